@@ -1,21 +1,98 @@
 package main
 
 import (
+	"errors"
 	"flag"
+	"fmt"
 	"log"
+	"os"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/Hru-s/driftwatch/internal/app" // change to your module path if needed
 )
 
+// labelFlag collects repeated -label key=value flags.
+type labelFlag map[string]string
+
+func (l labelFlag) String() string {
+	keys := make([]string, 0, len(l))
+	for k := range l {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, k+"="+l[k])
+	}
+	return strings.Join(parts, ",")
+}
+
+func (l labelFlag) Set(v string) error {
+	key, val, ok := strings.Cut(v, "=")
+	key = strings.TrimSpace(key)
+	if !ok || key == "" {
+		return fmt.Errorf("invalid label %q (expected key=value)", v)
+	}
+	l[key] = strings.TrimSpace(val)
+	return nil
+}
+
+// stringsFlag collects a repeated string flag.
+type stringsFlag []string
+
+func (s *stringsFlag) String() string { return strings.Join(*s, ",") }
+
+func (s *stringsFlag) Set(v string) error {
+	*s = append(*s, v)
+	return nil
+}
+
 func main() {
 	mode := flag.String("mode", "single",
-		"Mode: 'single' (baseline YAML vs live cluster) or 'cluster-compare' (cluster A vs cluster B)")
+		"Mode: 'single' (baseline YAML vs live cluster) or 'cluster-compare' (cluster A vs cluster B) or 'snapshot-compare' (snapshot A vs snapshot B)")
 
 	baselineDir := flag.String("baseline", "",
-		"Path to baseline policy YAML directory (RBAC, NetworkPolicy, PSA) for single mode")
+		"Path to baseline policy YAML directory (RBAC, NetworkPolicy, PSA) for single mode, a single multi-document .yaml file, - to read one from stdin, an https:// URL to a .tar/.tar.gz bundle or .yaml file, or git::https://host/org/repo.git[?ref=branch|tag|commit]; a snapshot file written by -snapshot-out is also accepted")
+
+	snapshotOut := flag.String("snapshot-out", "",
+		"Single mode: write the collected live RBAC, NetworkPolicy and PSA state to this file (JSON if it ends in .json, else YAML) or directory (snapshot.yaml), for later use as -baseline or -snapshot-a/-snapshot-b; without -baseline only the snapshot is written")
+
+	snapshotA := flag.String("snapshot-a", "",
+		"Snapshot-compare mode: snapshot file written by -snapshot-out to compare from (the baseline side)")
+	snapshotB := flag.String("snapshot-b", "",
+		"Snapshot-compare mode: snapshot file written by -snapshot-out to compare against snapshot A")
+
+	baselineSHA256 := flag.String("baseline-sha256", "",
+		"Expected SHA-256 (hex) of a downloaded -baseline bundle or file; the run fails on mismatch (not for git::)")
 
 	kubeconfig := flag.String("kubeconfig", "",
-		"Path to kubeconfig file for the live cluster (single mode)")
+		"Path to kubeconfig file for the live cluster (single mode), or recorded:<dir> to replay recorded List responses")
+
+	kubeContext := flag.String("context", "",
+		"kubeconfig context to use for the live cluster (default: the current-context)")
+
+	contextA := flag.String("context-a", "",
+		"kubeconfig context to use for cluster A (cluster-compare mode)")
+
+	contextB := flag.String("context-b", "",
+		"kubeconfig context to use for cluster B (cluster-compare mode)")
+
+	apiServer := flag.String("api-server", "",
+		"URL of the live cluster's API server (single mode); connects with -token or -token-file instead of a kubeconfig")
+
+	token := flag.String("token", "",
+		"Bearer token for -api-server")
+
+	tokenFile := flag.String("token-file", "",
+		"File holding the bearer token for -api-server (re-read periodically, e.g. a projected ServiceAccount token)")
+
+	caFile := flag.String("ca-file", "",
+		"PEM CA bundle that verifies the -api-server certificate (default: system roots)")
+
+	inCluster := flag.Bool("in-cluster", false,
+		"Connect to the cluster driftwatch runs in with the pod's ServiceAccount (single mode; e.g. as a CronJob)")
 
 	kubeconfigA := flag.String("kubeconfig-a", "",
 		"Path to kubeconfig for baseline cluster A (cluster-compare mode)")
@@ -28,36 +105,404 @@ func main() {
 
 	ignoreSystem := flag.Bool("ignore-system", true,
 		"Ignore kube-system and system:* subjects/namespaces when reporting drift (default true)")
+	systemNamespaces := flag.String("system-namespaces", "",
+		"Namespaces -ignore-system treats as system: comma-separated names or /regex/ patterns replacing kube-system,kube-public; a leading + extends them (e.g. +kube-node-lease,/^gke-/)")
+	systemSubjectPrefixes := flag.String("system-subject-prefixes", "",
+		"Subject and role name prefixes -ignore-system treats as system, replacing system:; a leading + extends them (e.g. +eks:,gke:)")
+
+	includeNamespaces := flag.String("include-namespaces", "",
+		"Only report findings in these namespaces: comma-separated names or /regex/ patterns (applied before -exclude-namespaces and -ignore-system)")
+	excludeNamespaces := flag.String("exclude-namespaces", "",
+		"Do not report findings in these namespaces: comma-separated names or /regex/ patterns (applied after -include-namespaces, before -ignore-system)")
 
 	output := flag.String("output", "text",
-		"Output format: text|json ")
+		"Output format: text|json|yaml|sarif|kubediff|ndjson-findings (one finding per line, then a summary line)|junit (one testsuite per category, one failed testcase per finding)")
+
+	outputDir := flag.String("output-dir", "",
+		"Write the report as one JSON file per section (rbac.json, netpol.json, psa.json, ...) plus summary.json into this directory instead of stdout")
+	metricsOut := flag.String("metrics-out", "",
+		"Also write drift counts as Prometheus gauges (driftwatch_rbac_extra_subjects, driftwatch_netpol_missing_total, ...) labeled by cluster and mode to this file, in the node exporter textfile format; rewritten every -watch cycle")
 
 	subjectKind := flag.String("subject-kind", "All",
 		"Filter by subject kind: ServiceAccount|User|Group|All ")
 
 	subjectName := flag.String("subject-name", "",
 		"Filter by subject name (exact or /regex/)")
+	subjectNameFile := flag.String("subject-name-file", "",
+		"File with one subject name (exact or /regex/) per line; entries are OR-ed with -subject-name")
 
 	subjectNamespace := flag.String("subject-namespace", "",
 		"Filter by subject namespace (exact or /regex/)")
 
+	ignoreFile := flag.String("ignore-file", "",
+		"YAML file of accepted drift (rbac: subject and optional permissions; networkPolicies: namespace and optional name; psa: namespace) removed from the RBAC, NetworkPolicy and PSA findings; the report counts what it removed")
+
+	reportTitle := flag.String("report-title", "",
+		"Optional title echoed into the report header/metadata")
+
+	labels := labelFlag{}
+	flag.Var(labels, "label",
+		"Report metadata label key=value (repeatable), e.g. -label team=payments")
+
+	auditRoles := flag.Bool("audit-roles", false,
+		"Also report ClusterRoles granting wildcard verbs on all or sensitive resources (posture check, not drift)")
+
+	auditIPBlocks := flag.Bool("audit-ipblocks", false,
+		"Also report NetworkPolicies whose ipBlocks allow 0.0.0.0/0, ::/0 or very broad ranges (posture check, not drift)")
+	ipBlockMaxPrefix := flag.Int("ipblock-max-prefix", 8,
+		"With -audit-ipblocks, flag IPv4 ipBlocks with a prefix length at or below this value")
+
+	auditNetPolSelectors := flag.Bool("audit-netpol-selectors", false,
+		"Also report NetworkPolicy namespaceSelectors that match no namespace in the cluster (advisory; likely dead rules; not with -namespace-selector)")
+
+	netpolOpenness := flag.Bool("netpol-openness", false,
+		"Also report NetworkPolicies that admit more traffic in live than in the baseline: rules with no from/to peers, open to every peer (on the listed ports, or on all ports)")
+	defaultDenyCoverage := flag.Bool("default-deny-coverage", false,
+		"Also report namespaces without an ingress or egress default-deny NetworkPolicy in live, as a regression when the baseline has one")
+
+	regoPolicy := flag.String("rego", "",
+		"Rego policy file evaluated against the live snapshot; each element of data.driftwatch.deny (string or {msg, severity, namespace, name}) is reported")
+
+	ignoreDefaultClusterRoles := flag.Bool("ignore-default-clusterroles", false,
+		"Exclude Kubernetes' built-in ClusterRoles (system:*, cluster-admin, admin, edit, view) and their bootstrap bindings from RBAC analysis")
+
+	psaExemptions := flag.String("psa-exemptions", "",
+		"API server PodSecurity admission config (or its exemptions block); exempted namespaces count as privileged")
+
+	explainPSA := flag.Bool("explain-psa", false,
+		"Print the PSA enforce-level ranking (unset < privileged < baseline < restricted) and its rationale above the PSA section")
+
+	psaManagedBy := flag.String("compare-annotations-on-psa", "",
+		"Flag live namespaces with PSA labels whose annotation is absent or wrong, as annotation[=value] (e.g. app.kubernetes.io/managed-by=policy-controller); without a value the baseline namespace's annotation is expected")
+
+	strictPSA := flag.Bool("strict-psa", false,
+		"Report PSA enforce levels that cannot be ordered (unknown/custom values) as errors and fail the run")
+	strict := flag.Bool("strict", false,
+		"Fail when the baseline holds policy-relevant documents of unrecognized kinds (e.g. a misspelled Rolebinding or a PodSecurityPolicy) instead of warning that they were not loaded")
+
+	redact := flag.Bool("redact", false,
+		"Replace subject names with salted hashes in the report (kind and namespace are kept)")
+	redactSalt := flag.String("redact-salt", "",
+		"Salt for -redact hashing; reuse it within an export batch so subjects correlate, change it between batches")
+
+	storageClasses := flag.Bool("storage-classes", false,
+		"Also compare StorageClasses (provisioner, reclaimPolicy, default-class annotation)")
+
+	resourceQuotas := flag.Bool("resource-quotas", false,
+		"Also compare ResourceQuotas (hard limits per namespace)")
+	limitRanges := flag.Bool("limit-ranges", false,
+		"Also compare LimitRanges (default, defaultRequest, max and min per limit type)")
+	admissionWebhooks := flag.Bool("admission-webhooks", false,
+		"Also compare Validating/MutatingWebhookConfigurations (webhooks, failurePolicy, rules, namespaceSelector)")
+	serviceAccounts := flag.Bool("service-accounts", false,
+		"Also compare the baseline's ServiceAccounts (automountServiceAccountToken, imagePullSecrets, secrets)")
+
+	checkImages := flag.Bool("check-images", false,
+		"Also flag Pod images whose registry is not in the baseline ConfigMap driftwatch-allowed-registries (cluster-compare: not used in cluster A)")
+
+	listConcurrency := flag.Int("list-concurrency", 4,
+		"Maximum concurrent List calls per cluster (0 = unbounded)")
+	qps := flag.Float64("qps", 50,
+		"Client-side rate limit of Kubernetes API requests per cluster, in requests per second (0 = client-go default of 5)")
+	burst := flag.Int("burst", 100,
+		"Maximum burst of Kubernetes API requests per cluster above -qps (0 = client-go default of 10)")
+
+	as := flag.String("as", "",
+		"Impersonate this user or ServiceAccount (system:serviceaccount:<namespace>:<name>) for all Kubernetes API requests")
+	var asGroups stringsFlag
+	flag.Var(&asGroups, "as-group",
+		"Group to impersonate with -as; may be repeated")
+	asUID := flag.String("as-uid", "",
+		"UID to impersonate with -as")
+
+	workload := flag.String("workload", "",
+		"Show the effective permissions of a Deployment's ServiceAccount (namespace/deployment), diffed against baseline")
+
+	validateSchema := flag.Bool("validate-baseline-schema", false,
+		"Validate baseline objects against the live cluster's OpenAPI schema and report errors per file (single mode)")
+	validateBaseline := flag.Bool("validate-baseline", false,
+		"Only load -baseline and print the Roles, ClusterRoles, bindings, NetworkPolicies and Namespaces read, and the files skipped as unrecognized kinds; no cluster is contacted")
+
+	stats := flag.Bool("stats", false,
+		"Add collection statistics to the report: subjects, distinct permissions, NetworkPolicies per namespace, PSA-labeled namespaces")
+
+	findingsOnly := flag.Bool("findings-only", false,
+		"Omit the report header/metadata; JSON output contains only the findings object")
+
+	quiet := flag.Bool("quiet", false,
+		"Text output: print only sections with findings, without the header or \"No ... drift detected\" lines; warnings go to stderr")
+
+	summary := flag.Bool("summary", false,
+		"Print finding counts per category and drift type (rbac.extra, psa.missing, ...) instead of the findings (text, json and yaml output)")
+
+	fast := flag.Bool("fast", false,
+		"Report changed NetworkPolicies by spec hash only, skipping per-field change detail (faster on huge clusters)")
+
+	shard := flag.String("shard", "",
+		"Only collect and report namespaces in shard i of N (i/N, 0-based); cluster-scoped objects are still listed by every shard and reported by shard 0")
+
+	namespaceSelector := flag.String("namespace-selector", "",
+		"Only collect namespaced objects from namespaces matching this label selector (e.g. team=payments); cluster-scoped objects are still collected")
+
+	reportHistory := flag.String("report-history", "",
+		"Directory to store each run's findings as <UTC timestamp>.json (stable formatting, suitable for committing to git)")
+	reportDiffGit := flag.Bool("report-diff-against-git", false,
+		"With -report-history, print new/resolved/persisting findings versus the newest report committed at git HEAD")
+	baselineState := flag.String("baseline-state", "",
+		"File recording this run's findings; the next run marks its findings new, persisting or resolved against it (created on the first run)")
+	failOnDrift := flag.String("fail-on-drift", "",
+		"Exit with status 2 when there is drift: any, or new (findings not in -baseline-state)")
+	notifyOn := flag.String("notify-on", "any",
+		"When -notify-webhook fires: any (every run with findings) or new (only runs with findings not in -baseline-state)")
+
+	sortOrder := flag.String("sort", "",
+		"Order findings within each section: severity|namespace|name|subject (default: by subject/namespace)")
+
+	timeout := flag.Duration("timeout", 60*time.Second,
+		"Timeout for collecting from the cluster(s) in one run (e.g. 5m for large clusters behind slow links; 0 = no timeout)")
+
+	maxRuntime := flag.Duration("max-runtime", 0,
+		"Hard cap on one run (e.g. 2m); on expiry render a partial report marking uncollected sections and exit non-zero (0 = no cap)")
+
+	emitEvents := flag.Bool("emit-events", false,
+		"Create Kubernetes Events describing the findings in the live cluster (cluster B in cluster-compare mode)")
+	eventsNamespace := flag.String("events-namespace", "default",
+		"Namespace the -emit-events Events are created in")
+	notifyWebhook := flag.String("notify-webhook", "",
+		"POST a JSON summary of each run with findings to this URL (e.g. a Slack incoming webhook): a \"text\" message with the counts and top findings, plus the structured report")
+
+	watch := flag.Duration("watch", 0,
+		"Re-run the comparison on this interval (e.g. 5m); 0 runs once. -fail-on-severity, -strict-psa and -fail-on-drift end the watch at the first cycle that trips them")
+
+	watchOnChange := flag.Bool("watch-on-change", false,
+		"In watch mode, only emit a report (and -emit-events, -notify-webhook) when findings differ from the previous cycle, listing what changed")
+
+	serve := flag.String("serve", "",
+		"Run as a service listening on this address (e.g. :8080): re-run the comparison every -interval and serve the latest result at /metrics (Prometheus) and /report (JSON)")
+	serveInterval := flag.Duration("interval", 5*time.Minute,
+		"With -serve, how often the comparison is re-run")
+
+	groupMap := flag.String("group-map", "",
+		"YAML/JSON file mapping users to groups; expands Group permissions to member Users")
+
+	showProgress := flag.Bool("progress", true,
+		"Show collection progress on stderr (only when stderr is a terminal and -output is text)")
+	logLevel := flag.String("log-level", "warn",
+		"Diagnostic log level on stderr: debug (every List call with object counts and durations), info (connections and collection phases), warn or error")
+	verbose := flag.Bool("v", false,
+		"Shorthand for -log-level debug")
+
+	compareLabels := flag.String("compare-labels", "",
+		"Comma-separated metadata label keys to compare on NetworkPolicies and Namespaces")
+
+	failOnSeverity := flag.String("fail-on-severity", "",
+		"Exit with code 2 if a finding at or above this severity survives filtering: critical|high|medium|low")
+
+	minSeverity := flag.String("min-severity", "",
+		"Only show RBAC permissions at or above this severity: critical|high|medium|low")
+
+	severityConfig := flag.String("severity-config", "",
+		"YAML/JSON file tuning severities, e.g. subjectKindBoost: {User: 1, Group: 1} to rank User/Group RBAC findings above ServiceAccounts")
+
+	rbacScope := flag.String("rbac-scope", "both",
+		"RBAC findings to show: cluster (cluster-wide grants) | namespace | both")
+
+	detectRenames := flag.Bool("detect-renames", false,
+		"Report a subject missing in live plus a new subject of the same kind with identical permissions as one rename")
+
+	resourceNamesAsSet := flag.Bool("diff-resourcenames-as-set", false,
+		"Compare resourceName lists of named RBAC grants as sets and report added/removed names per grant")
+
+	rbacSemantic := flag.Bool("rbac-semantic", false,
+		"Treat RBAC wildcards as covering concrete grants: only report permissions no permission on the other side covers (e.g. verb=* resource=* covers get pods)")
+
+	normalizeVerbs := flag.Bool("normalize-verbs", false,
+		"Lowercase RBAC verbs before diffing and warn about unknown verbs (catches typos like 'Get')")
+
+	maxPerms := flag.Int("max-perms-per-subject", 0,
+		"Show at most N permissions per RBAC subject, with a '+M more' note (0 = unlimited)")
+
+	expectedSA := flag.String("expected-sa-annotation", "",
+		"Drop RBAC drift for live ServiceAccounts carrying this annotation (key or key=value; bare key means \"true\"), e.g. driftwatch.io/expected-privileged")
+
+	maxBaselineFileMB := flag.Int64("max-baseline-file-mb", 10,
+		"Reject baseline files larger than this many MiB, including stdin, downloads and tarball entries (0 = unlimited)")
+	baselineReadTimeout := flag.Duration("baseline-read-timeout", 30*time.Second,
+		"Fail when reading a single baseline file takes longer than this (0 = no timeout)")
+
+	netpolIncludeChanged := flag.Bool("netpol-include-changed", true,
+		"Include NetworkPolicies whose spec changed, regardless of -drift-type (set false for a pure missing/extra view)")
+
+	lastApplied := flag.Bool("baseline-last-applied-config", false,
+		"Also compare each live NetworkPolicy, RBAC object and Namespace with its own kubectl last-applied-configuration annotation (detects kubectl edit drift)")
+
+	changedDetailThreshold := flag.Int("include-changed-detail-threshold", 0,
+		"In text output, list changed NetworkPolicies as bare namespace/name lines once more than this many changed (0 = always show per-policy detail)")
+
+	netpolStabilize := flag.Duration("ignore-transient-netpol-changes", 0,
+		"Read live NetworkPolicies twice this far apart (e.g. 10s) and skip changes to policies rewritten in between (0 = single read)")
+
+	bindingDiff := flag.Bool("binding-diff", false,
+		"Also report, per Role/ClusterRole reference, which subjects were added to or removed from its bindings")
+
+	clusterRoleView := flag.Bool("clusterrole-view", false,
+		"Also report, per ClusterRole, every subject bound to it via RoleBindings and ClusterRoleBindings in baseline vs live")
+
+	mergeScopes := flag.Bool("merge-cluster-and-namespace-scope", false,
+		"Annotate namespaced RBAC permissions that the same subject also has cluster-wide as covered by the cluster-wide grant")
+
 	flag.Parse()
 
 	opts := app.Options{
-		Mode:             *mode,
-		BaselineDir:      *baselineDir,
-		Kubeconfig:       *kubeconfig,
-		KubeconfigA:      *kubeconfigA,
-		KubeconfigB:      *kubeconfigB,
-		DriftType:        *driftType,
-		IgnoreSystem:     *ignoreSystem,
-		SubjectKind:      *subjectKind,
-		SubjectName:      *subjectName,
-		SubjectNamespace: *subjectNamespace,
-		OutputFormat:     *output,
+		Mode:                      *mode,
+		BaselineDir:               *baselineDir,
+		SnapshotOut:               *snapshotOut,
+		SnapshotA:                 *snapshotA,
+		SnapshotB:                 *snapshotB,
+		Kubeconfig:                *kubeconfig,
+		KubeconfigA:               *kubeconfigA,
+		KubeconfigB:               *kubeconfigB,
+		Context:                   *kubeContext,
+		ContextA:                  *contextA,
+		ContextB:                  *contextB,
+		APIServer:                 *apiServer,
+		Token:                     *token,
+		TokenFile:                 *tokenFile,
+		CAFile:                    *caFile,
+		InCluster:                 *inCluster,
+		DriftType:                 *driftType,
+		IgnoreSystem:              *ignoreSystem,
+		SystemNamespaces:          *systemNamespaces,
+		SystemSubjectPrefixes:     *systemSubjectPrefixes,
+		IncludeNamespaces:         *includeNamespaces,
+		ExcludeNamespaces:         *excludeNamespaces,
+		SubjectKind:               *subjectKind,
+		SubjectName:               *subjectName,
+		SubjectNamespace:          *subjectNamespace,
+		SubjectNameFile:           *subjectNameFile,
+		IgnoreFile:                *ignoreFile,
+		OutputFormat:              *output,
+		ReportTitle:               *reportTitle,
+		Labels:                    labels,
+		AuditRoles:                *auditRoles,
+		WatchInterval:             *watch,
+		Serve:                     *serve,
+		ServeInterval:             *serveInterval,
+		WatchOnChange:             *watchOnChange,
+		GroupMapFile:              *groupMap,
+		Progress:                  *showProgress,
+		LogLevel:                  *logLevel,
+		CompareLabels:             splitList(*compareLabels),
+		FailOnSeverity:            *failOnSeverity,
+		MinSeverity:               *minSeverity,
+		BaselineSHA256:            *baselineSHA256,
+		RBACScope:                 *rbacScope,
+		NormalizeVerbs:            *normalizeVerbs,
+		MaxPermsPerSubject:        *maxPerms,
+		ExpectedSAAnnotation:      *expectedSA,
+		MaxBaselineFileBytes:      baselineLimitBytes(*maxBaselineFileMB),
+		BaselineReadTimeout:       baselineReadLimit(*baselineReadTimeout),
+		NetPolOmitChanged:         !*netpolIncludeChanged,
+		BindingDiff:               *bindingDiff,
+		MergeScopes:               *mergeScopes,
+		AuditIPBlocks:             *auditIPBlocks,
+		IPBlockMaxPrefix:          *ipBlockMaxPrefix,
+		IgnoreDefaultClusterRoles: *ignoreDefaultClusterRoles,
+		StrictPSA:                 *strictPSA,
+		Strict:                    *strict,
+		Redact:                    *redact,
+		RedactSalt:                *redactSalt,
+		StorageClasses:            *storageClasses,
+		ResourceQuotas:            *resourceQuotas,
+		LimitRanges:               *limitRanges,
+		AdmissionWebhooks:         *admissionWebhooks,
+		ServiceAccounts:           *serviceAccounts,
+		ListConcurrency:           *listConcurrency,
+		QPS:                       float32(*qps),
+		Burst:                     *burst,
+		As:                        *as,
+		AsGroups:                  asGroups,
+		AsUID:                     *asUID,
+		Workload:                  *workload,
+		ValidateBaselineSchema:    *validateSchema,
+		ValidateBaseline:          *validateBaseline,
+		FindingsOnly:              *findingsOnly,
+		Summary:                   *summary,
+		Quiet:                     *quiet,
+		Shard:                     *shard,
+		NamespaceSelector:         *namespaceSelector,
+		AuditNetPolSelectors:      *auditNetPolSelectors,
+		NetPolOpenness:            *netpolOpenness,
+		DefaultDenyCoverage:       *defaultDenyCoverage,
+		ReportHistoryDir:          *reportHistory,
+		ReportDiffAgainstGit:      *reportDiffGit,
+		BaselineState:             *baselineState,
+		FailOnDrift:               *failOnDrift,
+		NotifyOn:                  *notifyOn,
+		PSAExemptionsFile:         *psaExemptions,
+		SortOrder:                 *sortOrder,
+		CheckImages:               *checkImages,
+		DetectRenames:             *detectRenames,
+		Timeout:                   *timeout,
+		MaxRuntime:                *maxRuntime,
+		EmitEvents:                *emitEvents,
+		EventsNamespace:           *eventsNamespace,
+		NotifyWebhook:             *notifyWebhook,
+		Fast:                      *fast,
+		ResourceNamesAsSet:        *resourceNamesAsSet,
+		RBACSemantic:              *rbacSemantic,
+		Stats:                     *stats,
+		RegoPolicy:                *regoPolicy,
+		NetPolStabilizeWindow:     *netpolStabilize,
+		ClusterRoleView:           *clusterRoleView,
+		ExplainPSA:                *explainPSA,
+		PSAManagedBy:              *psaManagedBy,
+		OutputDir:                 *outputDir,
+		MetricsOut:                *metricsOut,
+		ChangedDetailThreshold:    *changedDetailThreshold,
+		LastApplied:               *lastApplied,
+		SeverityConfigFile:        *severityConfig,
+	}
+	if *verbose {
+		opts.LogLevel = "debug"
 	}
 
 	if err := app.Run(opts); err != nil {
+		var sevErr *app.SeverityThresholdError
+		var driftErr *app.DriftFoundError
+		if errors.As(err, &sevErr) || errors.As(err, &driftErr) {
+			fmt.Fprintf(os.Stderr, "driftwatch: %v\n", err)
+			os.Exit(2)
+		}
 		log.Fatalf("error: %v", err)
 	}
 }
+
+// splitList parses a comma-separated flag value, dropping empty entries.
+func splitList(s string) []string {
+	var out []string
+	for _, part := range strings.Split(s, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
+
+// baselineLimitBytes converts the -max-baseline-file-mb flag into the byte
+// limit understood by app.Options (negative = unlimited).
+func baselineLimitBytes(mb int64) int64 {
+	if mb <= 0 {
+		return -1
+	}
+	return mb << 20
+}
+
+// baselineReadLimit converts the -baseline-read-timeout flag into the
+// timeout understood by app.Options (negative = unlimited).
+func baselineReadLimit(d time.Duration) time.Duration {
+	if d <= 0 {
+		return -1
+	}
+	return d
+}
@@ -0,0 +1,35 @@
+// Package driftwatch runs driftwatch's analysis in-process, for programs
+// that embed it (e.g. a controller) instead of running the CLI.
+package driftwatch
+
+import (
+	"context"
+
+	"github.com/Hru-s/driftwatch/internal/app"
+)
+
+// Options configures one analysis; the fields mirror the CLI flags.
+type Options = app.Options
+
+// Report is the result of one analysis, the structure -output json prints.
+type Report = app.Report
+
+// The sections of a Report.
+type (
+	RBACReport           = app.RBACReport
+	SubjectPermissions   = app.SubjectPermissions
+	PermissionFinding    = app.PermissionFinding
+	NetPolReport         = app.NetPolReport
+	PSAReport            = app.PSAReport
+	ResourceQuotaReport  = app.ResourceQuotaReport
+	LimitRangeReport     = app.LimitRangeReport
+	ServiceAccountReport = app.ServiceAccountReport
+)
+
+// Analyze performs one analysis as configured by opts and returns its
+// report without printing anything. Options that only affect rendering or
+// the exit status are ignored; see app.Analyze. Concurrent calls with
+// different Options do not affect each other.
+func Analyze(ctx context.Context, opts Options) (*Report, error) {
+	return app.Analyze(ctx, opts)
+}
@@ -1,19 +1,28 @@
 package app
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"regexp"
 	"sort"
 	"strings"
 	"time"
 
+	"github.com/Hru-s/driftwatch/internal/audit"
 	"github.com/Hru-s/driftwatch/internal/collectors"
 	"github.com/Hru-s/driftwatch/internal/diff"
 	"github.com/Hru-s/driftwatch/internal/kube"
 	"github.com/Hru-s/driftwatch/internal/model"
+	"github.com/Hru-s/driftwatch/internal/source"
+
+	"golang.org/x/sync/errgroup"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 )
 
 type Options struct {
@@ -24,142 +33,1601 @@ type Options struct {
 	KubeconfigA string
 	KubeconfigB string
 
+	// Context, ContextA and ContextB select a kubeconfig context other
+	// than the current-context for the matching kubeconfig.
+	Context  string
+	ContextA string
+	ContextB string
+
+	// APIServer connects to the live cluster directly with a bearer token
+	// (Token or TokenFile) instead of a kubeconfig; single mode only.
+	// CAFile verifies the server certificate.
+	APIServer string
+	Token     string
+	TokenFile string
+	CAFile    string
+
+	// InCluster connects to the cluster driftwatch runs in, using the
+	// pod's mounted ServiceAccount (single mode, instead of -kubeconfig).
+	InCluster bool
+
 	DriftType    string
 	IgnoreSystem bool
 
+	// SystemNamespaces and SystemSubjectPrefixes are comma-separated lists
+	// replacing what IgnoreSystem treats as system namespaces (names or
+	// /regex/ patterns; default kube-system, kube-public) and system
+	// subject/role name prefixes (default "system:"). A leading "+"
+	// extends the defaults instead.
+	SystemNamespaces      string
+	SystemSubjectPrefixes string
+
+	// IncludeNamespaces and ExcludeNamespaces are comma-separated namespace
+	// names or /regex/ patterns. A namespaced finding is kept only if it
+	// matches IncludeNamespaces (when set), then dropped if it matches
+	// ExcludeNamespaces, then dropped by IgnoreSystem if it is in a system
+	// namespace. Cluster-scoped findings are not affected by the lists.
+	IncludeNamespaces string
+	ExcludeNamespaces string
+
 	SubjectKind      string
 	SubjectName      string
 	SubjectNamespace string
 
+	// SubjectNameFile lists one subject name (or /regex/) per line; its
+	// entries are OR-ed with SubjectName. Run loads it into SubjectNames.
+	SubjectNameFile string
+	SubjectNames    []string
+
+	// IgnoreFile lists accepted drift (see ignoreList) that is removed
+	// from the RBAC, NetworkPolicy and PSA findings and counted instead.
+	IgnoreFile string
+
 	OutputFormat string
+
+	// OutputDir replaces the report on stdout with one JSON file per
+	// section (rbac.json, netpol.json, psa.json, ...) plus summary.json.
+	OutputDir string
+
+	// MetricsOut receives the run's drift counts as Prometheus gauges in
+	// the node exporter textfile format, rewritten every -watch cycle.
+	MetricsOut string
+
+	// Report metadata, echoed verbatim so aggregated reports can be attributed.
+	ReportTitle string
+	Labels      map[string]string
+
+	// AuditRoles enables the point-in-time ClusterRole wildcard posture check.
+	AuditRoles bool
+
+	// WatchInterval > 0 re-runs the analysis on that interval; the exit
+	// gates end the watch at the first cycle that trips them. WatchOnChange
+	// suppresses output, Events and notifications for cycles whose findings
+	// match the previous one, and reports what changed otherwise.
+	WatchInterval time.Duration
+	WatchOnChange bool
+
+	// Serve, a listen address such as ":8080", runs driftwatch as a
+	// service: the analysis is repeated every ServeInterval and the latest
+	// result is served at /metrics (Prometheus) and /report (JSON).
+	Serve         string
+	ServeInterval time.Duration
+
+	// GroupMapFile maps users to groups so Group permissions can be
+	// expanded down to member Users before diffing.
+	GroupMapFile string
+
+	// Progress prints collection phases to stderr (interactive text runs only).
+	Progress bool
+
+	// LogLevel ("debug", "info", "warn" or "error") enables the diagnostic
+	// log on stderr; empty discards it.
+	LogLevel string
+
+	// CompareLabels lists metadata label keys compared on NetworkPolicies
+	// and Namespaces (empty = labels are ignored).
+	CompareLabels []string
+
+	// FailOnSeverity makes Run return a SeverityThresholdError when a
+	// finding at or above this severity survives filtering ("" = never).
+	FailOnSeverity string
+
+	// MinSeverity drops RBAC permissions classified below this severity
+	// from the extra and missing lists ("" = keep all).
+	MinSeverity string
+
+	// BaselineSHA256 is the expected checksum of a remote -baseline tarball.
+	BaselineSHA256 string
+
+	// RBACScope limits RBAC findings to cluster-wide or namespaced
+	// permissions: cluster|namespace|both.
+	RBACScope string
+
+	// NormalizeVerbs lowercases RBAC verbs and warns on unknown ones.
+	NormalizeVerbs bool
+
+	// MaxPermsPerSubject caps the permissions rendered per subject (0 = all).
+	MaxPermsPerSubject int
+
+	// ExpectedSAAnnotation ("key" or "key=value") marks ServiceAccounts
+	// that are intentionally privileged; their RBAC drift is dropped.
+	// A bare key matches the value "true".
+	ExpectedSAAnnotation string
+
+	// MaxBaselineFileBytes caps each baseline file's size (0 = collectors'
+	// default, negative = unlimited), including a baseline read from
+	// stdin or downloaded and each file of a baseline tarball.
+	MaxBaselineFileBytes int64
+
+	// BaselineReadTimeout bounds reading each baseline file (0 =
+	// collectors' default, negative = unlimited).
+	BaselineReadTimeout time.Duration
+
+	// NetPolOmitChanged drops the NetworkPolicy "changed" bucket so
+	// -drift-type gives a pure missing/extra view.
+	NetPolOmitChanged bool
+
+	// ChangedDetailThreshold switches the text report's changed-policy
+	// list to bare namespace/name lines once more than this many policies
+	// changed (0 = always print the per-policy detail).
+	ChangedDetailThreshold int
+
+	// BindingDiff adds a per-role view of subjects added to / removed
+	// from each Role/ClusterRole reference.
+	BindingDiff bool
+
+	// MergeScopes annotates namespaced RBAC entries that the same subject
+	// also holds cluster-wide.
+	MergeScopes bool
+
+	// AuditIPBlocks enables the NetworkPolicy ipBlock breadth posture check;
+	// IPBlockMaxPrefix is the IPv4 prefix length at or below which a range is
+	// flagged (0 = 8).
+	AuditIPBlocks    bool
+	IPBlockMaxPrefix int
+
+	// IgnoreDefaultClusterRoles drops Kubernetes' built-in ClusterRoles and
+	// their bootstrap bindings before RBAC is compared.
+	IgnoreDefaultClusterRoles bool
+
+	// StrictPSA reports PSA levels that cannot be ordered ("different") as
+	// errors instead of bucketing them into extra, and fails the run.
+	StrictPSA bool
+
+	// Strict fails the run when the baseline holds policy-relevant
+	// documents of unrecognized kinds (a misspelled Rolebinding, a
+	// PodSecurityPolicy) instead of warning that they were not loaded.
+	Strict bool
+
+	// Redact hashes subject names in every output format; RedactSalt keys
+	// the hash so it only correlates within one export batch.
+	Redact     bool
+	RedactSalt string
+
+	// StorageClasses adds StorageClass drift (provisioner, reclaimPolicy
+	// and the default-class annotation) to the report.
+	StorageClasses bool
+
+	// ResourceQuotas adds ResourceQuota drift (hard limits per namespace)
+	// to the report.
+	ResourceQuotas bool
+
+	// LimitRanges adds LimitRange drift (default, defaultRequest, max and
+	// min per limit type) to the report.
+	LimitRanges bool
+
+	// AdmissionWebhooks adds Validating/MutatingWebhookConfiguration drift
+	// (webhooks, failurePolicy, rules and namespaceSelector) to the report.
+	AdmissionWebhooks bool
+
+	// ServiceAccounts adds drift of the baseline's ServiceAccounts
+	// (automountServiceAccountToken, imagePullSecrets, secrets) to the
+	// report.
+	ServiceAccounts bool
+
+	// ListConcurrency caps concurrent List calls per cluster (0 = unbounded).
+	ListConcurrency int
+
+	// QPS and Burst are the client-side rate limit of each cluster client
+	// (0 = client-go's default).
+	QPS   float32
+	Burst int
+
+	// As, AsGroups and AsUID impersonate a user or ServiceAccount for
+	// every cluster client, so drift is checked as that identity sees it.
+	As       string
+	AsGroups []string
+	AsUID    string
+
+	// Workload ("namespace/deployment") adds the effective permissions of
+	// that Deployment's ServiceAccount, compared against the baseline.
+	Workload string
+
+	// ValidateBaselineSchema checks baseline objects against the live
+	// cluster's OpenAPI schema (single mode) and reports errors as warnings.
+	ValidateBaselineSchema bool
+
+	// ValidateBaseline only loads -baseline and prints how many objects of
+	// each kind the collectors read and which files they skipped.
+	ValidateBaseline bool
+
+	// FindingsOnly drops the report header/metadata: text output starts at
+	// the first findings section and JSON holds only the findings object.
+	FindingsOnly bool
+
+	// SnapshotOut, in single mode, receives the collected live RBAC,
+	// NetworkPolicy and PSA state as a snapshot file that -baseline
+	// accepts. Without BaselineDir the run only writes the snapshot.
+	SnapshotOut string
+
+	// SnapshotA and SnapshotB are the snapshot files compared in
+	// snapshot-compare mode, A taking the place of the baseline.
+	SnapshotA string
+	SnapshotB string
+
+	// Quiet trims text output to the findings: no header, no sections
+	// without findings and no "No ... drift detected" lines. Warnings go to
+	// stderr.
+	Quiet bool
+
+	// Summary replaces the findings of -output text, json and yaml with
+	// their counts per report section ("rbac.extra", "psa.missing", ...).
+	Summary bool
+
+	// AuditNetPolSelectors enables the advisory check for NetworkPolicy
+	// namespaceSelectors that match no namespace in the same cluster. It
+	// needs every namespace, so NamespaceSelector cannot be set with it.
+	AuditNetPolSelectors bool
+
+	// NetPolOpenness reports NetworkPolicy directions that admit more
+	// traffic in live than in the baseline (see diff.DiffNetPolOpenness).
+	NetPolOpenness bool
+
+	// DefaultDenyCoverage reports live namespaces without an ingress or
+	// egress default-deny NetworkPolicy (see diff.DiffDefaultDeny).
+	DefaultDenyCoverage bool
+
+	// ReportHistoryDir, when set, receives one findings file per run
+	// (<UTC timestamp>.json). With ReportDiffAgainstGit the run is also
+	// compared with the newest report committed at HEAD of that directory's
+	// git repository. Not used in watch mode.
+	ReportHistoryDir     string
+	ReportDiffAgainstGit bool
+
+	// BaselineState records each run's findings so the next run can mark
+	// its findings new, persisting or resolved. FailOnDrift ("any" or
+	// "new") fails the run on drift; NotifyOn ("any", the default, or
+	// "new") limits -notify-webhook to runs with new findings.
+	BaselineState string
+	FailOnDrift   string
+	NotifyOn      string
+
+	// PSAExemptionsFile is the API server's PodSecurity admission config
+	// (or just its exemptions block). Exempted namespaces on the live side
+	// are treated as privileged regardless of their labels.
+	PSAExemptionsFile string
+
+	// Timeout bounds the API calls of one analysis, and the publishing of
+	// its Events and notification. 0 means no timeout.
+	Timeout time.Duration
+
+	// MaxRuntime caps one whole analysis (baseline fetch plus collection).
+	// When it expires, sections not yet collected are marked incomplete,
+	// the partial report is rendered and Run returns IncompleteRunError.
+	MaxRuntime time.Duration
+	deadline   time.Time
+	ignores    *ignoreList          // loaded from IgnoreFile
+	severity   model.SeverityConfig // loaded from SeverityConfigFile
+
+	// stdinBaseline is where a -baseline - read once for -watch or -serve
+	// was saved, so later cycles do not read stdin at EOF.
+	stdinBaseline string
+
+	// EmitEvents records the findings as Events in the live cluster
+	// (cluster B in cluster-compare mode) so they show up in
+	// `kubectl get events`. EventsNamespace defaults to "default".
+	// Events are created as the caller, not the -as identity. In watch
+	// mode every cycle adds a summary Event, but the per-finding Events
+	// only when the findings changed since they were last recorded.
+	EmitEvents      bool
+	EventsNamespace string
+
+	// NotifyWebhook receives a JSON summary of each run with findings (a
+	// Slack-compatible "text" plus the structured report).
+	NotifyWebhook string
+
+	// ExplainPSA prints the PSA enforce-level ranking and its rationale at
+	// the top of the text PSA section.
+	ExplainPSA bool
+
+	// PSAManagedBy ("annotation[=value]") flags live namespaces with PSA
+	// labels whose annotation is absent or differs from value (or, with no
+	// value, from the baseline namespace's annotation).
+	PSAManagedBy string
+
+	// ClusterRoleView adds a section keyed by ClusterRole listing the
+	// subjects bound to it (via RoleBindings and ClusterRoleBindings) in
+	// baseline and live, and whether that set drifted.
+	ClusterRoleView bool
+
+	// RegoPolicy is a Rego file whose data.driftwatch.deny rule is evaluated
+	// against the live snapshot; each element is reported as a finding.
+	RegoPolicy string
+
+	// Stats adds an inventory block (subjects, permissions, NetworkPolicies
+	// per namespace, PSA-labeled namespaces) counted from the snapshots.
+	Stats bool
+
+	// ResourceNamesAsSet compares the resourceName lists of named grants as
+	// sets and reports added/removed names per grant instead of unrelated
+	// extra/missing permission lines.
+	ResourceNamesAsSet bool
+
+	// RBACSemantic compares RBAC permissions with wildcards expanded: a
+	// permission is only extra (missing) when no baseline (live) permission
+	// of the same subject covers it, e.g. verb=* resource=* covers get pods.
+	RBACSemantic bool
+
+	// DetectRenames reports a subject missing from live and a new live
+	// subject with identical permissions as one rename (SA rotation).
+	DetectRenames bool
+
+	// CheckImages lists Pods and flags images whose registry is not in the
+	// baseline allowlist (in cluster-compare mode: not used in cluster A).
+	CheckImages bool
+
+	// SeverityConfigFile tunes finding severities, e.g. boosting RBAC
+	// findings for User and Group subjects over ServiceAccounts.
+	SeverityConfigFile string
+
+	// LastApplied compares each live object (cluster A and B in
+	// cluster-compare mode) with its own last-applied-configuration
+	// annotation, catching `kubectl edit` changes since the last apply.
+	LastApplied bool
+
+	// SortOrder reorders findings within every section: severity,
+	// namespace, name or subject ("" keeps the default ordering).
+	SortOrder string
+
+	// NetPolStabilizeWindow, when positive, reads live NetworkPolicies twice
+	// this far apart and does not report a change for policies whose spec
+	// differed between the reads (controllers rewriting policies).
+	NetPolStabilizeWindow time.Duration
+
+	// Fast reports changed NetworkPolicies by spec hash only, skipping the
+	// per-field change detail. The ipBlock and namespaceSelector audits
+	// and -rego need that detail and cannot be combined with it.
+	Fast bool
+
+	// Shard ("i/N") restricts the report to namespaces hashing to shard i
+	// so large clusters can be fanned out; cluster-scoped findings go to 0.
+	// Live collection is scoped too: each shard still lists Namespaces and
+	// every cluster-scoped kind (ClusterRoles and their bindings,
+	// StorageClasses, webhooks) in full, but lists namespaced kinds only
+	// in the namespaces it owns, one List per namespace. That moves about
+	// 1/N of the namespaced objects, at the price of more round trips
+	// than a cluster-wide List when namespaces are many and small.
+	Shard string
+
+	// NamespaceSelector is a label selector ("team=payments") scoping live
+	// collection to matching namespaces: they are listed once per cluster,
+	// and every namespaced kind is then listed only in them. In single
+	// mode baseline objects in other namespaces are dropped too, so they
+	// do not show as missing.
+	NamespaceSelector string
 }
 
+// driftResults bundles everything the renderers need for one report.
+type driftResults struct {
+	RBAC            diff.RBACDrift
+	NetPol          diff.NetPolDrift
+	PSA             diff.PSADrift
+	RoleAudit       []model.RoleRiskFinding
+	NetAudit        []model.NetPolRiskFinding
+	SelAudit        []model.NetPolRiskFinding
+	Openness        []model.NetPolOpennessChange
+	DefaultDeny     []model.DefaultDenyGap
+	Rego            []model.RegoViolation
+	Labels          []model.LabelChange
+	Bindings        []model.BindingChange
+	RoleSubjects    []model.ClusterRoleSubjects
+	Dangling        []model.DanglingBinding
+	Storage         diff.StorageClassDrift
+	Quotas          diff.ResourceQuotaDrift
+	LimitRanges     diff.LimitRangeDrift
+	Webhooks        diff.WebhookDrift
+	ServiceAccounts diff.ServiceAccountDrift
+	Images          []model.ImageViolation
+	LastApplied     []model.LastAppliedDrift
+	Workload        *workloadJSON
+	History         *reportDelta
+	Stats           *collectionStats
+	Warnings        []string
+	// Incomplete lists sections cut short by -max-runtime.
+	Incomplete []string
+	// events is the client -emit-events writes with; see eventsClient.
+	events kubernetes.Interface
+}
+
+// Run performs one analysis (or, with WatchInterval, one per interval),
+// renders it in the configured output format and applies the exit gates
+// (-fail-on-severity, -strict-psa, -max-runtime).
 func Run(opts Options) error {
+	if opts.LogLevel != "" {
+		level, err := parseLogLevel(opts.LogLevel)
+		if err != nil {
+			return fmt.Errorf("-log-level: %w", err)
+		}
+		configureLogging(level)
+	}
+
+	var threshold model.Severity
+	if opts.FailOnSeverity != "" {
+		sev, err := model.ParseSeverity(opts.FailOnSeverity)
+		if err != nil {
+			return fmt.Errorf("-fail-on-severity: %w", err)
+		}
+		threshold = sev
+	}
+
+	if opts.OutputDir != "" {
+		if f := normalizeOutputFormat(opts.OutputFormat); f != "text" && f != "json" {
+			return fmt.Errorf("-output-dir writes JSON files and cannot be combined with -output %s", f)
+		}
+	}
+	if opts.Summary {
+		if opts.OutputDir != "" {
+			return fmt.Errorf("-summary cannot be combined with -output-dir")
+		}
+		if f := normalizeOutputFormat(opts.OutputFormat); f != "text" && f != "json" && f != "yaml" {
+			return fmt.Errorf("-summary supports -output text, json and yaml, not %s", f)
+		}
+	}
+	if opts.QPS < 0 {
+		return fmt.Errorf("-qps must not be negative, got %g", opts.QPS)
+	}
+	if opts.Burst < 0 {
+		return fmt.Errorf("-burst must not be negative, got %d", opts.Burst)
+	}
+	if opts.As == "" && (len(opts.AsGroups) > 0 || opts.AsUID != "") {
+		return fmt.Errorf("-as-group and -as-uid require -as")
+	}
+	if opts.ReportDiffAgainstGit && opts.ReportHistoryDir == "" {
+		return fmt.Errorf("-report-diff-against-git requires -report-history")
+	}
+	if opts.BaselineState != "" && opts.ReportDiffAgainstGit {
+		return fmt.Errorf("-baseline-state cannot be combined with -report-diff-against-git")
+	}
+	if opts.BaselineState != "" && opts.Serve != "" {
+		return fmt.Errorf("-baseline-state is not supported with -serve")
+	}
+	if err := checkDriftTrigger("-fail-on-drift", opts.FailOnDrift, opts); err != nil {
+		return err
+	}
+	if err := checkDriftTrigger("-notify-on", opts.NotifyOn, opts); err != nil {
+		return err
+	}
+	if opts.SnapshotOut != "" && opts.Mode != "single" {
+		return fmt.Errorf("-snapshot-out is only supported in single mode")
+	}
+	if opts.NotifyWebhook != "" {
+		if err := checkNotifyWebhook(opts.NotifyWebhook); err != nil {
+			return err
+		}
+	}
+
+	if opts.BaselineDir == source.Stdin && (opts.Serve != "" || opts.WatchInterval > 0) {
+		// stdin can be read once; every cycle compares against that copy
+		dir, cleanup, err := source.Resolve(context.Background(), source.Stdin, "", collectorConfig(opts).MaxFileBytes())
+		if err != nil {
+			return fmt.Errorf("resolving baseline %s: %w", opts.BaselineDir, err)
+		}
+		defer cleanup()
+		opts.stdinBaseline = dir
+	}
+
+	if opts.Serve != "" {
+		if opts.WatchInterval > 0 {
+			return fmt.Errorf("-serve and -watch cannot be combined; use -interval")
+		}
+		if opts.FailOnSeverity != "" || opts.StrictPSA || opts.FailOnDrift != "" {
+			return fmt.Errorf("-fail-on-severity, -strict-psa and -fail-on-drift end a run and cannot be combined with -serve")
+		}
+		if opts.ServeInterval <= 0 {
+			return fmt.Errorf("-interval must be positive, got %s", opts.ServeInterval)
+		}
+		// fail on bad options now rather than in every cycle
+		if _, err := prepareOptions(opts); err != nil {
+			return err
+		}
+		return runServe(opts)
+	}
+
+	if opts.WatchInterval > 0 {
+		opts, err := prepareOptions(opts)
+		if err != nil {
+			return err
+		}
+		return runWatch(opts, threshold)
+	}
+
+	if opts.ValidateBaseline {
+		return validateBaseline(context.Background(), opts)
+	}
+	if opts.SnapshotOut != "" && opts.BaselineDir == "" && opts.Mode == "single" {
+		return captureSnapshot(context.Background(), opts)
+	}
+
+	report, err := Analyze(context.Background(), opts)
+	if err != nil {
+		return err
+	}
+	opts, modeLabel, res := report.opts, report.Mode, report.res
+
+	// A partial report would show every uncollected finding as resolved.
+	keepHistory := opts.ReportHistoryDir != "" && len(res.Incomplete) == 0
+	if opts.ReportHistoryDir != "" && !keepHistory {
+		fmt.Fprintln(os.Stderr, "report history not updated: report is partial")
+	}
+
+	var history driftFindingsJSON
+	if keepHistory {
+		history = historyFindings(modeLabel, opts, res)
+		if opts.ReportDiffAgainstGit {
+			if err := os.MkdirAll(opts.ReportHistoryDir, 0o755); err != nil {
+				return fmt.Errorf("-report-history: %w", err)
+			}
+			name, prev, err := lastCommittedReport(opts.ReportHistoryDir)
+			if err != nil {
+				return fmt.Errorf("-report-diff-against-git: %w", err)
+			}
+			res.History = diffReports(name, prev, history)
+		}
+	}
+
+	var state driftFindingsJSON
+	keepState := false
+	if opts.BaselineState != "" {
+		state, keepState, err = trackBaselineState(modeLabel, opts, &res)
+		if err != nil {
+			return fmt.Errorf("-baseline-state: %w", err)
+		}
+	}
+
+	if err := renderReport(modeLabel, opts, res); err != nil {
+		return err
+	}
+
+	if keepHistory {
+		path, err := writeReportHistory(opts.ReportHistoryDir, history, time.Now())
+		if err != nil {
+			return fmt.Errorf("-report-history: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "report history written to %s\n", path)
+	}
+
+	if opts.EmitEvents {
+		if err := publishEvents(modeLabel, opts, res, true); err != nil {
+			return fmt.Errorf("-emit-events: %w", err)
+		}
+	}
+
+	if opts.MetricsOut != "" {
+		if err := writeMetricsFile(opts.MetricsOut, modeLabel, opts, res); err != nil {
+			return fmt.Errorf("-metrics-out: %w", err)
+		}
+	}
+
+	if opts.NotifyWebhook != "" {
+		if err := notifyWebhook(modeLabel, opts, res); err != nil {
+			return fmt.Errorf("-notify-webhook: %w", err)
+		}
+	}
+
+	// recorded only once the Events and the notification went out, or a
+	// failed delivery would turn its new findings into persisting ones
+	if keepState {
+		if err := writeBaselineState(opts.BaselineState, state); err != nil {
+			return fmt.Errorf("-baseline-state: %w", err)
+		}
+	}
+
+	if err := exitGates(modeLabel, opts, res, threshold); err != nil {
+		return err
+	}
+
+	if len(res.Incomplete) > 0 {
+		return &IncompleteRunError{MaxRuntime: opts.MaxRuntime, Sections: res.Incomplete}
+	}
+	return nil
+}
+
+// exitGates applies -strict-psa, -fail-on-severity (threshold) and
+// -fail-on-drift to a reported run.
+func exitGates(modeLabel string, opts Options, res driftResults, threshold model.Severity) error {
+	if opts.StrictPSA {
+		gateOpts := normalizeOptions(opts)
+		if bad := psaDriftToJSON(res.PSA, gateOpts, nil).Incomparable; len(bad) > 0 {
+			namespaces := make([]string, 0, len(bad))
+			for _, e := range bad {
+				namespaces = append(namespaces, e.Namespace)
+			}
+			return fmt.Errorf("-strict-psa: incomparable PSA enforce levels in namespaces: %s", strings.Join(namespaces, ", "))
+		}
+	}
+
+	if threshold != "" {
+		// Severity gating must see every permission, not the rendered subset.
+		gateOpts := normalizeOptions(opts)
+		gateOpts.MaxPermsPerSubject = 0
+		highest := highestSeverity(buildJSONReport(modeLabel, gateOpts, res))
+		if highest.Rank() >= threshold.Rank() {
+			return &SeverityThresholdError{Threshold: threshold, Highest: highest}
+		}
+	}
+	return driftGate(modeLabel, opts, res)
+}
+
+// Analyze performs one analysis and returns its report without printing
+// it. Rendering, notification and exit-gate options are ignored; sections
+// cut short by MaxRuntime are listed in Report.Incomplete.
+func Analyze(ctx context.Context, opts Options) (*Report, error) {
+	opts, err := prepareOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+	modeLabel, res, err := analyze(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	report := buildJSONReport(modeLabel, opts, res)
+	return &report, nil
+}
+
+// prepareOptions validates opts, loads the files they reference and
+// normalizes them for analyze.
+func prepareOptions(opts Options) (Options, error) {
+	if opts.Shard != "" {
+		if _, err := parseShard(opts.Shard); err != nil {
+			return opts, fmt.Errorf("-shard: %w", err)
+		}
+	}
+	if _, err := parseSortOrder(opts.SortOrder); err != nil {
+		return opts, fmt.Errorf("-sort: %w", err)
+	}
+	if err := validateNamespaceList(strings.TrimPrefix(strings.TrimSpace(opts.SystemNamespaces), "+")); err != nil {
+		return opts, fmt.Errorf("-system-namespaces: %w", err)
+	}
+	if err := validateNamespaceList(opts.IncludeNamespaces); err != nil {
+		return opts, fmt.Errorf("-include-namespaces: %w", err)
+	}
+	if err := validateNamespaceList(opts.ExcludeNamespaces); err != nil {
+		return opts, fmt.Errorf("-exclude-namespaces: %w", err)
+	}
+	if opts.NamespaceSelector != "" {
+		if _, err := labels.Parse(opts.NamespaceSelector); err != nil {
+			return opts, fmt.Errorf("-namespace-selector: %w", err)
+		}
+		// the audit matches selectors against every namespace, which
+		// -namespace-selector keeps from being collected
+		if opts.AuditNetPolSelectors {
+			return opts, fmt.Errorf("-audit-netpol-selectors cannot be combined with -namespace-selector")
+		}
+	}
+	if opts.MinSeverity != "" {
+		if _, err := model.ParseSeverity(opts.MinSeverity); err != nil {
+			return opts, fmt.Errorf("-min-severity: %w", err)
+		}
+	}
+
+	if opts.SeverityConfigFile != "" {
+		cfg, err := collectors.LoadSeverityConfig(opts.SeverityConfigFile)
+		if err != nil {
+			return opts, fmt.Errorf("-severity-config: %w", err)
+		}
+		opts.severity = cfg
+	}
+
+	if opts.PSAManagedBy != "" {
+		if key, _ := parsePSAManagedBy(opts.PSAManagedBy); key == "" {
+			return opts, fmt.Errorf("-compare-annotations-on-psa: missing annotation name in %q", opts.PSAManagedBy)
+		}
+	}
+	if opts.Fast && (opts.AuditIPBlocks || opts.AuditNetPolSelectors || opts.NetPolOpenness || opts.DefaultDenyCoverage || opts.RegoPolicy != "") {
+		return opts, fmt.Errorf("-fast cannot be combined with -audit-ipblocks, -audit-netpol-selectors, -netpol-openness, -default-deny-coverage or -rego")
+	}
+
+	if opts.SubjectNameFile != "" {
+		names, err := loadSubjectNameFile(opts.SubjectNameFile)
+		if err != nil {
+			return opts, fmt.Errorf("-subject-name-file: %w", err)
+		}
+		opts.SubjectNames = append(opts.SubjectNames, names...)
+	}
+	if opts.IgnoreFile != "" {
+		ignores, err := loadIgnoreFile(opts.IgnoreFile)
+		if err != nil {
+			return opts, fmt.Errorf("-ignore-file: %w", err)
+		}
+		opts.ignores = ignores
+	}
+	return normalizeOptions(opts), nil
+}
+
+func analyze(ctx context.Context, opts Options) (string, driftResults, error) {
+	if opts.MaxRuntime > 0 {
+		opts.deadline = time.Now().Add(opts.MaxRuntime)
+	}
+
+	var (
+		modeLabel string
+		res       driftResults
+		err       error
+	)
+	start := time.Now()
 	switch opts.Mode {
 	case "single":
-		return runSingle(opts)
+		modeLabel, res, err = runSingle(ctx, opts)
 	case "cluster-compare":
-		return runClusterCompare(opts)
+		modeLabel, res, err = runClusterCompare(ctx, opts)
+	case "snapshot-compare":
+		modeLabel, res, err = runSnapshotCompare(ctx, opts)
 	default:
-		return fmt.Errorf("unknown mode: %s (supported: single, cluster-compare)", opts.Mode)
+		return "", driftResults{}, fmt.Errorf("unknown mode: %s (supported: single, cluster-compare, snapshot-compare)", opts.Mode)
+	}
+	if err != nil {
+		return "", driftResults{}, err
+	}
+	logger.Info("analysis finished", "mode", opts.Mode, "elapsed", time.Since(start).Round(time.Millisecond))
+
+	if opts.Shard != "" {
+		shard, err := parseShard(opts.Shard)
+		if err != nil {
+			return "", driftResults{}, fmt.Errorf("-shard: %w", err)
+		}
+		applyShard(shard, &res)
+	}
+	return modeLabel, res, nil
+}
+
+// runWatch re-runs the analysis every WatchInterval until the process is
+// stopped. With WatchOnChange, a cycle is only reported (and its Events and
+// notification sent) when its findings differ from the previous cycle's;
+// the prior findings are kept in memory and compared like
+// -report-diff-against-git compares reports. The exit gates (-strict-psa,
+// -fail-on-severity with threshold, -fail-on-drift) apply to every cycle:
+// the first cycle that trips one ends the watch with its error.
+func runWatch(opts Options, threshold model.Severity) error {
+	ticker := time.NewTicker(opts.WatchInterval)
+	defer ticker.Stop()
+
+	var (
+		prev     driftFindingsJSON
+		prevFP   []byte
+		prevAt   time.Time
+		eventsFP []byte // findings of the last per-finding Events
+	)
+	first := true
+	for {
+		modeLabel, res, err := analyze(context.Background(), opts)
+		if err != nil {
+			// Keep watching; a single failed cycle (e.g. API server blip)
+			// should not stop the monitor.
+			fmt.Fprintf(os.Stderr, "watch cycle failed: %v\n", err)
+		} else {
+			var state driftFindingsJSON
+			keepState := false
+			if opts.BaselineState != "" {
+				state, keepState, err = trackBaselineState(modeLabel, opts, &res)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "-baseline-state: %v\n", err)
+				}
+			}
+			cur := historyFindings(modeLabel, opts, res)
+			fp, err := json.Marshal(cur)
+			if err != nil {
+				return err
+			}
+			// a policy that changed again keeps its key; compare the details
+			changed := first || !bytes.Equal(fp, prevFP)
+			delta := diffReports(prevAt.UTC().Format(time.RFC3339), prev, cur)
+			if opts.WatchOnChange && !first && res.History == nil {
+				// -baseline-state already reports the change since the
+				// previous run, which in watch mode is the previous cycle
+				delta.since = "previous cycle"
+				res.History = delta
+			}
+			if !opts.WatchOnChange || changed {
+				if err := renderReport(modeLabel, opts, res); err != nil {
+					return err
+				}
+				if opts.EmitEvents {
+					// per-finding Events only when the findings differ
+					// from the last ones published, so an unchanged
+					// drift is not recorded again every cycle
+					findingEvents := !bytes.Equal(fp, eventsFP)
+					if err := publishEvents(modeLabel, opts, res, findingEvents); err != nil {
+						fmt.Fprintf(os.Stderr, "-emit-events: %v\n", err)
+						keepState = false
+					} else if findingEvents {
+						eventsFP = fp
+					}
+				}
+				if opts.NotifyWebhook != "" {
+					if err := notifyWebhook(modeLabel, opts, res); err != nil {
+						fmt.Fprintf(os.Stderr, "-notify-webhook: %v\n", err)
+						keepState = false
+					}
+				}
+			}
+			// a failed delivery leaves the state as it was, so the next
+			// cycle reports (and delivers) the same findings as new
+			if keepState {
+				if err := writeBaselineState(opts.BaselineState, state); err != nil {
+					fmt.Fprintf(os.Stderr, "-baseline-state: %v\n", err)
+				}
+			}
+			// unlike the report, metrics carry the run time, so every
+			// cycle rewrites them
+			if opts.MetricsOut != "" {
+				if err := writeMetricsFile(opts.MetricsOut, modeLabel, opts, res); err != nil {
+					fmt.Fprintf(os.Stderr, "-metrics-out: %v\n", err)
+				}
+			}
+			if err := exitGates(modeLabel, opts, res, threshold); err != nil {
+				return err
+			}
+			prev, prevFP, prevAt = cur, fp, time.Now()
+			first = false
+		}
+		<-ticker.C
 	}
 }
 
-func runSingle(opts Options) error {
+// checkLiveCluster validates the options that select the live cluster of
+// single mode.
+func checkLiveCluster(opts Options) error {
+	if opts.Context != "" && opts.Kubeconfig == "" {
+		return fmt.Errorf("-context requires -kubeconfig")
+	}
+	switch {
+	case opts.InCluster:
+		if opts.Kubeconfig != "" || opts.APIServer != "" {
+			return fmt.Errorf("-in-cluster cannot be combined with -kubeconfig or -api-server")
+		}
+	case opts.APIServer != "":
+		if opts.Kubeconfig != "" {
+			return fmt.Errorf("-api-server and -kubeconfig are mutually exclusive")
+		}
+		if (opts.Token == "") == (opts.TokenFile == "") {
+			return fmt.Errorf("-api-server requires exactly one of -token or -token-file")
+		}
+	case opts.Kubeconfig == "":
+		return fmt.Errorf("-kubeconfig (or -api-server, or -in-cluster) is required in single mode")
+	}
+	return nil
+}
+
+// collectorConfig returns the collector settings of opts.
+func collectorConfig(opts Options) collectors.Config {
+	return collectors.Config{
+		MaxBaselineFileBytes:    opts.MaxBaselineFileBytes,
+		BaselineReadTimeout:     opts.BaselineReadTimeout,
+		Strict:                  opts.Strict,
+		SkipDefaultClusterRoles: opts.IgnoreDefaultClusterRoles,
+		NetPolHashOnly:          opts.Fast,
+		NetPolStabilizeWindow:   opts.NetPolStabilizeWindow,
+	}
+}
+
+// clientConfig returns the client settings of opts: rate limits, the
+// impersonation of -as, -as-group and -as-uid, and the -api-server
+// connection (single mode only).
+func clientConfig(opts Options) kube.ClientConfig {
+	return kube.ClientConfig{
+		QPS:             opts.QPS,
+		Burst:           opts.Burst,
+		Impersonate:     rest.ImpersonationConfig{UserName: opts.As, UID: opts.AsUID, Groups: opts.AsGroups},
+		ListConcurrency: opts.ListConcurrency,
+		Direct: kube.DirectConfig{
+			Host:      opts.APIServer,
+			Token:     opts.Token,
+			TokenFile: opts.TokenFile,
+			CAFile:    opts.CAFile,
+		},
+	}
+}
+
+func runSingle(ctx context.Context, opts Options) (string, driftResults, error) {
 	if opts.BaselineDir == "" {
-		return fmt.Errorf("-baseline is required in single mode")
+		return "", driftResults{}, fmt.Errorf("-baseline is required in single mode")
+	}
+	if err := checkLiveCluster(opts); err != nil {
+		return "", driftResults{}, err
+	}
+	cfg := collectorConfig(opts)
+
+	// Remote baselines are fetched into a temp dir; local paths pass through.
+	baselineDir := opts.stdinBaseline
+	if opts.BaselineDir != source.Stdin || baselineDir == "" {
+		resolveCtx, cancelResolve := runContext(ctx, opts)
+		defer cancelResolve()
+		dir, cleanup, err := source.Resolve(resolveCtx, opts.BaselineDir, opts.BaselineSHA256, cfg.MaxFileBytes())
+		if err != nil {
+			return "", driftResults{}, fmt.Errorf("resolving baseline %s: %w", opts.BaselineDir, err)
+		}
+		defer cleanup()
+		baselineDir = dir
+	}
+	opts.BaselineDir = baselineDir
+
+	snapshot, fromSnapshot, err := collectors.LoadSnapshotFile(opts.BaselineDir, cfg)
+	if err != nil {
+		return "", driftResults{}, fmt.Errorf("loading baseline %s: %w", opts.BaselineDir, err)
+	}
+	if fromSnapshot {
+		if flag := manifestOnlyFlag(opts); flag != "" {
+			return "", driftResults{}, fmt.Errorf("%s needs a manifest baseline; snapshots hold only RBAC, NetworkPolicies and namespaces", flag)
+		}
+	}
+
+	var schemaProblems []string
+	if opts.ValidateBaselineSchema {
+		specs, err := kube.BuildSchemaSource(opts.Kubeconfig, opts.Context, clientConfig(opts).Direct)
+		if err != nil {
+			return "", driftResults{}, fmt.Errorf("loading OpenAPI schema: %w", err)
+		}
+		schemaProblems, err = collectors.ValidateBaselineSchema(opts.BaselineDir, specs, cfg)
+		if err != nil {
+			return "", driftResults{}, fmt.Errorf("validating baseline %s: %w", opts.BaselineDir, err)
+		}
+	}
+
+	logger.Info("connecting to live cluster", "kubeconfig", opts.Kubeconfig, "context", opts.Context, "apiServer", opts.APIServer)
+	clientLive, err := kube.BuildClient(opts.Kubeconfig, opts.Context, clientConfig(opts))
+	if err != nil {
+		return "", driftResults{}, fmt.Errorf("creating client for live cluster: %w", err)
+	}
+	events, err := eventsClient(clientLive, opts.Kubeconfig, opts.Context, opts)
+	if err != nil {
+		return "", driftResults{}, err
+	}
+
+	ctx, cancel := runContext(ctx, opts)
+	defer cancel()
+	partial := &partialRun{ctx: ctx, opts: opts}
+
+	prog := newProgress(opts)
+
+	rbacBaseline, netpolBaseline, psaBaseline := snapshot.RBAC, snapshot.NetPol, snapshot.PSA
+	if !fromSnapshot {
+		start := time.Now()
+		rbacBaseline, err = collectors.CollectRBACFromBaselineDir(opts.BaselineDir, cfg)
+		if err != nil {
+			return "", driftResults{}, fmt.Errorf("loading baseline RBAC from %s: %w", opts.BaselineDir, err)
+		}
+		netpolBaseline, err = collectors.CollectNetPolFromBaselineDir(opts.BaselineDir, cfg)
+		if err != nil {
+			return "", driftResults{}, fmt.Errorf("loading baseline NetworkPolicies from %s: %w", opts.BaselineDir, err)
+		}
+		psaBaseline, err = collectors.CollectPSAFromBaselineDir(opts.BaselineDir, cfg)
+		if err != nil {
+			return "", driftResults{}, fmt.Errorf("loading baseline PSA from %s: %w", opts.BaselineDir, err)
+		}
+		logger.Info("loaded baseline", "path", opts.BaselineDir, "subjects", len(rbacBaseline.Subjects),
+			"networkPolicies", len(netpolBaseline.Items), "namespaces", len(psaBaseline), "elapsed", time.Since(start).Round(time.Millisecond))
+	}
+
+	cfg, scope, err := scopeNamespaces(ctx, clientLive, cfg, opts, "live cluster")
+	if err != nil {
+		return "", driftResults{}, err
+	}
+
+	var live clusterSnapshots
+	g, gctx := errgroup.WithContext(ctx)
+	collectCluster(gctx, g, clientLive, cfg, "live cluster", prog, partial, &live)
+	if err := g.Wait(); err != nil {
+		return "", driftResults{}, err
+	}
+	if err := writeSnapshot(opts, partial, live); err != nil {
+		return "", driftResults{}, err
+	}
+	rbacLive, netpolLive, psaLive := live.rbac, live.netpol, live.psa
+	scopeBaseline(scope, rbacBaseline, netpolBaseline, &psaBaseline)
+
+	// -------- RBAC --------
+	if err := expandGroups(opts, rbacBaseline, rbacLive); err != nil {
+		return "", driftResults{}, err
+	}
+	normalizeVerbs(opts, rbacBaseline, rbacLive)
+	rbacDrift := diff.DiffRBAC(rbacBaseline, rbacLive)
+	if opts.RBACSemantic {
+		diff.DropCoveredPermissions(&rbacDrift, rbacBaseline, rbacLive)
+	}
+	if err := dropExpectedServiceAccounts(ctx, opts, clientLive, &rbacDrift); err != nil && !partial.tolerate(sectionRBAC, err) {
+		return "", driftResults{}, err
+	}
+	if opts.DetectRenames {
+		diff.DetectSubjectRenames(&rbacDrift, rbacBaseline, rbacLive)
+	}
+	if opts.ResourceNamesAsSet {
+		diff.GroupResourceNameChanges(&rbacDrift, rbacBaseline, rbacLive)
+	}
+	var workload *workloadJSON
+	if opts.Workload != "" {
+		workload, err = resolveWorkload(ctx, opts.Workload, clientLive, rbacBaseline, rbacLive)
+		if err != nil && !partial.tolerate(sectionRBAC, err) {
+			return "", driftResults{}, err
+		}
+	}
+
+	// ------ NetworkPolicy ------
+	netpolDrift := diff.DiffNetworkPolicies(netpolBaseline, netpolLive)
+
+	// ------ PSA (Pod Security Admission) ------
+	exemptions, err := loadPSAExemptions(opts)
+	if err != nil {
+		return "", driftResults{}, err
+	}
+	psaDrift := diff.DiffPSA(psaBaseline, psaLive, exemptions.Namespaces)
+	if opts.PSAManagedBy != "" {
+		key, want := parsePSAManagedBy(opts.PSAManagedBy)
+		diff.CheckPSAManagedBy(&psaDrift, psaBaseline, psaLive, key, want)
+	}
+
+	res := driftResults{RBAC: rbacDrift, NetPol: netpolDrift, PSA: psaDrift, Workload: workload, events: events}
+	res.addWarnings("psa exemptions", psaExemptionWarnings(exemptions))
+	if opts.Stats {
+		res.Stats = &collectionStats{
+			Baseline: snapshotStatsOf(rbacBaseline, netpolBaseline, psaBaseline),
+			Live:     snapshotStatsOf(rbacLive, netpolLive, psaLive),
+		}
+	}
+
+	// ------ StorageClass ------
+	if opts.StorageClasses {
+		scBaseline, err := collectors.CollectStorageClassFromBaselineDir(opts.BaselineDir, cfg)
+		if err != nil {
+			return "", driftResults{}, fmt.Errorf("loading baseline StorageClasses from %s: %w", opts.BaselineDir, err)
+		}
+		prog.phase("collecting StorageClasses from live cluster")
+		scLive, err := collectors.CollectStorageClassFromCluster(ctx, clientLive)
+		if err != nil && !partial.tolerate(sectionStorage, err) {
+			return "", driftResults{}, fmt.Errorf("collecting StorageClasses from live cluster: %w", err)
+		}
+		prog.done(len(scLive), "StorageClasses")
+		res.Storage = diff.DiffStorageClasses(scBaseline, scLive)
+	}
+
+	// ------ ResourceQuota ------
+	if opts.ResourceQuotas {
+		rqBaseline, err := collectors.CollectQuotaFromBaselineDir(opts.BaselineDir, cfg)
+		if err != nil {
+			return "", driftResults{}, fmt.Errorf("loading baseline ResourceQuotas from %s: %w", opts.BaselineDir, err)
+		}
+		prog.phase("collecting ResourceQuotas from live cluster")
+		rqBaseline = scopeItems(scope, rqBaseline, func(d model.ResourceQuotaDigest) string { return d.Namespace })
+		rqLive, err := collectors.CollectQuotaFromCluster(ctx, clientLive, cfg)
+		if err != nil && !partial.tolerate(sectionQuotas, err) {
+			return "", driftResults{}, fmt.Errorf("collecting ResourceQuotas from live cluster: %w", err)
+		}
+		prog.done(len(rqLive), "ResourceQuotas")
+		res.Quotas = diff.DiffResourceQuotas(rqBaseline, rqLive)
+	}
+
+	// ------ LimitRange ------
+	if opts.LimitRanges {
+		lrBaseline, err := collectors.CollectLimitRangeFromBaselineDir(opts.BaselineDir, cfg)
+		if err != nil {
+			return "", driftResults{}, fmt.Errorf("loading baseline LimitRanges from %s: %w", opts.BaselineDir, err)
+		}
+		prog.phase("collecting LimitRanges from live cluster")
+		lrBaseline = scopeItems(scope, lrBaseline, func(d model.LimitRangeDigest) string { return d.Namespace })
+		lrLive, err := collectors.CollectLimitRangeFromCluster(ctx, clientLive, cfg)
+		if err != nil && !partial.tolerate(sectionLimitRanges, err) {
+			return "", driftResults{}, fmt.Errorf("collecting LimitRanges from live cluster: %w", err)
+		}
+		prog.done(len(lrLive), "LimitRanges")
+		res.LimitRanges = diff.DiffLimitRanges(lrBaseline, lrLive)
+	}
+
+	// ------ Admission webhooks ------
+	if opts.AdmissionWebhooks {
+		whBaseline, err := collectors.CollectWebhooksFromBaselineDir(opts.BaselineDir, cfg)
+		if err != nil {
+			return "", driftResults{}, fmt.Errorf("loading baseline webhook configurations from %s: %w", opts.BaselineDir, err)
+		}
+		prog.phase("collecting admission webhooks from live cluster")
+		whLive, err := collectors.CollectWebhooksFromCluster(ctx, clientLive)
+		if err != nil && !partial.tolerate(sectionWebhooks, err) {
+			return "", driftResults{}, fmt.Errorf("collecting admission webhooks from live cluster: %w", err)
+		}
+		prog.done(len(whLive), "webhooks")
+		res.Webhooks = diff.DiffWebhooks(whBaseline, whLive)
+	}
+
+	// ------ ServiceAccount ------
+	if opts.ServiceAccounts {
+		saBaseline, err := collectors.CollectServiceAccountsFromBaselineDir(opts.BaselineDir, cfg)
+		if err != nil {
+			return "", driftResults{}, fmt.Errorf("loading baseline ServiceAccounts from %s: %w", opts.BaselineDir, err)
+		}
+		prog.phase("collecting ServiceAccounts from live cluster")
+		saBaseline = scopeItems(scope, saBaseline, func(d model.ServiceAccountDigest) string { return d.Namespace })
+		saLive, err := collectors.CollectServiceAccountsFromCluster(ctx, clientLive, cfg)
+		if err != nil && !partial.tolerate(sectionSAs, err) {
+			return "", driftResults{}, fmt.Errorf("collecting ServiceAccounts from live cluster: %w", err)
+		}
+		prog.done(len(saLive), "ServiceAccounts")
+		res.ServiceAccounts = diff.DiffServiceAccounts(saBaseline, saLive)
+	}
+
+	// ------ Container images ------
+	if opts.CheckImages {
+		allowed, found, err := collectors.CollectAllowedRegistriesFromBaselineDir(opts.BaselineDir, cfg)
+		if err != nil {
+			return "", driftResults{}, fmt.Errorf("loading allowed image registries from %s: %w", opts.BaselineDir, err)
+		}
+		prog.phase("collecting Pod images from live cluster")
+		usage, err := collectors.CollectImageUsageFromCluster(ctx, clientLive, cfg)
+		if err != nil && !partial.tolerate(sectionImages, err) {
+			return "", driftResults{}, fmt.Errorf("collecting Pod images from live cluster: %w", err)
+		}
+		prog.done(len(usage), "namespaces")
+		if found {
+			res.Images = diff.DiffImageRegistries(usage, allowed)
+		} else {
+			res.addWarnings("baseline", []string{fmt.Sprintf(
+				"-check-images: no ConfigMap %q in baseline; image registries not checked", model.AllowedRegistriesConfigMap)})
+		}
+	}
+
+	// ------ Last-applied configuration ------
+	if opts.LastApplied {
+		prog.phase("collecting last-applied configurations from live cluster")
+		objs, warnings, err := collectors.CollectLastAppliedFromCluster(ctx, clientLive, cfg)
+		if err != nil && !partial.tolerate(sectionLastApplied, err) {
+			return "", driftResults{}, fmt.Errorf("collecting last-applied configurations from live cluster: %w", err)
+		}
+		prog.done(len(objs), "applied objects")
+		res.LastApplied = diff.DiffLastApplied(objs, "live")
+		res.addWarnings("live", warnings)
+	}
+
+	res.addWarnings("baseline", rbacBaseline.Warnings)
+	res.addWarnings("baseline", netpolBaseline.Warnings)
+	res.addWarnings("baseline schema", schemaProblems)
+	res.addWarnings("live", rbacLive.Warnings)
+	res.addWarnings("live", unstableNetPolWarnings(netpolLive))
+	if opts.BindingDiff {
+		res.Bindings = diff.DiffBindings(rbacBaseline, rbacLive)
+	}
+	if opts.ClusterRoleView {
+		res.RoleSubjects = diff.ClusterRoleSubjects(rbacBaseline, rbacLive)
+	}
+	res.Labels = append(res.Labels, diff.DiffNetPolLabels(netpolBaseline, netpolLive, opts.CompareLabels)...)
+	res.Labels = append(res.Labels, diff.DiffNamespaceLabels(psaBaseline, psaLive, opts.CompareLabels)...)
+	res.Dangling = append(danglingBindings(rbacBaseline, "baseline"), danglingBindings(rbacLive, "live")...)
+	if opts.AuditRoles {
+		res.RoleAudit = append(res.RoleAudit, audit.AuditClusterRoles(rbacBaseline, "baseline")...)
+		res.RoleAudit = append(res.RoleAudit, audit.AuditClusterRoles(rbacLive, "live")...)
+	}
+	if opts.AuditIPBlocks {
+		res.NetAudit = append(res.NetAudit, audit.AuditNetPolIPBlocks(netpolBaseline, "baseline", ipBlockMaxPrefix(opts))...)
+		res.NetAudit = append(res.NetAudit, audit.AuditNetPolIPBlocks(netpolLive, "live", ipBlockMaxPrefix(opts))...)
+	}
+	if opts.AuditNetPolSelectors {
+		// baseline dirs rarely carry every Namespace, so only live policies
+		// are joined against the live namespace set
+		res.SelAudit = audit.AuditNetPolNamespaceSelectors(netpolLive, "live", psaLive)
+	}
+	if opts.NetPolOpenness {
+		res.Openness = diff.DiffNetPolOpenness(netpolBaseline, netpolLive)
+	}
+	if opts.DefaultDenyCoverage {
+		res.DefaultDeny = diff.DiffDefaultDeny(netpolBaseline, netpolLive, psaLive)
+	}
+	if opts.RegoPolicy != "" {
+		res.Rego, err = audit.AuditRego(ctx, opts.RegoPolicy, audit.NewRegoInput("live", rbacLive, netpolLive, psaLive))
+		if err != nil {
+			return "", driftResults{}, fmt.Errorf("-rego: %w", err)
+		}
+	}
+	partial.apply(&res)
+
+	modeLabel := "single (baseline YAML vs live cluster)"
+	return modeLabel, res, nil
+}
+
+func runClusterCompare(ctx context.Context, opts Options) (string, driftResults, error) {
+	if opts.KubeconfigA == "" || opts.KubeconfigB == "" {
+		return "", driftResults{}, fmt.Errorf("both -kubeconfig-a and -kubeconfig-b are required for cluster-compare mode")
+	}
+	if opts.APIServer != "" || opts.InCluster {
+		return "", driftResults{}, fmt.Errorf("-api-server and -in-cluster are only supported in single mode")
+	}
+	cfg := collectorConfig(opts)
+
+	logger.Info("connecting to cluster A", "kubeconfig", opts.KubeconfigA, "context", opts.ContextA)
+	clientA, err := kube.BuildClient(opts.KubeconfigA, opts.ContextA, clientConfig(opts))
+	if err != nil {
+		return "", driftResults{}, fmt.Errorf("creating client for baseline cluster A: %w", err)
+	}
+	logger.Info("connecting to cluster B", "kubeconfig", opts.KubeconfigB, "context", opts.ContextB)
+	clientB, err := kube.BuildClient(opts.KubeconfigB, opts.ContextB, clientConfig(opts))
+	if err != nil {
+		return "", driftResults{}, fmt.Errorf("creating client for live cluster B: %w", err)
+	}
+	events, err := eventsClient(clientB, opts.KubeconfigB, opts.ContextB, opts)
+	if err != nil {
+		return "", driftResults{}, err
+	}
+
+	ctx, cancel := runContext(ctx, opts)
+	defer cancel()
+	partial := &partialRun{ctx: ctx, opts: opts}
+
+	prog := newProgress(opts)
+
+	cfgA, _, err := scopeNamespaces(ctx, clientA, cfg, opts, "cluster A")
+	if err != nil {
+		return "", driftResults{}, err
+	}
+	cfgB, _, err := scopeNamespaces(ctx, clientB, cfg, opts, "cluster B")
+	if err != nil {
+		return "", driftResults{}, err
+	}
+
+	var a, b clusterSnapshots
+	g, gctx := errgroup.WithContext(ctx)
+	collectCluster(gctx, g, clientA, cfgA, "cluster A", prog, partial, &a)
+	collectCluster(gctx, g, clientB, cfgB, "cluster B", prog, partial, &b)
+	if err := g.Wait(); err != nil {
+		return "", driftResults{}, err
+	}
+	rbacA, netpolA, psaA := a.rbac, a.netpol, a.psa
+	rbacB, netpolB, psaB := b.rbac, b.netpol, b.psa
+
+	// -------- RBAC --------
+	if err := expandGroups(opts, rbacA, rbacB); err != nil {
+		return "", driftResults{}, err
+	}
+	normalizeVerbs(opts, rbacA, rbacB)
+	rbacDrift := diff.DiffRBAC(rbacA, rbacB)
+	if opts.RBACSemantic {
+		diff.DropCoveredPermissions(&rbacDrift, rbacA, rbacB)
+	}
+	if err := dropExpectedServiceAccounts(ctx, opts, clientB, &rbacDrift); err != nil && !partial.tolerate(sectionRBAC, err) {
+		return "", driftResults{}, err
+	}
+	if opts.DetectRenames {
+		diff.DetectSubjectRenames(&rbacDrift, rbacA, rbacB)
+	}
+	if opts.ResourceNamesAsSet {
+		diff.GroupResourceNameChanges(&rbacDrift, rbacA, rbacB)
+	}
+	var workload *workloadJSON
+	if opts.Workload != "" {
+		workload, err = resolveWorkload(ctx, opts.Workload, clientB, rbacA, rbacB)
+		if err != nil && !partial.tolerate(sectionRBAC, err) {
+			return "", driftResults{}, err
+		}
+	}
+
+	// ------ NetworkPolicy ------
+	netpolDrift := diff.DiffNetworkPolicies(netpolA, netpolB)
+
+	// ------ PSA (Pod Security Admission) ------
+	exemptions, err := loadPSAExemptions(opts)
+	if err != nil {
+		return "", driftResults{}, err
+	}
+	psaDrift := diff.DiffPSA(psaA, psaB, exemptions.Namespaces)
+	if opts.PSAManagedBy != "" {
+		key, want := parsePSAManagedBy(opts.PSAManagedBy)
+		diff.CheckPSAManagedBy(&psaDrift, psaA, psaB, key, want)
+	}
+
+	res := driftResults{RBAC: rbacDrift, NetPol: netpolDrift, PSA: psaDrift, Workload: workload, events: events}
+	res.addWarnings("psa exemptions", psaExemptionWarnings(exemptions))
+	if opts.Stats {
+		res.Stats = &collectionStats{
+			Baseline: snapshotStatsOf(rbacA, netpolA, psaA),
+			Live:     snapshotStatsOf(rbacB, netpolB, psaB),
+		}
+	}
+
+	// ------ StorageClass ------
+	if opts.StorageClasses {
+		prog.phase("collecting StorageClasses from cluster A")
+		scA, err := collectors.CollectStorageClassFromCluster(ctx, clientA)
+		if err != nil && !partial.tolerate(sectionStorage, err) {
+			return "", driftResults{}, fmt.Errorf("collecting StorageClasses from cluster A: %w", err)
+		}
+		prog.done(len(scA), "StorageClasses")
+		prog.phase("collecting StorageClasses from cluster B")
+		scB, err := collectors.CollectStorageClassFromCluster(ctx, clientB)
+		if err != nil && !partial.tolerate(sectionStorage, err) {
+			return "", driftResults{}, fmt.Errorf("collecting StorageClasses from cluster B: %w", err)
+		}
+		prog.done(len(scB), "StorageClasses")
+		res.Storage = diff.DiffStorageClasses(scA, scB)
+	}
+
+	// ------ ResourceQuota ------
+	if opts.ResourceQuotas {
+		prog.phase("collecting ResourceQuotas from cluster A")
+		rqA, err := collectors.CollectQuotaFromCluster(ctx, clientA, cfgA)
+		if err != nil && !partial.tolerate(sectionQuotas, err) {
+			return "", driftResults{}, fmt.Errorf("collecting ResourceQuotas from cluster A: %w", err)
+		}
+		prog.done(len(rqA), "ResourceQuotas")
+		prog.phase("collecting ResourceQuotas from cluster B")
+		rqB, err := collectors.CollectQuotaFromCluster(ctx, clientB, cfgB)
+		if err != nil && !partial.tolerate(sectionQuotas, err) {
+			return "", driftResults{}, fmt.Errorf("collecting ResourceQuotas from cluster B: %w", err)
+		}
+		prog.done(len(rqB), "ResourceQuotas")
+		res.Quotas = diff.DiffResourceQuotas(rqA, rqB)
+	}
+
+	// ------ LimitRange ------
+	if opts.LimitRanges {
+		prog.phase("collecting LimitRanges from cluster A")
+		lrA, err := collectors.CollectLimitRangeFromCluster(ctx, clientA, cfgA)
+		if err != nil && !partial.tolerate(sectionLimitRanges, err) {
+			return "", driftResults{}, fmt.Errorf("collecting LimitRanges from cluster A: %w", err)
+		}
+		prog.done(len(lrA), "LimitRanges")
+		prog.phase("collecting LimitRanges from cluster B")
+		lrB, err := collectors.CollectLimitRangeFromCluster(ctx, clientB, cfgB)
+		if err != nil && !partial.tolerate(sectionLimitRanges, err) {
+			return "", driftResults{}, fmt.Errorf("collecting LimitRanges from cluster B: %w", err)
+		}
+		prog.done(len(lrB), "LimitRanges")
+		res.LimitRanges = diff.DiffLimitRanges(lrA, lrB)
+	}
+
+	// ------ Admission webhooks ------
+	if opts.AdmissionWebhooks {
+		prog.phase("collecting admission webhooks from cluster A")
+		whA, err := collectors.CollectWebhooksFromCluster(ctx, clientA)
+		if err != nil && !partial.tolerate(sectionWebhooks, err) {
+			return "", driftResults{}, fmt.Errorf("collecting admission webhooks from cluster A: %w", err)
+		}
+		prog.done(len(whA), "webhooks")
+		prog.phase("collecting admission webhooks from cluster B")
+		whB, err := collectors.CollectWebhooksFromCluster(ctx, clientB)
+		if err != nil && !partial.tolerate(sectionWebhooks, err) {
+			return "", driftResults{}, fmt.Errorf("collecting admission webhooks from cluster B: %w", err)
+		}
+		prog.done(len(whB), "webhooks")
+		res.Webhooks = diff.DiffWebhooks(whA, whB)
+	}
+
+	// ------ ServiceAccount ------
+	if opts.ServiceAccounts {
+		prog.phase("collecting ServiceAccounts from cluster A")
+		saA, err := collectors.CollectServiceAccountsFromCluster(ctx, clientA, cfgA)
+		if err != nil && !partial.tolerate(sectionSAs, err) {
+			return "", driftResults{}, fmt.Errorf("collecting ServiceAccounts from cluster A: %w", err)
+		}
+		prog.done(len(saA), "ServiceAccounts")
+		prog.phase("collecting ServiceAccounts from cluster B")
+		saB, err := collectors.CollectServiceAccountsFromCluster(ctx, clientB, cfgB)
+		if err != nil && !partial.tolerate(sectionSAs, err) {
+			return "", driftResults{}, fmt.Errorf("collecting ServiceAccounts from cluster B: %w", err)
+		}
+		prog.done(len(saB), "ServiceAccounts")
+		res.ServiceAccounts = diff.DiffServiceAccounts(saA, saB)
+	}
+
+	// ------ Container images ------
+	if opts.CheckImages {
+		prog.phase("collecting Pod images from cluster A")
+		usageA, err := collectors.CollectImageUsageFromCluster(ctx, clientA, cfgA)
+		if err != nil && !partial.tolerate(sectionImages, err) {
+			return "", driftResults{}, fmt.Errorf("collecting Pod images from cluster A: %w", err)
+		}
+		prog.done(len(usageA), "namespaces")
+		prog.phase("collecting Pod images from cluster B")
+		usageB, err := collectors.CollectImageUsageFromCluster(ctx, clientB, cfgB)
+		if err != nil && !partial.tolerate(sectionImages, err) {
+			return "", driftResults{}, fmt.Errorf("collecting Pod images from cluster B: %w", err)
+		}
+		prog.done(len(usageB), "namespaces")
+		// cluster A's registries serve as the allowlist
+		var allowed []string
+		for _, u := range usageA {
+			allowed = append(allowed, u.Registries...)
+		}
+		res.Images = diff.DiffImageRegistries(usageB, allowed)
+	}
+
+	// ------ Last-applied configuration ------
+	if opts.LastApplied {
+		for _, c := range []struct {
+			label  string
+			client kubernetes.Interface
+			cfg    collectors.Config
+		}{{"cluster A", clientA, cfgA}, {"cluster B", clientB, cfgB}} {
+			prog.phase("collecting last-applied configurations from " + c.label)
+			objs, warnings, err := collectors.CollectLastAppliedFromCluster(ctx, c.client, c.cfg)
+			if err != nil && !partial.tolerate(sectionLastApplied, err) {
+				return "", driftResults{}, fmt.Errorf("collecting last-applied configurations from %s: %w", c.label, err)
+			}
+			prog.done(len(objs), "applied objects")
+			res.LastApplied = append(res.LastApplied, diff.DiffLastApplied(objs, c.label)...)
+			res.addWarnings(c.label, warnings)
+		}
+	}
+
+	res.addWarnings("cluster A", rbacA.Warnings)
+	res.addWarnings("cluster B", rbacB.Warnings)
+	res.addWarnings("cluster A", unstableNetPolWarnings(netpolA))
+	res.addWarnings("cluster B", unstableNetPolWarnings(netpolB))
+	if opts.BindingDiff {
+		res.Bindings = diff.DiffBindings(rbacA, rbacB)
+	}
+	if opts.ClusterRoleView {
+		res.RoleSubjects = diff.ClusterRoleSubjects(rbacA, rbacB)
+	}
+	res.Labels = append(res.Labels, diff.DiffNetPolLabels(netpolA, netpolB, opts.CompareLabels)...)
+	res.Labels = append(res.Labels, diff.DiffNamespaceLabels(psaA, psaB, opts.CompareLabels)...)
+	res.Dangling = append(danglingBindings(rbacA, "baseline"), danglingBindings(rbacB, "live")...)
+	if opts.AuditRoles {
+		res.RoleAudit = append(res.RoleAudit, audit.AuditClusterRoles(rbacA, "baseline")...)
+		res.RoleAudit = append(res.RoleAudit, audit.AuditClusterRoles(rbacB, "live")...)
+	}
+	if opts.AuditIPBlocks {
+		res.NetAudit = append(res.NetAudit, audit.AuditNetPolIPBlocks(netpolA, "baseline", ipBlockMaxPrefix(opts))...)
+		res.NetAudit = append(res.NetAudit, audit.AuditNetPolIPBlocks(netpolB, "live", ipBlockMaxPrefix(opts))...)
 	}
-	if opts.Kubeconfig == "" {
-		return fmt.Errorf("-kubeconfig is required in single mode")
+	if opts.AuditNetPolSelectors {
+		res.SelAudit = append(res.SelAudit, audit.AuditNetPolNamespaceSelectors(netpolA, "baseline", psaA)...)
+		res.SelAudit = append(res.SelAudit, audit.AuditNetPolNamespaceSelectors(netpolB, "live", psaB)...)
 	}
-
-	clientLive, err := kube.BuildClient(opts.Kubeconfig)
-	if err != nil {
-		return fmt.Errorf("creating client for live cluster: %w", err)
+	if opts.NetPolOpenness {
+		res.Openness = diff.DiffNetPolOpenness(netpolA, netpolB)
+	}
+	if opts.DefaultDenyCoverage {
+		res.DefaultDeny = diff.DiffDefaultDeny(netpolA, netpolB, psaB)
+	}
+	if opts.RegoPolicy != "" {
+		res.Rego, err = audit.AuditRego(ctx, opts.RegoPolicy, audit.NewRegoInput("live", rbacB, netpolB, psaB))
+		if err != nil {
+			return "", driftResults{}, fmt.Errorf("-rego: %w", err)
+		}
 	}
+	partial.apply(&res)
 
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
-	defer cancel()
+	modeLabel := "cluster-compare (cluster A vs cluster B)"
+	return modeLabel, res, nil
+}
 
-	// -------- RBAC --------
-	rbacBaseline, err := collectors.CollectRBACFromBaselineDir(opts.BaselineDir)
-	if err != nil {
-		return fmt.Errorf("loading baseline RBAC from %s: %w", opts.BaselineDir, err)
+func (r *driftResults) addWarnings(source string, warnings []string) {
+	for _, w := range warnings {
+		r.Warnings = append(r.Warnings, source+": "+w)
 	}
-	rbacLive, err := collectors.CollectRBACFromCluster(ctx, clientLive)
-	if err != nil {
-		return fmt.Errorf("collecting RBAC from live cluster: %w", err)
+}
+
+// unstableNetPolWarnings names the policies whose spec changed between the
+// two reads of -ignore-transient-netpol-changes.
+func unstableNetPolWarnings(snap *model.NetPolSnapshot) []string {
+	var out []string
+	for key, d := range snap.Items {
+		if d.Unstable {
+			out = append(out, fmt.Sprintf("NetworkPolicy %s changed between reads; treated as transient and not reported as changed", key))
+		}
 	}
-	rbacDrift := diff.DiffRBAC(rbacBaseline, rbacLive)
+	sort.Strings(out)
+	return out
+}
 
-	// ------ NetworkPolicy ------
-	netpolBaseline, err := collectors.CollectNetPolFromBaselineDir(opts.BaselineDir)
-	if err != nil {
-		return fmt.Errorf("loading baseline NetworkPolicies from %s: %w", opts.BaselineDir, err)
+// loadPSAExemptions reads -psa-exemptions, if set.
+func loadPSAExemptions(opts Options) (model.PSAExemptions, error) {
+	if opts.PSAExemptionsFile == "" {
+		return model.PSAExemptions{}, nil
 	}
-	netpolLive, err := collectors.CollectNetPolFromCluster(ctx, clientLive)
+	ex, err := collectors.LoadPSAExemptions(opts.PSAExemptionsFile)
 	if err != nil {
-		return fmt.Errorf("collecting NetworkPolicies from live cluster: %w", err)
+		return model.PSAExemptions{}, fmt.Errorf("loading PSA exemptions: %w", err)
 	}
-	netpolDrift := diff.DiffNetworkPolicies(netpolBaseline, netpolLive)
+	return ex, nil
+}
 
-	// ------ PSA (Pod Security Admission) ------
-	psaBaseline, err := collectors.CollectPSAFromBaselineDir(opts.BaselineDir)
-	if err != nil {
-		return fmt.Errorf("loading baseline PSA from %s: %w", opts.BaselineDir, err)
+// psaExemptionWarnings describes exemptions that are not tied to a
+// namespace and therefore bypass enforcement everywhere.
+func psaExemptionWarnings(ex model.PSAExemptions) []string {
+	var out []string
+	if len(ex.Usernames) > 0 {
+		out = append(out, fmt.Sprintf("PSA is not enforced for requests by users %s in any namespace", strings.Join(ex.Usernames, ", ")))
 	}
-	psaLive, err := collectors.CollectPSAFromCluster(ctx, clientLive)
-	if err != nil {
-		return fmt.Errorf("collecting PSA from live cluster: %w", err)
+	if len(ex.RuntimeClasses) > 0 {
+		out = append(out, fmt.Sprintf("PSA is not enforced for pods with runtimeClass %s in any namespace", strings.Join(ex.RuntimeClasses, ", ")))
 	}
-	psaDrift := diff.DiffPSA(psaBaseline, psaLive)
-
-	modeLabel := "single (baseline YAML vs live cluster)"
-	return renderReport(modeLabel, opts, rbacDrift, netpolDrift, psaDrift)
+	return out
 }
 
-func runClusterCompare(opts Options) error {
-	if opts.KubeconfigA == "" || opts.KubeconfigB == "" {
-		return fmt.Errorf("both -kubeconfig-a and -kubeconfig-b are required for cluster-compare mode")
+// expandGroups applies the optional -group-map to each snapshot.
+func expandGroups(opts Options, snaps ...*model.RBACSnapshot) error {
+	if opts.GroupMapFile == "" {
+		return nil
 	}
-
-	clientA, err := kube.BuildClient(opts.KubeconfigA)
+	userGroups, err := collectors.LoadGroupMap(opts.GroupMapFile)
 	if err != nil {
-		return fmt.Errorf("creating client for baseline cluster A: %w", err)
+		return fmt.Errorf("loading group map: %w", err)
 	}
-	clientB, err := kube.BuildClient(opts.KubeconfigB)
-	if err != nil {
-		return fmt.Errorf("creating client for live cluster B: %w", err)
+	for _, snap := range snaps {
+		snap.ExpandGroups(userGroups)
 	}
+	return nil
+}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
-	defer cancel()
-
-	// -------- RBAC --------
-	rbacA, err := collectors.CollectRBACFromCluster(ctx, clientA)
-	if err != nil {
-		return fmt.Errorf("collecting RBAC from cluster A: %w", err)
+// dropExpectedServiceAccounts removes RBAC drift for ServiceAccounts in the
+// live cluster that carry the -expected-sa-annotation.
+func dropExpectedServiceAccounts(ctx context.Context, opts Options, client kubernetes.Interface, d *diff.RBACDrift) error {
+	key, want, hasValue := strings.Cut(opts.ExpectedSAAnnotation, "=")
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return nil
 	}
-	rbacB, err := collectors.CollectRBACFromCluster(ctx, clientB)
-	if err != nil {
-		return fmt.Errorf("collecting RBAC from cluster B: %w", err)
+	if !hasValue {
+		want = "true"
 	}
-	rbacDrift := diff.DiffRBAC(rbacA, rbacB)
 
-	// ------ NetworkPolicy ------
-	netpolA, err := collectors.CollectNetPolFromCluster(ctx, clientA)
+	// every namespace: ClusterRoleBindings grant to ServiceAccounts outside
+	// the -namespace-selector scope too
+	sas, err := collectors.CollectServiceAccountsFromCluster(ctx, client, collectors.Config{})
 	if err != nil {
-		return fmt.Errorf("collecting NetworkPolicies from cluster A: %w", err)
+		return fmt.Errorf("collecting ServiceAccounts for -expected-sa-annotation: %w", err)
 	}
-	netpolB, err := collectors.CollectNetPolFromCluster(ctx, clientB)
-	if err != nil {
-		return fmt.Errorf("collecting NetworkPolicies from cluster B: %w", err)
+	for _, sa := range sas {
+		if v, ok := sa.Annotations[key]; ok && strings.EqualFold(strings.TrimSpace(v), strings.TrimSpace(want)) {
+			delete(d.Extra, sa.SubjectKey())
+			delete(d.Missing, sa.SubjectKey())
+		}
 	}
-	netpolDrift := diff.DiffNetworkPolicies(netpolA, netpolB)
+	return nil
+}
 
-	// ------ PSA (Pod Security Admission) ------
-	psaA, err := collectors.CollectPSAFromCluster(ctx, clientA)
-	if err != nil {
-		return fmt.Errorf("collecting PSA from cluster A: %w", err)
+// normalizeVerbs applies the optional -normalize-verbs pass to each snapshot.
+func normalizeVerbs(opts Options, snaps ...*model.RBACSnapshot) {
+	if !opts.NormalizeVerbs {
+		return
 	}
-	psaB, err := collectors.CollectPSAFromCluster(ctx, clientB)
-	if err != nil {
-		return fmt.Errorf("collecting PSA from cluster B: %w", err)
+	for _, snap := range snaps {
+		snap.NormalizeVerbs()
 	}
-	psaDrift := diff.DiffPSA(psaA, psaB)
-
-	modeLabel := "cluster-compare (cluster A vs cluster B)"
-	return renderReport(modeLabel, opts, rbacDrift, netpolDrift, psaDrift)
 }
 
 // -----------------------------------------------------------------------------
 // Reporting helpers
 // -----------------------------------------------------------------------------
 
+// normalizeOptions canonicalizes the enum-like options used by the renderers.
+func normalizeOptions(opts Options) Options {
+	opts.DriftType = normalizeDriftType(opts.DriftType)
+	opts.OutputFormat = normalizeOutputFormat(opts.OutputFormat)
+	opts.RBACScope = normalizeRBACScope(opts.RBACScope)
+	opts.SortOrder, _ = parseSortOrder(opts.SortOrder)
+	return opts
+}
+
 func normalizeOutputFormat(s string) string {
 	switch strings.ToLower(s) {
 	case "json":
 		return "json"
+	case "yaml":
+		return "yaml"
+	case "sarif":
+		return "sarif"
+	case "kubediff":
+		return "kubediff"
+	case "ndjson-findings":
+		return "ndjson-findings"
+	case "junit":
+		return "junit"
 	case "text", "":
 		return "text"
 	default:
@@ -167,6 +1635,17 @@ func normalizeOutputFormat(s string) string {
 	}
 }
 
+func normalizeRBACScope(s string) string {
+	switch strings.ToLower(s) {
+	case "cluster":
+		return "cluster"
+	case "namespace":
+		return "namespace"
+	default:
+		return "both"
+	}
+}
+
 func normalizeDriftType(s string) string {
 	switch strings.ToLower(s) {
 	case "missing":
@@ -180,48 +1659,154 @@ func normalizeDriftType(s string) string {
 	}
 }
 
-func renderReport(
-	modeLabel string,
-	opts Options,
-	rbacDrift diff.RBACDrift,
-	netpolDrift diff.NetPolDrift,
-	psaDrift diff.PSADrift,
-) error {
-	opts.DriftType = normalizeDriftType(opts.DriftType)
-	opts.OutputFormat = normalizeOutputFormat(opts.OutputFormat)
+func renderReport(modeLabel string, opts Options, res driftResults) error {
+	opts = normalizeOptions(opts)
 
+	if opts.OutputDir != "" {
+		return writeReportDir(opts.OutputDir, modeLabel, opts, res)
+	}
+	if opts.Summary {
+		return printSummaryReport(modeLabel, opts, res)
+	}
 	switch opts.OutputFormat {
 	case "json":
-		return printJSONReport(modeLabel, opts, rbacDrift, netpolDrift, psaDrift)
+		return printJSONReport(modeLabel, opts, res)
+	case "yaml":
+		return printYAMLReport(modeLabel, opts, res)
+	case "sarif":
+		return printSARIFReport(modeLabel, opts, res)
+	case "kubediff":
+		return printKubeDiffReport(modeLabel, opts, res)
+	case "ndjson-findings":
+		return printNDJSONReport(modeLabel, opts, res)
+	case "junit":
+		return printJUnitReport(modeLabel, opts, res)
 	default:
-		printHumanReport(modeLabel, opts, rbacDrift, netpolDrift, psaDrift)
+		printHumanReport(modeLabel, opts, res)
 		return nil
 	}
 }
 
 // ---- filtering helpers ----
 
-func isSystemSubject(s model.SubjectKey) bool {
+// defaultSystemNamespaces and defaultSystemSubjectPrefixes are what
+// -ignore-system drops unless -system-namespaces or
+// -system-subject-prefixes replace them (or, with a leading "+", extend
+// them).
+const (
+	defaultSystemNamespaces      = "kube-system,kube-public"
+	defaultSystemSubjectPrefixes = "system:"
+)
+
+// systemList resolves a -system-* flag value against its built-in list:
+// "" keeps the built-ins, "+a,b" appends to them, anything else replaces
+// them.
+func systemList(value, builtin string) string {
+	value = strings.TrimSpace(value)
+	switch {
+	case value == "":
+		return builtin
+	case strings.HasPrefix(value, "+"):
+		return builtin + "," + value[1:]
+	default:
+		return value
+	}
+}
+
+func isSystemSubject(s model.SubjectKey, opts Options) bool {
 	if s.Kind == "User" || s.Kind == "Group" {
-		if strings.HasPrefix(s.Name, "system:") {
+		if hasSystemPrefix(s.Name, opts) {
 			return true
 		}
 	}
 	if s.Kind == "ServiceAccount" {
-		if s.Namespace == "kube-system" || s.Namespace == "kube-public" {
+		if isSystemNamespace(s.Namespace, opts) {
 			return true
 		}
 	}
 	return false
 }
 
-func isSystemNamespace(ns string) bool {
-	switch ns {
-	case "kube-system", "kube-public":
-		return true
-	default:
-		return false
+// isSystemNamespace reports whether ns is in -system-namespaces (names or
+// /regex/ patterns).
+func isSystemNamespace(ns string, opts Options) bool {
+	return ns != "" && matchesNamespaceList(ns, systemList(opts.SystemNamespaces, defaultSystemNamespaces))
+}
+
+// hasSystemPrefix reports whether a subject or role name starts with one
+// of -system-subject-prefixes.
+func hasSystemPrefix(name string, opts Options) bool {
+	for _, prefix := range strings.Split(systemList(opts.SystemSubjectPrefixes, defaultSystemSubjectPrefixes), ",") {
+		if prefix = strings.TrimSpace(prefix); prefix != "" && strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// namespaceFilter returns the name of the first filter that excludes
+// findings in namespace ns, or "" if they are kept: -include-namespaces
+// narrows first, then -exclude-namespaces removes, then -ignore-system
+// removes the system namespaces. ns "" (cluster-scoped) is always kept.
+func namespaceFilter(ns string, opts Options) string {
+	if f := namespaceListFilter(ns, opts); f != "" {
+		return f
+	}
+	if opts.IgnoreSystem && isSystemNamespace(ns, opts) {
+		return "ignore-system"
+	}
+	return ""
+}
+
+// namespaceListFilter is namespaceFilter without -ignore-system, for
+// findings (like namespaced RBAC permissions) that -ignore-system has
+// never applied to.
+func namespaceListFilter(ns string, opts Options) string {
+	switch {
+	case ns == "":
+		return ""
+	case strings.TrimSpace(opts.IncludeNamespaces) != "" && !matchesNamespaceList(ns, opts.IncludeNamespaces):
+		return "include-namespaces"
+	case matchesNamespaceList(ns, opts.ExcludeNamespaces):
+		return "exclude-namespaces"
+	}
+	return ""
+}
+
+// matchesNamespaceList reports whether ns matches any entry of a
+// comma-separated list of names and /regex/ patterns.
+func matchesNamespaceList(ns, list string) bool {
+	for _, entry := range strings.Split(list, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if len(entry) >= 2 && entry[0] == '/' && entry[len(entry)-1] == '/' {
+			re, err := regexp.Compile(entry[1 : len(entry)-1])
+			if err == nil && re.MatchString(ns) {
+				return true
+			}
+			continue
+		}
+		if ns == entry {
+			return true
+		}
+	}
+	return false
+}
+
+// validateNamespaceList checks that every /regex/ entry of a namespace
+// list compiles.
+func validateNamespaceList(list string) error {
+	for _, entry := range strings.Split(list, ",") {
+		entry = strings.TrimSpace(entry)
+		if len(entry) >= 2 && entry[0] == '/' && entry[len(entry)-1] == '/' {
+			if _, err := regexp.Compile(entry[1 : len(entry)-1]); err != nil {
+				return err
+			}
+		}
 	}
+	return nil
 }
 
 func matchesSubjectKind(s model.SubjectKey, filter string) bool {
@@ -232,186 +1817,1009 @@ func matchesSubjectKind(s model.SubjectKey, filter string) bool {
 	return strings.ToLower(s.Kind) == filter
 }
 
-func matchesSubjectNamespace(s model.SubjectKey, ns string) bool {
-	ns = strings.TrimSpace(ns)
-	if ns == "" {
-		return true
-	}
-	return s.Namespace == ns
+func matchesSubjectNamespace(s model.SubjectKey, ns string) bool {
+	ns = strings.TrimSpace(ns)
+	if ns == "" {
+		return true
+	}
+	return s.Namespace == ns
+}
+
+// matchesSubjectName reports whether name matches -subject-name or any
+// -subject-name-file entry. With no filters set every name matches.
+func matchesSubjectName(name string, opts Options) bool {
+	filters := append([]string{opts.SubjectName}, opts.SubjectNames...)
+
+	active := false
+	for _, filter := range filters {
+		filter = strings.TrimSpace(filter)
+		if filter == "" {
+			continue
+		}
+		active = true
+
+		// Regex style: /pattern/
+		if len(filter) >= 2 && filter[0] == '/' && filter[len(filter)-1] == '/' {
+			pattern := filter[1 : len(filter)-1]
+			re, err := regexp.Compile(pattern)
+			if err == nil && re.MatchString(name) {
+				return true
+			}
+			continue
+		}
+
+		if name == filter {
+			return true
+		}
+	}
+	return !active
+}
+
+// loadSubjectNameFile reads one subject name filter per line, skipping blank
+// lines and #-comments.
+func loadSubjectNameFile(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		names = append(names, line)
+	}
+	return names, nil
+}
+
+func filterRoleAudit(findings []model.RoleRiskFinding, opts Options) []model.RoleRiskFinding {
+	var out []model.RoleRiskFinding
+	for _, f := range findings {
+		if opts.IgnoreSystem && hasSystemPrefix(f.Role, opts) {
+			continue
+		}
+		out = append(out, f)
+	}
+	sortFindings(out, opts.SortOrder, roleRiskSortKey)
+	return out
+}
+
+// ipBlockMaxPrefix returns the IPv4 prefix threshold for the ipBlock audit.
+func ipBlockMaxPrefix(opts Options) int {
+	if opts.IPBlockMaxPrefix <= 0 {
+		return 8
+	}
+	return opts.IPBlockMaxPrefix
+}
+
+func filterNetPolAudit(findings []model.NetPolRiskFinding, opts Options) []model.NetPolRiskFinding {
+	var out []model.NetPolRiskFinding
+	for _, f := range findings {
+		if namespaceFilter(f.Namespace, opts) != "" {
+			continue
+		}
+		out = append(out, f)
+	}
+	sortFindings(out, opts.SortOrder, netPolRiskSortKey)
+	return out
+}
+
+// filterNetPolOpenness keeps the newly open policies, which are weaker
+// (extra) drift, and applies the namespace filters.
+func filterNetPolOpenness(changes []model.NetPolOpennessChange, opts Options) []model.NetPolOpennessChange {
+	var out []model.NetPolOpennessChange
+	if opts.DriftType != "extra" && opts.DriftType != "both" {
+		return out
+	}
+	for _, c := range changes {
+		if namespaceFilter(c.Namespace, opts) != "" {
+			continue
+		}
+		out = append(out, c)
+	}
+	sortFindings(out, opts.SortOrder, netPolOpennessSortKey)
+	return out
+}
+
+// filterDefaultDeny keeps the coverage gaps, which are weaker (extra)
+// drift, and applies the namespace filters.
+func filterDefaultDeny(gaps []model.DefaultDenyGap, opts Options) []model.DefaultDenyGap {
+	var out []model.DefaultDenyGap
+	if opts.DriftType != "extra" && opts.DriftType != "both" {
+		return out
+	}
+	for _, g := range gaps {
+		if namespaceFilter(g.Namespace, opts) != "" {
+			continue
+		}
+		out = append(out, g)
+	}
+	sortFindings(out, opts.SortOrder, defaultDenySortKey)
+	return out
+}
+
+// filterRegoViolations drops violations in namespaces excluded by the
+// namespace filters; violations without a namespace are always kept.
+func filterRegoViolations(violations []model.RegoViolation, opts Options) []model.RegoViolation {
+	var out []model.RegoViolation
+	for _, v := range violations {
+		if namespaceFilter(v.Namespace, opts) != "" {
+			continue
+		}
+		out = append(out, v)
+	}
+	sortFindings(out, opts.SortOrder, regoViolationSortKey)
+	return out
+}
+
+// filterClusterRoleSubjects applies the subject filters to every grant of
+// the ClusterRole view and -drift-type to its added/removed grants. Roles
+// left without grants on either side are dropped.
+func filterClusterRoleSubjects(views []model.ClusterRoleSubjects, opts Options) []model.ClusterRoleSubjects {
+	keep := func(grants []model.ClusterRoleGrant) []model.ClusterRoleGrant {
+		out := []model.ClusterRoleGrant{}
+		for _, g := range grants {
+			if rbacSubjectFilter(g.Subject, opts) != "" {
+				continue
+			}
+			g.Subject = redactSubject(g.Subject, opts)
+			out = append(out, g)
+		}
+		return out
+	}
+
+	var out []model.ClusterRoleSubjects
+	for _, v := range views {
+		if opts.IgnoreSystem && hasSystemPrefix(v.Role, opts) {
+			continue
+		}
+		filtered := model.ClusterRoleSubjects{
+			Role:     v.Role,
+			Baseline: keep(v.Baseline),
+			Live:     keep(v.Live),
+		}
+		if len(filtered.Baseline) == 0 && len(filtered.Live) == 0 {
+			continue
+		}
+		if opts.DriftType == "extra" || opts.DriftType == "both" {
+			filtered.Added = keep(v.Added)
+		}
+		if opts.DriftType == "missing" || opts.DriftType == "both" {
+			filtered.Removed = keep(v.Removed)
+		}
+		filtered.Drifted = len(filtered.Added) > 0 || len(filtered.Removed) > 0
+		if !filtered.Drifted {
+			filtered.Added, filtered.Removed = nil, nil
+		}
+		out = append(out, filtered)
+	}
+	sortFindings(out, opts.SortOrder, clusterRoleSubjectsSortKey)
+	return out
+}
+
+// filterBindingDrift applies the subject filters and -drift-type to
+// binding changes: added subjects are "extra", removed ones "missing".
+func filterBindingDrift(changes []model.BindingChange, opts Options) []model.BindingChange {
+	keep := func(subjects []model.SubjectKey) []model.SubjectKey {
+		var out []model.SubjectKey
+		for _, subj := range subjects {
+			if opts.IgnoreSystem && isSystemSubject(subj, opts) {
+				continue
+			}
+			if !matchesSubjectKind(subj, opts.SubjectKind) ||
+				!matchesSubjectNamespace(subj, opts.SubjectNamespace) ||
+				!matchesSubjectName(subj.Name, opts) {
+				continue
+			}
+			out = append(out, redactSubject(subj, opts))
+		}
+		return out
+	}
+
+	var out []model.BindingChange
+	for _, ch := range changes {
+		if namespaceFilter(ch.Role.Namespace, opts) != "" || (opts.IgnoreSystem && hasSystemPrefix(ch.Role.Name, opts)) {
+			continue
+		}
+		filtered := model.BindingChange{Role: ch.Role}
+		if opts.DriftType == "extra" || opts.DriftType == "both" {
+			filtered.Added = keep(ch.Added)
+		}
+		if opts.DriftType == "missing" || opts.DriftType == "both" {
+			filtered.Removed = keep(ch.Removed)
+		}
+		if len(filtered.Added) == 0 && len(filtered.Removed) == 0 {
+			continue
+		}
+		out = append(out, filtered)
+	}
+	sortFindings(out, opts.SortOrder, bindingChangeSortKey)
+	return out
+}
+
+// danglingBindings returns the dangling bindings of snap, labelled with
+// source.
+func danglingBindings(snap *model.RBACSnapshot, source string) []model.DanglingBinding {
+	out := make([]model.DanglingBinding, 0, len(snap.Dangling))
+	for _, d := range snap.Dangling {
+		d.Source = source
+		out = append(out, d)
+	}
+	return out
+}
+
+// filterDanglingBindings drops bindings in namespaces excluded by the
+// namespace filters, and system: bindings under -ignore-system.
+func filterDanglingBindings(bindings []model.DanglingBinding, opts Options) []model.DanglingBinding {
+	var out []model.DanglingBinding
+	for _, d := range bindings {
+		if namespaceFilter(d.Namespace, opts) != "" || (opts.IgnoreSystem && hasSystemPrefix(d.Name, opts)) {
+			continue
+		}
+		subjects := make([]model.SubjectKey, 0, len(d.Subjects))
+		for _, subj := range d.Subjects {
+			subjects = append(subjects, redactSubject(subj, opts))
+		}
+		d.Subjects = subjects
+		out = append(out, d)
+	}
+	sortFindings(out, opts.SortOrder, danglingBindingSortKey)
+	return out
+}
+
+func filterImageViolations(violations []model.ImageViolation, opts Options) []model.ImageViolation {
+	var out []model.ImageViolation
+	for _, v := range violations {
+		if namespaceFilter(v.Namespace, opts) != "" {
+			continue
+		}
+		out = append(out, v)
+	}
+	sortFindings(out, opts.SortOrder, imageViolationSortKey)
+	return out
+}
+
+// filterLastApplied drops objects in namespaces excluded by the namespace
+// filters (and those Namespace objects themselves), and system:
+// cluster-scoped RBAC objects under -ignore-system.
+func filterLastApplied(drift []model.LastAppliedDrift, opts Options) []model.LastAppliedDrift {
+	var out []model.LastAppliedDrift
+	for _, d := range drift {
+		ns := d.Namespace
+		if d.Kind == "Namespace" {
+			ns = d.Name
+		}
+		if namespaceFilter(ns, opts) != "" {
+			continue
+		}
+		if opts.IgnoreSystem && d.Namespace == "" && hasSystemPrefix(d.Name, opts) {
+			continue
+		}
+		out = append(out, d)
+	}
+	sortFindings(out, opts.SortOrder, lastAppliedSortKey)
+	return out
+}
+
+func filterLabelDrift(changes []model.LabelChange, opts Options) []model.LabelChange {
+	var out []model.LabelChange
+	for _, ch := range changes {
+		if namespaceFilter(ch.Namespace, opts) != "" {
+			continue
+		}
+		out = append(out, ch)
+	}
+	sortFindings(out, opts.SortOrder, labelChangeSortKey)
+	return out
+}
+
+// -----------------------------------------------------------------------------
+// JSON representation
+// -----------------------------------------------------------------------------
+
+// PermissionFinding is a drifted permission with its severity.
+type PermissionFinding struct {
+	model.Permission
+	Severity model.Severity `json:"severity"`
+	// DefinedIn locates the baseline role and binding granting a missing
+	// permission.
+	DefinedIn []model.SourceRef `json:"definedIn,omitempty"`
+}
+
+// SubjectPermissions is one subject's drifted permissions in a Report.
+type SubjectPermissions struct {
+	Subject        model.SubjectKey    `json:"subject"`
+	Permissions    []PermissionFinding `json:"permissions"`
+	ComplianceRefs []string            `json:"complianceRefs,omitempty"`
+
+	// TotalPermissions and Truncated are set when -max-perms-per-subject
+	// shortened Permissions; TotalPermissions is the pre-truncation count.
+	TotalPermissions int `json:"totalPermissions,omitempty"`
+	Truncated        int `json:"truncated,omitempty"`
+
+	// CoveredByClusterWide lists namespaced permissions (by String()) that
+	// the same subject also holds cluster-wide in this list.
+	CoveredByClusterWide []string `json:"coveredByClusterWide,omitempty"`
+}
+
+// grants returns the permissions without their severities.
+func (sp SubjectPermissions) grants() []model.Permission {
+	out := make([]model.Permission, len(sp.Permissions))
+	for i, p := range sp.Permissions {
+		out[i] = p.Permission
+	}
+	return out
+}
+
+// RBACReport is the RBAC section of a Report, after -drift-type and the
+// subject filters.
+type RBACReport struct {
+	Extra   []SubjectPermissions  `json:"extra,omitempty"`
+	Missing []SubjectPermissions  `json:"missing,omitempty"`
+	Renamed []model.SubjectRename `json:"renamed,omitempty"`
+
+	ResourceNames []model.ResourceNameChange `json:"resourceNames,omitempty"`
+}
+
+// NetPolReport is the NetworkPolicy section of a Report.
+type NetPolReport struct {
+	Missing []model.NetPolRef    `json:"missing,omitempty"`
+	Extra   []model.NetPolRef    `json:"extra,omitempty"`
+	Changed []model.NetPolChange `json:"changed,omitempty"`
+}
+
+// PSAReport is the Pod Security Admission section of a Report.
+type PSAReport struct {
+	Extra   []model.PSADriftEntry `json:"extra,omitempty"`
+	Missing []model.PSADriftEntry `json:"missing,omitempty"`
+	// Incomparable holds "different" entries split out by -strict-psa,
+	// independent of -drift-type.
+	Incomparable []model.PSADriftEntry `json:"incomparable,omitempty"`
+	// ManagedBy is set by -compare-annotations-on-psa, independent of
+	// -drift-type.
+	ManagedBy []model.PSAManagedByDrift `json:"managedBy,omitempty"`
+	Summary   PSASummary                `json:"summary"`
+}
+
+type storageClassDriftJSON struct {
+	Missing []model.StorageClassDigest `json:"missing,omitempty"`
+	Extra   []model.StorageClassDigest `json:"extra,omitempty"`
+	Changed []model.StorageClassChange `json:"changed,omitempty"`
 }
 
-func matchesSubjectName(name, filter string) bool {
-	filter = strings.TrimSpace(filter)
-	if filter == "" {
-		return true
-	}
-
-	// Regex style: /pattern/
-	if len(filter) >= 2 && filter[0] == '/' && filter[len(filter)-1] == '/' {
-		pattern := filter[1 : len(filter)-1]
-		re, err := regexp.Compile(pattern)
-		if err != nil {
-			return false
-		}
-		return re.MatchString(name)
-	}
+// ResourceQuotaReport is the ResourceQuota section of a Report, set only
+// with Options.ResourceQuotas.
+type ResourceQuotaReport struct {
+	Missing []model.ResourceQuotaDigest `json:"missing,omitempty"`
+	Extra   []model.ResourceQuotaDigest `json:"extra,omitempty"`
+	Changed []model.ResourceQuotaChange `json:"changed,omitempty"`
+}
 
-	return name == filter
+// LimitRangeReport is the LimitRange section of a Report, set only with
+// Options.LimitRanges.
+type LimitRangeReport struct {
+	Missing []model.LimitRangeDigest `json:"missing,omitempty"`
+	Extra   []model.LimitRangeDigest `json:"extra,omitempty"`
+	Changed []model.LimitRangeChange `json:"changed,omitempty"`
 }
 
-// -----------------------------------------------------------------------------
-// JSON representation
-// -----------------------------------------------------------------------------
+// ServiceAccountReport is the ServiceAccount section of a Report, set only
+// with Options.ServiceAccounts. Its buckets follow the PSA direction
+// semantics (see diff.ServiceAccountDrift).
+type ServiceAccountReport struct {
+	Extra   []model.ServiceAccountDriftEntry `json:"extra,omitempty"`
+	Missing []model.ServiceAccountDriftEntry `json:"missing,omitempty"`
+}
 
-type subjectPermissions struct {
-	Subject     model.SubjectKey   `json:"subject"`
-	Permissions []model.Permission `json:"permissions"`
+// PSASummary is a net scorecard of PSA posture across all compared
+// namespaces, independent of -drift-type.
+type PSASummary struct {
+	Weaker    int `json:"weaker"`
+	Stronger  int `json:"stronger"`
+	Different int `json:"different"`
+	Unchanged int `json:"unchanged"`
+	New       int `json:"new"`
+	Removed   int `json:"removed"`
+	Exempted  int `json:"exempted,omitempty"`
 }
 
-type rbacDriftJSON struct {
-	Extra   []subjectPermissions `json:"extra,omitempty"`
-	Missing []subjectPermissions `json:"missing,omitempty"`
+// Report is the filtered result of one analysis, as printed by -output
+// json. Analyze returns it to callers embedding driftwatch.
+type Report struct {
+	Title             string            `json:"title,omitempty"`
+	Labels            map[string]string `json:"labels,omitempty"`
+	Mode              string            `json:"mode"`
+	DriftType         string            `json:"driftType"`
+	IgnoreSystem      bool              `json:"ignoreSystem"`
+	SystemNamespaces  string            `json:"systemNamespaces,omitempty"`
+	SystemPrefixes    string            `json:"systemSubjectPrefixes,omitempty"`
+	IncludeNamespaces string            `json:"includeNamespaces,omitempty"`
+	ExcludeNamespaces string            `json:"excludeNamespaces,omitempty"`
+	SubjectKind       string            `json:"subjectKind"`
+	SubjectName       string            `json:"subjectName"`
+	SubjectNamespace  string            `json:"subjectNamespace"`
+	SubjectNameFile   string            `json:"subjectNameFile,omitempty"`
+	IgnoreFile        string            `json:"ignoreFile,omitempty"`
+	RBACScope         string            `json:"rbacScope"`
+	SortOrder         string            `json:"sort,omitempty"`
+	Shard             string            `json:"shard,omitempty"`
+	NamespaceSelector string            `json:"namespaceSelector,omitempty"`
+	Fast              bool              `json:"fast,omitempty"`
+
+	// Stats is the -stats inventory; it is run metadata, not a finding.
+	Stats *collectionStats `json:"stats,omitempty"`
+
+	RBAC          RBACReport   `json:"rbac"`
+	NetworkPolicy NetPolReport `json:"networkPolicy"`
+	PSA           PSAReport    `json:"psa"`
+
+	RoleAudit     []model.RoleRiskFinding      `json:"roleAudit,omitempty"`
+	NetPolAudit   []model.NetPolRiskFinding    `json:"netpolAudit,omitempty"`
+	SelectorAudit []model.NetPolRiskFinding    `json:"netpolSelectorAudit,omitempty"`
+	Openness      []model.NetPolOpennessChange `json:"netpolOpenness,omitempty"`
+	DefaultDeny   []model.DefaultDenyGap       `json:"defaultDeny,omitempty"`
+	Rego          []model.RegoViolation        `json:"regoViolations,omitempty"`
+	LabelDrift    []model.LabelChange          `json:"labelDrift,omitempty"`
+	Bindings      []model.BindingChange        `json:"bindings,omitempty"`
+	RoleSubjects  []model.ClusterRoleSubjects  `json:"clusterRoleSubjects,omitempty"`
+
+	DanglingBindings  []model.DanglingBinding    `json:"danglingBindings,omitempty"`
+	StorageClasses    *storageClassDriftJSON     `json:"storageClasses,omitempty"`
+	ResourceQuotas    *ResourceQuotaReport       `json:"resourceQuotas,omitempty"`
+	LimitRanges       *LimitRangeReport          `json:"limitRanges,omitempty"`
+	AdmissionWebhooks []model.WebhookConfigDrift `json:"admissionWebhooks,omitempty"`
+	ServiceAccounts   *ServiceAccountReport      `json:"serviceAccounts,omitempty"`
+	Images            []model.ImageViolation     `json:"imageViolations,omitempty"`
+	LastApplied       []model.LastAppliedDrift   `json:"lastApplied,omitempty"`
+	Workload          *workloadJSON              `json:"workload,omitempty"`
+
+	// Incomplete lists sections not collected before -max-runtime expired.
+	Incomplete []string `json:"incomplete,omitempty"`
+
+	// HistoryDelta is set by -report-diff-against-git.
+	HistoryDelta *reportDelta `json:"historyDelta,omitempty"`
+
+	// Suppressed explains report sections that are empty only because the
+	// filters removed every finding, keyed by section ("rbac", ...).
+	Suppressed map[string]*suppressionNote `json:"suppressed,omitempty"`
+
+	// Ignored counts the findings -ignore-file accepted: RBAC subjects,
+	// NetworkPolicies and PSA namespaces, the units of Suppressed.
+	Ignored int `json:"ignored,omitempty"`
+
+	Warnings []string `json:"warnings,omitempty"`
+
+	// opts and res are what the report was built from, kept so Run can
+	// render it in the other output formats.
+	opts Options
+	res  driftResults
 }
 
-type netPolDriftJSON struct {
-	Missing []model.NetPolRef    `json:"missing,omitempty"`
-	Extra   []model.NetPolRef    `json:"extra,omitempty"`
-	Changed []model.NetPolChange `json:"changed,omitempty"`
+// driftFindingsJSON is the findings part of Report, without the
+// run metadata; used by -findings-only and watch-mode fingerprints.
+type driftFindingsJSON struct {
+	RBAC              RBACReport                   `json:"rbac"`
+	NetworkPolicy     NetPolReport                 `json:"networkPolicy"`
+	PSA               PSAReport                    `json:"psa"`
+	RoleAudit         []model.RoleRiskFinding      `json:"roleAudit,omitempty"`
+	NetPolAudit       []model.NetPolRiskFinding    `json:"netpolAudit,omitempty"`
+	SelectorAudit     []model.NetPolRiskFinding    `json:"netpolSelectorAudit,omitempty"`
+	Openness          []model.NetPolOpennessChange `json:"netpolOpenness,omitempty"`
+	DefaultDeny       []model.DefaultDenyGap       `json:"defaultDeny,omitempty"`
+	Rego              []model.RegoViolation        `json:"regoViolations,omitempty"`
+	LabelDrift        []model.LabelChange          `json:"labelDrift,omitempty"`
+	Bindings          []model.BindingChange        `json:"bindings,omitempty"`
+	RoleSubjects      []model.ClusterRoleSubjects  `json:"clusterRoleSubjects,omitempty"`
+	DanglingBindings  []model.DanglingBinding      `json:"danglingBindings,omitempty"`
+	StorageClasses    *storageClassDriftJSON       `json:"storageClasses,omitempty"`
+	ResourceQuotas    *ResourceQuotaReport         `json:"resourceQuotas,omitempty"`
+	LimitRanges       *LimitRangeReport            `json:"limitRanges,omitempty"`
+	AdmissionWebhooks []model.WebhookConfigDrift   `json:"admissionWebhooks,omitempty"`
+	ServiceAccounts   *ServiceAccountReport        `json:"serviceAccounts,omitempty"`
+	Images            []model.ImageViolation       `json:"imageViolations,omitempty"`
+	LastApplied       []model.LastAppliedDrift     `json:"lastApplied,omitempty"`
+	Workload          *workloadJSON                `json:"workload,omitempty"`
+	Incomplete        []string                     `json:"incomplete,omitempty"`
 }
 
-type psaDriftJSON struct {
-	Extra   []model.PSADriftEntry `json:"extra,omitempty"`
-	Missing []model.PSADriftEntry `json:"missing,omitempty"`
+func (r Report) findings() driftFindingsJSON {
+	return driftFindingsJSON{
+		RBAC:              r.RBAC,
+		NetworkPolicy:     r.NetworkPolicy,
+		PSA:               r.PSA,
+		RoleAudit:         r.RoleAudit,
+		NetPolAudit:       r.NetPolAudit,
+		SelectorAudit:     r.SelectorAudit,
+		Openness:          r.Openness,
+		DefaultDeny:       r.DefaultDeny,
+		Rego:              r.Rego,
+		LabelDrift:        r.LabelDrift,
+		Bindings:          r.Bindings,
+		RoleSubjects:      r.RoleSubjects,
+		DanglingBindings:  r.DanglingBindings,
+		StorageClasses:    r.StorageClasses,
+		ResourceQuotas:    r.ResourceQuotas,
+		LimitRanges:       r.LimitRanges,
+		AdmissionWebhooks: r.AdmissionWebhooks,
+		ServiceAccounts:   r.ServiceAccounts,
+		Images:            r.Images,
+		LastApplied:       r.LastApplied,
+		Workload:          r.Workload,
+		Incomplete:        r.Incomplete,
+	}
 }
 
-type driftReportJSON struct {
-	Mode             string `json:"mode"`
-	DriftType        string `json:"driftType"`
-	IgnoreSystem     bool   `json:"ignoreSystem"`
-	SubjectKind      string `json:"subjectKind"`
-	SubjectName      string `json:"subjectName"`
-	SubjectNamespace string `json:"subjectNamespace"`
+// filterRBACDriftToSlices applies -drift-type, the subject filters and
+// -rbac-scope to both buckets, recording removed subjects in tally.
+func filterRBACDriftToSlices(d diff.RBACDrift, opts Options, tally *filterTally) ([]SubjectPermissions, []SubjectPermissions) {
+	extraOut := []SubjectPermissions{}
+	missingOut := []SubjectPermissions{}
+
+	if opts.DriftType == "extra" || opts.DriftType == "both" {
+		extraOut = filterRBACBucket(d.Extra, nil, opts, tally)
+	} else {
+		tally.add("drift-type", len(d.Extra))
+	}
+	if opts.DriftType == "missing" || opts.DriftType == "both" {
+		missingOut = filterRBACBucket(d.Missing, d.DefinedIn, opts, tally)
+	} else {
+		tally.add("drift-type", len(d.Missing))
+	}
 
-	RBAC          rbacDriftJSON   `json:"rbac"`
-	NetworkPolicy netPolDriftJSON `json:"networkPolicy"`
-	PSA           psaDriftJSON    `json:"psa"`
+	return extraOut, missingOut
 }
 
-func filterRBACDriftToSlices(d diff.RBACDrift, opts Options) ([]subjectPermissions, []subjectPermissions) {
-	extraOut := []subjectPermissions{}
-	missingOut := []subjectPermissions{}
+// filterRBACBucket turns one bucket of RBACDrift into report entries;
+// definedIn locates the permissions in the baseline directory (nil if
+// unknown).
+func filterRBACBucket(bucket map[model.SubjectKey][]model.Permission, definedIn map[model.SubjectKey]map[model.Permission][]model.SourceRef, opts Options, tally *filterTally) []SubjectPermissions {
+	out := []SubjectPermissions{}
+	minSeverity, _ := model.ParseSeverity(opts.MinSeverity)
 
 	// stable ordering
-	subjectsExtra := make([]model.SubjectKey, 0, len(d.Extra))
-	for s := range d.Extra {
-		subjectsExtra = append(subjectsExtra, s)
+	subjects := make([]model.SubjectKey, 0, len(bucket))
+	for s := range bucket {
+		subjects = append(subjects, s)
 	}
-	sort.Slice(subjectsExtra, func(i, j int) bool {
-		return subjectsExtra[i].String() < subjectsExtra[j].String()
+	sort.Slice(subjects, func(i, j int) bool {
+		return subjects[i].String() < subjects[j].String()
 	})
 
-	for _, subj := range subjectsExtra {
-		perms := d.Extra[subj]
-		if opts.IgnoreSystem && isSystemSubject(subj) {
+	for _, subj := range subjects {
+		if f := rbacSubjectFilter(subj, opts); f != "" {
+			tally.add(f, 1)
 			continue
 		}
-		if !matchesSubjectKind(subj, opts.SubjectKind) {
+		permsCopy := filterPermissionsByScope(bucket[subj], opts.RBACScope)
+		if len(permsCopy) == 0 {
+			tally.add("rbac-scope", 1)
 			continue
 		}
-		if !matchesSubjectNamespace(subj, opts.SubjectNamespace) {
+		var nsFilter string
+		permsCopy, nsFilter = filterPermissionsByNamespace(permsCopy, opts)
+		if len(permsCopy) == 0 {
+			tally.add(nsFilter, 1)
 			continue
 		}
-		if !matchesSubjectName(subj.Name, opts.SubjectName) {
+		rated := make([]PermissionFinding, 0, len(permsCopy))
+		for _, p := range permsCopy {
+			sev := opts.severity.ClassifySubjectPermission(subj, p)
+			if sev.Rank() < minSeverity.Rank() {
+				continue
+			}
+			rated = append(rated, PermissionFinding{Permission: p, Severity: sev, DefinedIn: definedIn[subj][p]})
+		}
+		if len(rated) == 0 {
+			tally.add("min-severity", 1)
 			continue
 		}
-		if len(perms) == 0 {
+		// counted per subject like the filters above, whether -ignore-file
+		// accepted all of its permissions or only some
+		kept := rated[:0]
+		for _, p := range rated {
+			if !opts.ignores.permission(subj, p.Permission) {
+				kept = append(kept, p)
+			}
+		}
+		if len(kept) < len(rated) {
+			tally.add("ignore-file", 1)
+		}
+		if rated = kept; len(rated) == 0 {
 			continue
 		}
-		permsCopy := append([]model.Permission(nil), perms...)
-		sort.Slice(permsCopy, func(i, j int) bool {
-			return permsCopy[i].String() < permsCopy[j].String()
-		})
-		extraOut = append(extraOut, subjectPermissions{
-			Subject:     subj,
-			Permissions: permsCopy,
+		sort.Slice(rated, func(i, j int) bool {
+			return rated[i].String() < rated[j].String()
 		})
+		if opts.SortOrder == sortBySeverity {
+			// most severe first, so -max-perms-per-subject keeps those
+			sortFindings(rated, opts.SortOrder, func(p PermissionFinding) findingSortKey {
+				return findingSortKey{severity: p.Severity}
+			})
+		}
+		sp := SubjectPermissions{
+			Subject:     redactSubject(subj, opts),
+			Permissions: rated,
+		}
+		if opts.MergeScopes {
+			sp.CoveredByClusterWide = coveredByClusterWide(sp.grants())
+		}
+		out = append(out, capPermissions(sp, opts.MaxPermsPerSubject))
 	}
+	sortFindings(out, opts.SortOrder, subjectPermissionsSortKey)
+	return out
+}
 
-	subjectsMissing := make([]model.SubjectKey, 0, len(d.Missing))
-	for s := range d.Missing {
-		subjectsMissing = append(subjectsMissing, s)
+// filterRenames keeps renames where either subject passes the subject
+// filters, independent of -drift-type since a rename is both.
+func filterRenames(renames []model.SubjectRename, opts Options) []model.SubjectRename {
+	var out []model.SubjectRename
+	for _, r := range renames {
+		if rbacSubjectFilter(r.From, opts) != "" && rbacSubjectFilter(r.To, opts) != "" {
+			continue
+		}
+		perms := filterPermissionsByScope(r.Permissions, opts.RBACScope)
+		if len(perms) == 0 {
+			continue
+		}
+		out = append(out, model.SubjectRename{
+			From:        redactSubject(r.From, opts),
+			To:          redactSubject(r.To, opts),
+			Permissions: perms,
+		})
 	}
-	sort.Slice(subjectsMissing, func(i, j int) bool {
-		return subjectsMissing[i].String() < subjectsMissing[j].String()
-	})
+	sortFindings(out, opts.SortOrder, subjectRenameSortKey(opts.severity))
+	return out
+}
 
-	for _, subj := range subjectsMissing {
-		perms := d.Missing[subj]
-		if opts.IgnoreSystem && isSystemSubject(subj) {
+// filterResourceNameChanges applies the subject filters and -rbac-scope to
+// resourceName set changes. -drift-type selects which side is shown: added
+// names are extra, removed names are missing.
+func filterResourceNameChanges(changes []model.ResourceNameChange, opts Options) []model.ResourceNameChange {
+	var out []model.ResourceNameChange
+	for _, c := range changes {
+		if rbacSubjectFilter(c.Subject, opts) != "" {
 			continue
 		}
-		if !matchesSubjectKind(subj, opts.SubjectKind) {
+		if len(filterPermissionsByScope([]model.Permission{c.Grant()}, opts.RBACScope)) == 0 {
 			continue
 		}
-		if !matchesSubjectNamespace(subj, opts.SubjectNamespace) {
-			continue
+		switch opts.DriftType {
+		case "extra":
+			c.Removed = nil
+		case "missing":
+			c.Added = nil
 		}
-		if !matchesSubjectName(subj.Name, opts.SubjectName) {
+		if len(c.Added) == 0 && len(c.Removed) == 0 {
 			continue
 		}
-		if len(perms) == 0 {
+		c.Subject = redactSubject(c.Subject, opts)
+		out = append(out, c)
+	}
+	sortFindings(out, opts.SortOrder, resourceNameChangeSortKey(opts.severity))
+	return out
+}
+
+// rbacSubjectFilter returns the name of the first filter that excludes
+// subj, or "" if the subject is kept.
+func rbacSubjectFilter(subj model.SubjectKey, opts Options) string {
+	switch {
+	case opts.IgnoreSystem && isSystemSubject(subj, opts):
+		return "ignore-system"
+	case !matchesSubjectKind(subj, opts.SubjectKind):
+		return "subject-kind"
+	case !matchesSubjectNamespace(subj, opts.SubjectNamespace):
+		return "subject-namespace"
+	case !matchesSubjectName(subj.Name, opts):
+		return "subject-name"
+	}
+	return ""
+}
+
+// coveredByClusterWide returns the namespaced permissions in perms whose
+// verb/resource/apiGroup/resourceName also appear as a cluster-wide grant.
+func coveredByClusterWide(perms []model.Permission) []string {
+	clusterWide := map[model.Permission]struct{}{}
+	for _, p := range perms {
+		if p.ScopeNamespace == "*" {
+			clusterWide[p] = struct{}{}
+		}
+	}
+	if len(clusterWide) == 0 {
+		return nil
+	}
+
+	var out []string
+	for _, p := range perms {
+		if p.ScopeNamespace == "*" || p.ScopeNamespace == "" {
 			continue
 		}
-		permsCopy := append([]model.Permission(nil), perms...)
-		sort.Slice(permsCopy, func(i, j int) bool {
-			return permsCopy[i].String() < permsCopy[j].String()
-		})
-		missingOut = append(missingOut, subjectPermissions{
-			Subject:     subj,
-			Permissions: permsCopy,
-		})
+		cw := p
+		cw.ScopeNamespace = "*"
+		if _, ok := clusterWide[cw]; ok {
+			out = append(out, p.String())
+		}
 	}
+	return out
+}
 
-	return extraOut, missingOut
+// capPermissions truncates a subject's permission list to limit entries
+// (0 = unlimited), recording the original total.
+func capPermissions(sp SubjectPermissions, limit int) SubjectPermissions {
+	if limit <= 0 || len(sp.Permissions) <= limit {
+		return sp
+	}
+	sp.TotalPermissions = len(sp.Permissions)
+	sp.Truncated = len(sp.Permissions) - limit
+	sp.Permissions = sp.Permissions[:limit]
+	return sp
+}
+
+// filterPermissionsByScope keeps cluster-wide ("*") or namespaced
+// permissions according to -rbac-scope. It always returns a fresh slice.
+func filterPermissionsByScope(perms []model.Permission, scope string) []model.Permission {
+	out := make([]model.Permission, 0, len(perms))
+	for _, p := range perms {
+		clusterWide := p.ScopeNamespace == "*" || p.ScopeNamespace == ""
+		switch scope {
+		case "cluster":
+			if !clusterWide {
+				continue
+			}
+		case "namespace":
+			if clusterWide {
+				continue
+			}
+		}
+		out = append(out, p)
+	}
+	return out
+}
+
+// filterPermissionsByNamespace drops namespaced permissions outside
+// -include-namespaces or inside -exclude-namespaces; cluster-wide ones are
+// kept. It also returns the filter that dropped the last permission.
+func filterPermissionsByNamespace(perms []model.Permission, opts Options) ([]model.Permission, string) {
+	out := perms[:0]
+	var dropped string
+	for _, p := range perms {
+		if p.ScopeNamespace != "*" {
+			if f := namespaceListFilter(p.ScopeNamespace, opts); f != "" {
+				dropped = f
+				continue
+			}
+		}
+		out = append(out, p)
+	}
+	return out, dropped
+}
+
+// netPolFilter returns the filter that hides the drift of a policy, or "".
+func netPolFilter(namespace, name string, opts Options) string {
+	if f := namespaceFilter(namespace, opts); f != "" {
+		return f
+	}
+	if opts.ignores.netPol(namespace, name) {
+		return "ignore-file"
+	}
+	return ""
 }
 
-func filterNetPolDriftToJSON(d diff.NetPolDrift, opts Options) netPolDriftJSON {
-	j := netPolDriftJSON{}
+func filterNetPolDriftToJSON(d diff.NetPolDrift, opts Options, tally *filterTally) NetPolReport {
+	j := NetPolReport{}
 
 	// extra / missing controlled by drift-type
 	if opts.DriftType == "extra" || opts.DriftType == "both" {
 		for _, ref := range d.Extra {
-			if opts.IgnoreSystem && isSystemNamespace(ref.Namespace) {
+			if f := netPolFilter(ref.Namespace, ref.Name, opts); f != "" {
+				tally.add(f, 1)
 				continue
 			}
 			j.Extra = append(j.Extra, ref)
 		}
+	} else {
+		tally.add("drift-type", len(d.Extra))
 	}
 	if opts.DriftType == "missing" || opts.DriftType == "both" {
 		for _, ref := range d.Missing {
-			if opts.IgnoreSystem && isSystemNamespace(ref.Namespace) {
+			if f := netPolFilter(ref.Namespace, ref.Name, opts); f != "" {
+				tally.add(f, 1)
 				continue
 			}
 			j.Missing = append(j.Missing, ref)
 		}
+	} else {
+		tally.add("drift-type", len(d.Missing))
+	}
+	// "changed" is independent of extra/missing; shown unless explicitly omitted
+	if !opts.NetPolOmitChanged {
+		for _, ch := range d.Changed {
+			if f := netPolFilter(ch.Namespace, ch.Name, opts); f != "" {
+				tally.add(f, 1)
+				continue
+			}
+			j.Changed = append(j.Changed, ch)
+		}
+	} else {
+		tally.add("netpol-include-changed", len(d.Changed))
+	}
+
+	sortFindings(j.Extra, opts.SortOrder, netPolRefSortKey)
+	sortFindings(j.Missing, opts.SortOrder, netPolRefSortKey)
+	sortFindings(j.Changed, opts.SortOrder, netPolChangeSortKey)
+	return j
+}
+
+// storageClassDriftToJSON honors -drift-type for missing/extra; changes are
+// always shown. Returns nil unless -storage-classes is set.
+func storageClassDriftToJSON(d diff.StorageClassDrift, opts Options) *storageClassDriftJSON {
+	if !opts.StorageClasses {
+		return nil
+	}
+	j := &storageClassDriftJSON{Changed: d.Changed}
+	if opts.DriftType == "extra" || opts.DriftType == "both" {
+		j.Extra = d.Extra
+	}
+	if opts.DriftType == "missing" || opts.DriftType == "both" {
+		j.Missing = d.Missing
+	}
+	return j
+}
+
+// quotaDriftToJSON applies -drift-type to missing/extra (changes are always
+// shown) and -ignore-system to all three buckets. Returns nil unless
+// -resource-quotas is set.
+func quotaDriftToJSON(d diff.ResourceQuotaDrift, opts Options, tally *filterTally) *ResourceQuotaReport {
+	if !opts.ResourceQuotas {
+		return nil
+	}
+	j := &ResourceQuotaReport{}
+	keep := func(dst *[]model.ResourceQuotaDigest, src []model.ResourceQuotaDigest) {
+		for _, q := range src {
+			if f := namespaceFilter(q.Namespace, opts); f != "" {
+				tally.add(f, 1)
+				continue
+			}
+			*dst = append(*dst, q)
+		}
+	}
+	if opts.DriftType == "extra" || opts.DriftType == "both" {
+		keep(&j.Extra, d.Extra)
+	} else {
+		tally.add("drift-type", len(d.Extra))
+	}
+	if opts.DriftType == "missing" || opts.DriftType == "both" {
+		keep(&j.Missing, d.Missing)
+	} else {
+		tally.add("drift-type", len(d.Missing))
+	}
+	for _, ch := range d.Changed {
+		if f := namespaceFilter(ch.Namespace, opts); f != "" {
+			tally.add(f, 1)
+			continue
+		}
+		j.Changed = append(j.Changed, ch)
+	}
+
+	sortFindings(j.Extra, opts.SortOrder, quotaDigestSortKey)
+	sortFindings(j.Missing, opts.SortOrder, quotaDigestSortKey)
+	sortFindings(j.Changed, opts.SortOrder, quotaChangeSortKey)
+	return j
+}
+
+// limitRangeDriftToJSON applies -drift-type to missing/extra (changes are
+// always shown) and -ignore-system to all three buckets. Returns nil unless
+// -limit-ranges is set.
+func limitRangeDriftToJSON(d diff.LimitRangeDrift, opts Options, tally *filterTally) *LimitRangeReport {
+	if !opts.LimitRanges {
+		return nil
+	}
+	j := &LimitRangeReport{}
+	keep := func(dst *[]model.LimitRangeDigest, src []model.LimitRangeDigest) {
+		for _, lr := range src {
+			if f := namespaceFilter(lr.Namespace, opts); f != "" {
+				tally.add(f, 1)
+				continue
+			}
+			*dst = append(*dst, lr)
+		}
+	}
+	if opts.DriftType == "extra" || opts.DriftType == "both" {
+		keep(&j.Extra, d.Extra)
+	} else {
+		tally.add("drift-type", len(d.Extra))
+	}
+	if opts.DriftType == "missing" || opts.DriftType == "both" {
+		keep(&j.Missing, d.Missing)
+	} else {
+		tally.add("drift-type", len(d.Missing))
 	}
-	// "changed" is always interesting, independent of extra/missing
 	for _, ch := range d.Changed {
-		if opts.IgnoreSystem && isSystemNamespace(ch.Namespace) {
+		if f := namespaceFilter(ch.Namespace, opts); f != "" {
+			tally.add(f, 1)
 			continue
 		}
 		j.Changed = append(j.Changed, ch)
 	}
 
+	sortFindings(j.Extra, opts.SortOrder, limitRangeDigestSortKey)
+	sortFindings(j.Missing, opts.SortOrder, limitRangeDigestSortKey)
+	sortFindings(j.Changed, opts.SortOrder, limitRangeChangeSortKey)
+	return j
+}
+
+// filterWebhookDrift applies -drift-type to each configuration's
+// missing/extra webhooks (changes are always shown) and drops
+// configurations left without drift.
+func filterWebhookDrift(d diff.WebhookDrift, opts Options) []model.WebhookConfigDrift {
+	var out []model.WebhookConfigDrift
+	for _, c := range d.Configurations {
+		if opts.DriftType != "extra" && opts.DriftType != "both" {
+			c.Extra = nil
+		}
+		if opts.DriftType != "missing" && opts.DriftType != "both" {
+			c.Missing = nil
+		}
+		if !c.Empty() {
+			out = append(out, c)
+		}
+	}
+	sortFindings(out, opts.SortOrder, webhookConfigSortKey)
+	return out
+}
+
+// saDriftToJSON applies -drift-type and -ignore-system to both buckets.
+// Returns nil unless -service-accounts is set.
+func saDriftToJSON(d diff.ServiceAccountDrift, opts Options, tally *filterTally) *ServiceAccountReport {
+	if !opts.ServiceAccounts {
+		return nil
+	}
+	j := &ServiceAccountReport{}
+	keep := func(dst *[]model.ServiceAccountDriftEntry, src []model.ServiceAccountDriftEntry) {
+		for _, e := range src {
+			if f := namespaceFilter(e.Namespace, opts); f != "" {
+				tally.add(f, 1)
+				continue
+			}
+			*dst = append(*dst, e)
+		}
+	}
+	if opts.DriftType == "extra" || opts.DriftType == "both" {
+		keep(&j.Extra, d.Extra)
+	} else {
+		tally.add("drift-type", len(d.Extra))
+	}
+	if opts.DriftType == "missing" || opts.DriftType == "both" {
+		keep(&j.Missing, d.Missing)
+	} else {
+		tally.add("drift-type", len(d.Missing))
+	}
+
+	sortFindings(j.Extra, opts.SortOrder, saEntrySortKey)
+	sortFindings(j.Missing, opts.SortOrder, saEntrySortKey)
 	return j
 }
 
-func psaDriftToJSON(d diff.PSADrift, opts Options) psaDriftJSON {
-	out := psaDriftJSON{}
+// psaFilter returns the filter that hides the PSA drift of a namespace, or
+// "".
+func psaFilter(namespace string, opts Options) string {
+	if f := namespaceFilter(namespace, opts); f != "" {
+		return f
+	}
+	if opts.ignores.psa(namespace) {
+		return "ignore-file"
+	}
+	return ""
+}
+
+func psaDriftToJSON(d diff.PSADrift, opts Options, tally *filterTally) PSAReport {
+	out := PSAReport{Summary: summarizePSA(d, opts)}
 
 	addFiltered := func(dst *[]model.PSADriftEntry, src []model.PSADriftEntry) {
 		for _, e := range src {
-			if opts.IgnoreSystem && isSystemNamespace(e.Namespace) {
+			if f := psaFilter(e.Namespace, opts); f != "" {
+				tally.add(f, 1)
 				continue
 			}
 			*dst = append(*dst, e)
@@ -420,35 +2828,109 @@ func psaDriftToJSON(d diff.PSADrift, opts Options) psaDriftJSON {
 		sort.Slice(*dst, func(i, j int) bool {
 			return (*dst)[i].Namespace < (*dst)[j].Namespace
 		})
+		sortFindings(*dst, opts.SortOrder, psaEntrySortKey)
+	}
+
+	extraSrc := d.Extra
+	if opts.StrictPSA {
+		extraSrc = nil
+		var incomparable []model.PSADriftEntry
+		for _, e := range d.Extra {
+			if e.DriftType == "different" {
+				incomparable = append(incomparable, e)
+				continue
+			}
+			extraSrc = append(extraSrc, e)
+		}
+		addFiltered(&out.Incomparable, incomparable)
+	}
+
+	for _, m := range d.ManagedBy {
+		if f := psaFilter(m.Namespace, opts); f != "" {
+			tally.add(f, 1)
+			continue
+		}
+		out.ManagedBy = append(out.ManagedBy, m)
 	}
+	sortFindings(out.ManagedBy, opts.SortOrder, psaManagedBySortKey)
 
 	// Honor drift-type like RBAC (extra/missing/both)
 	switch opts.DriftType {
 	case "extra":
-		addFiltered(&out.Extra, d.Extra)
+		addFiltered(&out.Extra, extraSrc)
+		tally.add("drift-type", len(d.Missing))
 	case "missing":
 		addFiltered(&out.Missing, d.Missing)
+		tally.add("drift-type", len(extraSrc))
 	case "both":
-		addFiltered(&out.Extra, d.Extra)
+		addFiltered(&out.Extra, extraSrc)
 		addFiltered(&out.Missing, d.Missing)
 	default:
 		// normalizeDriftType() should prevent this, but keep safe default
-		addFiltered(&out.Extra, d.Extra)
+		addFiltered(&out.Extra, extraSrc)
 	}
 
 	return out
 }
 
-func printJSONReport(
-	modeLabel string,
-	opts Options,
-	rbacDrift diff.RBACDrift,
-	netpolDrift diff.NetPolDrift,
-	psaDrift diff.PSADrift,
-) error {
-	extra, missing := filterRBACDriftToSlices(rbacDrift, opts)
+// parsePSAManagedBy splits a -compare-annotations-on-psa value
+// ("annotation[=value]") into the annotation name and expected value.
+func parsePSAManagedBy(s string) (key, value string) {
+	key, value, _ = strings.Cut(s, "=")
+	return strings.TrimSpace(key), strings.TrimSpace(value)
+}
+
+func summarizePSA(d diff.PSADrift, opts Options) PSASummary {
+	var sum PSASummary
+	for _, e := range append(append([]model.PSADriftEntry(nil), d.Extra...), d.Missing...) {
+		if psaFilter(e.Namespace, opts) != "" {
+			continue
+		}
+		switch e.DriftType {
+		case "weaker":
+			sum.Weaker++
+		case "stronger":
+			sum.Stronger++
+		case "different":
+			sum.Different++
+		case "extra":
+			sum.New++
+		case "missing":
+			sum.Removed++
+		case "exempted":
+			sum.Exempted++
+		}
+	}
+	for _, ns := range d.Unchanged {
+		if namespaceFilter(ns, opts) != "" {
+			continue
+		}
+		sum.Unchanged++
+	}
+	return sum
+}
+
+func printJSONReport(modeLabel string, opts Options, res driftResults) error {
+	report := buildJSONReport(modeLabel, opts, res)
+	if opts.FindingsOnly {
+		return writeJSONStream(os.Stdout, report.findings())
+	}
+	return writeJSONStream(os.Stdout, report)
+}
+
+// writeJSON writes v as indented JSON followed by a newline, the layout of
+// every JSON document driftwatch writes.
+func writeJSON(out io.Writer, v any) error {
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func buildJSONReport(modeLabel string, opts Options, res driftResults) Report {
+	var rbacTally, netpolTally, psaTally, quotaTally, limitRangeTally, saTally filterTally
+	extra, missing := filterRBACDriftToSlices(res.RBAC, opts, &rbacTally)
 
-	rbacJSON := rbacDriftJSON{}
+	rbacJSON := RBACReport{}
 	switch opts.DriftType {
 	case "extra":
 		rbacJSON.Extra = extra
@@ -458,83 +2940,359 @@ func printJSONReport(
 		rbacJSON.Extra = extra
 		rbacJSON.Missing = missing
 	}
+	rbacJSON.Renamed = filterRenames(res.RBAC.Renamed, opts)
+	rbacJSON.ResourceNames = filterResourceNameChanges(res.RBAC.ResourceNames, opts)
 
-	netpolJSON := filterNetPolDriftToJSON(netpolDrift, opts)
+	netpolJSON := filterNetPolDriftToJSON(res.NetPol, opts, &netpolTally)
 
 	// ✅ PSA now respects drift-type via psaDriftToJSON
-	psaJSON := psaDriftToJSON(psaDrift, opts)
+	psaJSON := psaDriftToJSON(res.PSA, opts, &psaTally)
 
-	report := driftReportJSON{
-		Mode:             modeLabel,
-		DriftType:        opts.DriftType,
-		IgnoreSystem:     opts.IgnoreSystem,
-		SubjectKind:      opts.SubjectKind,
-		SubjectName:      opts.SubjectName,
-		SubjectNamespace: opts.SubjectNamespace,
-		RBAC:             rbacJSON,
-		NetworkPolicy:    netpolJSON,
-		PSA:              psaJSON,
+	quotaJSON := quotaDriftToJSON(res.Quotas, opts, &quotaTally)
+	limitRangeJSON := limitRangeDriftToJSON(res.LimitRanges, opts, &limitRangeTally)
+	saJSON := saDriftToJSON(res.ServiceAccounts, opts, &saTally)
+
+	// Only explain sections the filters emptied entirely.
+	suppressed := map[string]*suppressionNote{}
+	if len(rbacJSON.Extra) == 0 && len(rbacJSON.Missing) == 0 && len(rbacJSON.Renamed) == 0 && len(rbacJSON.ResourceNames) == 0 {
+		if n := rbacTally.note(); n != nil {
+			suppressed["rbac"] = n
+		}
+	}
+	if len(netpolJSON.Extra) == 0 && len(netpolJSON.Missing) == 0 && len(netpolJSON.Changed) == 0 {
+		if n := netpolTally.note(); n != nil {
+			suppressed["networkPolicy"] = n
+		}
+	}
+	if len(psaJSON.Extra) == 0 && len(psaJSON.Missing) == 0 && len(psaJSON.Incomparable) == 0 && len(psaJSON.ManagedBy) == 0 {
+		if n := psaTally.note(); n != nil {
+			suppressed["psa"] = n
+		}
+	}
+	if quotaJSON != nil && len(quotaJSON.Extra) == 0 && len(quotaJSON.Missing) == 0 && len(quotaJSON.Changed) == 0 {
+		if n := quotaTally.note(); n != nil {
+			suppressed["resourceQuotas"] = n
+		}
+	}
+	if limitRangeJSON != nil && len(limitRangeJSON.Extra) == 0 && len(limitRangeJSON.Missing) == 0 && len(limitRangeJSON.Changed) == 0 {
+		if n := limitRangeTally.note(); n != nil {
+			suppressed["limitRanges"] = n
+		}
+	}
+	if saJSON != nil && len(saJSON.Extra) == 0 && len(saJSON.Missing) == 0 {
+		if n := saTally.note(); n != nil {
+			suppressed["serviceAccounts"] = n
+		}
 	}
 
-	enc := json.NewEncoder(os.Stdout)
-	enc.SetIndent("", "  ")
-	return enc.Encode(report)
+	report := Report{
+		opts:              opts,
+		res:               res,
+		Title:             opts.ReportTitle,
+		Labels:            opts.Labels,
+		Mode:              modeLabel,
+		DriftType:         opts.DriftType,
+		IgnoreSystem:      opts.IgnoreSystem,
+		SystemNamespaces:  opts.SystemNamespaces,
+		SystemPrefixes:    opts.SystemSubjectPrefixes,
+		IncludeNamespaces: opts.IncludeNamespaces,
+		ExcludeNamespaces: opts.ExcludeNamespaces,
+		SubjectKind:       opts.SubjectKind,
+		SubjectName:       opts.SubjectName,
+		SubjectNamespace:  opts.SubjectNamespace,
+		SubjectNameFile:   opts.SubjectNameFile,
+		IgnoreFile:        opts.IgnoreFile,
+		RBACScope:         opts.RBACScope,
+		SortOrder:         opts.SortOrder,
+		Shard:             opts.Shard,
+		NamespaceSelector: opts.NamespaceSelector,
+		Fast:              opts.Fast,
+		Stats:             res.Stats,
+		RBAC:              rbacJSON,
+		NetworkPolicy:     netpolJSON,
+		PSA:               psaJSON,
+		RoleAudit:         filterRoleAudit(res.RoleAudit, opts),
+		NetPolAudit:       filterNetPolAudit(res.NetAudit, opts),
+		SelectorAudit:     filterNetPolAudit(res.SelAudit, opts),
+		Openness:          filterNetPolOpenness(res.Openness, opts),
+		DefaultDeny:       filterDefaultDeny(res.DefaultDeny, opts),
+		Rego:              filterRegoViolations(res.Rego, opts),
+		LabelDrift:        filterLabelDrift(res.Labels, opts),
+		Bindings:          filterBindingDrift(res.Bindings, opts),
+		RoleSubjects:      filterClusterRoleSubjects(res.RoleSubjects, opts),
+		DanglingBindings:  filterDanglingBindings(res.Dangling, opts),
+		Suppressed:        suppressed,
+		Ignored:           rbacTally.byFilter["ignore-file"] + netpolTally.byFilter["ignore-file"] + psaTally.byFilter["ignore-file"],
+		StorageClasses:    storageClassDriftToJSON(res.Storage, opts),
+		ResourceQuotas:    quotaJSON,
+		LimitRanges:       limitRangeJSON,
+		AdmissionWebhooks: filterWebhookDrift(res.Webhooks, opts),
+		ServiceAccounts:   saJSON,
+		Images:            filterImageViolations(res.Images, opts),
+		LastApplied:       filterLastApplied(res.LastApplied, opts),
+		Workload:          redactWorkload(res.Workload, opts),
+		HistoryDelta:      res.History,
+		Incomplete:        res.Incomplete,
+		Warnings:          res.Warnings,
+	}
+	annotateCompliance(&report)
+	return report
 }
 
 // -----------------------------------------------------------------------------
 // Human-readable output
 // -----------------------------------------------------------------------------
 
-func printHumanReport(
-	modeLabel string,
-	opts Options,
-	rbacDrift diff.RBACDrift,
-	netpolDrift diff.NetPolDrift,
-	psaDrift diff.PSADrift,
-) {
+func printHumanReport(modeLabel string, opts Options, res driftResults) {
+	if !opts.FindingsOnly && !opts.Quiet {
+		printHumanHeader(modeLabel, opts)
+		fmt.Println()
+		if res.Stats != nil {
+			switch opts.Mode {
+			case "cluster-compare":
+				printHumanStats(res.Stats, "cluster A", "cluster B")
+			case "snapshot-compare":
+				printHumanStats(res.Stats, "snapshot A", "snapshot B")
+			default:
+				printHumanStats(res.Stats, "baseline", "live")
+			}
+			fmt.Println()
+		}
+	}
+	if len(res.Incomplete) > 0 {
+		fmt.Printf(" PARTIAL REPORT: -max-runtime expired before these sections were collected: %s\n\n",
+			strings.Join(res.Incomplete, ", "))
+	}
+
+	// Under -quiet only categories with findings are printed.
+	findings := map[string]int{}
+	if opts.Quiet {
+		eachFindingKey(buildJSONReport(modeLabel, opts, res).findings(), func(category, _ string) {
+			findings[category]++
+		})
+	}
+	printed := false
+	section := func(category string, print func()) {
+		if opts.Quiet && findings[category] == 0 {
+			return
+		}
+		if printed {
+			fmt.Println()
+		}
+		printed = true
+		print()
+	}
+	collected := func(name, what string, print func()) func() {
+		return func() {
+			if res.isIncomplete(name) {
+				printNotCollected(what)
+				return
+			}
+			print()
+		}
+	}
+
+	section("rbac", collected(sectionRBAC, "RBAC", func() { printHumanRBAC(opts, res.RBAC) }))
+	section("networkPolicy", collected(sectionNetPol, "NetworkPolicy", func() { printHumanNetPol(opts, res.NetPol) }))
+	section("psa", collected(sectionPSA, "Pod Security Admission (PSA)", func() { printHumanPSA(opts, res.PSA) }))
+	if res.Workload != nil {
+		section("workload", func() { printHumanWorkload(opts, res.Workload) })
+	}
+	if dangling := filterDanglingBindings(res.Dangling, opts); len(dangling) > 0 {
+		section("danglingBindings", func() { printHumanDanglingBindings(dangling) })
+	}
+	if opts.BindingDiff {
+		section("bindings", func() { printHumanBindings(opts, res.Bindings) })
+	}
+	if opts.ClusterRoleView {
+		section("clusterRoleSubjects", func() { printHumanClusterRoleSubjects(opts, res.RoleSubjects) })
+	}
+	if len(opts.CompareLabels) > 0 {
+		section("labelDrift", func() { printHumanLabelDrift(opts, res.Labels) })
+	}
+	if opts.AuditRoles {
+		section("roleAudit", func() { printHumanRoleAudit(opts, res.RoleAudit) })
+	}
+	if opts.StorageClasses {
+		section("storageClasses", collected(sectionStorage, "StorageClass", func() { printHumanStorageClasses(opts, res.Storage) }))
+	}
+	if opts.ResourceQuotas {
+		section("resourceQuotas", collected(sectionQuotas, "ResourceQuota", func() { printHumanQuotas(opts, res.Quotas) }))
+	}
+	if opts.LimitRanges {
+		section("limitRanges", collected(sectionLimitRanges, "LimitRange", func() { printHumanLimitRanges(opts, res.LimitRanges) }))
+	}
+	if opts.AdmissionWebhooks {
+		section("admissionWebhooks", collected(sectionWebhooks, "Admission webhook", func() { printHumanWebhooks(opts, res.Webhooks) }))
+	}
+	if opts.ServiceAccounts {
+		section("serviceAccounts", collected(sectionSAs, "ServiceAccount", func() { printHumanServiceAccounts(opts, res.ServiceAccounts) }))
+	}
+	if opts.CheckImages {
+		section("imageViolations", collected(sectionImages, "Container image", func() { printHumanImages(opts, res.Images) }))
+	}
+	if opts.LastApplied {
+		section("lastApplied", collected(sectionLastApplied, "Last-applied configuration", func() { printHumanLastApplied(opts, res.LastApplied) }))
+	}
+	if opts.AuditIPBlocks {
+		section("netpolAudit", func() { printHumanNetPolAudit(opts, res.NetAudit) })
+	}
+	if opts.AuditNetPolSelectors {
+		section("netpolSelectorAudit", func() { printHumanNetPolSelectorAudit(opts, res.SelAudit) })
+	}
+	if opts.NetPolOpenness {
+		section("netpolOpenness", collected(sectionNetPol, "NetworkPolicy openness", func() { printHumanNetPolOpenness(opts, res.Openness) }))
+	}
+	if opts.DefaultDenyCoverage {
+		section("defaultDeny", collected(sectionNetPol, "NetworkPolicy default-deny coverage", func() { printHumanDefaultDeny(opts, res.DefaultDeny) }))
+	}
+	if opts.RegoPolicy != "" {
+		section("regoViolations", func() { printHumanRego(opts, res.Rego) })
+	}
+	if res.History != nil {
+		if printed {
+			fmt.Println()
+		}
+		printHumanReportDelta(res.History)
+	}
+	if n := ignoredCount(opts, res); n > 0 {
+		// like warnings, the count is not a finding and stays off -quiet stdout
+		w := os.Stdout
+		if opts.Quiet {
+			w = os.Stderr
+		} else {
+			fmt.Println()
+		}
+		fmt.Fprintf(w, " Ignored: %d findings accepted by -ignore-file %s\n", n, opts.IgnoreFile)
+	}
+	if len(res.Warnings) > 0 {
+		// -quiet keeps stdout to findings; warnings still reach the user.
+		w := os.Stdout
+		if opts.Quiet {
+			w = os.Stderr
+		} else {
+			fmt.Println()
+		}
+		fmt.Fprintf(w, " Warnings (%d):\n", len(res.Warnings))
+		for _, warning := range res.Warnings {
+			fmt.Fprintf(w, "  - %s\n", warning)
+		}
+	}
+}
+
+// printHumanHeader prints the report metadata block (title, mode, inputs
+// and active filters).
+func printHumanHeader(modeLabel string, opts Options) {
+	if opts.ReportTitle != "" {
+		fmt.Printf("Report: %s\n", opts.ReportTitle)
+	}
+	if len(opts.Labels) > 0 {
+		keys := make([]string, 0, len(opts.Labels))
+		for k := range opts.Labels {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			fmt.Printf("Label: %s=%s\n", k, opts.Labels[k])
+		}
+	}
 	fmt.Printf("Mode: %s\n", modeLabel)
-	if opts.BaselineDir != "" {
+	switch opts.BaselineDir {
+	case "":
+	case source.Stdin:
+		fmt.Println("Baseline YAML: stdin")
+	default:
 		fmt.Printf("Baseline YAML dir: %s\n", opts.BaselineDir)
 	}
-	if opts.Kubeconfig != "" {
-		fmt.Printf("Live kubeconfig: %s\n", opts.Kubeconfig)
+	if opts.Kubeconfig != "" {
+		fmt.Printf("Live kubeconfig: %s\n", opts.Kubeconfig)
+		if opts.Context != "" {
+			fmt.Printf("Live context: %s\n", opts.Context)
+		}
+	}
+	if opts.APIServer != "" {
+		fmt.Printf("Live API server: %s\n", opts.APIServer)
+	}
+	if opts.InCluster {
+		fmt.Println("Live cluster: in-cluster ServiceAccount")
+	}
+	if opts.SnapshotA != "" {
+		fmt.Printf("Snapshot A: %s\n", opts.SnapshotA)
+	}
+	if opts.SnapshotB != "" {
+		fmt.Printf("Snapshot B: %s\n", opts.SnapshotB)
 	}
 	if opts.KubeconfigA != "" || opts.KubeconfigB != "" {
 		if opts.KubeconfigA != "" {
 			fmt.Printf("Cluster A kubeconfig: %s\n", opts.KubeconfigA)
+			if opts.ContextA != "" {
+				fmt.Printf("Cluster A context: %s\n", opts.ContextA)
+			}
 		}
 		if opts.KubeconfigB != "" {
 			fmt.Printf("Cluster B kubeconfig: %s\n", opts.KubeconfigB)
+			if opts.ContextB != "" {
+				fmt.Printf("Cluster B context: %s\n", opts.ContextB)
+			}
 		}
 	}
 	fmt.Printf("Drift type: %s\n", opts.DriftType)
 	fmt.Printf("Ignore system: %v\n", opts.IgnoreSystem)
+	if strings.TrimSpace(opts.SystemNamespaces) != "" {
+		fmt.Printf("System namespaces: %s\n", systemList(opts.SystemNamespaces, defaultSystemNamespaces))
+	}
+	if strings.TrimSpace(opts.SystemSubjectPrefixes) != "" {
+		fmt.Printf("System subject prefixes: %s\n", systemList(opts.SystemSubjectPrefixes, defaultSystemSubjectPrefixes))
+	}
+	if strings.TrimSpace(opts.IncludeNamespaces) != "" {
+		fmt.Printf("Include namespaces: %s\n", opts.IncludeNamespaces)
+	}
+	if strings.TrimSpace(opts.ExcludeNamespaces) != "" {
+		fmt.Printf("Exclude namespaces: %s\n", opts.ExcludeNamespaces)
+	}
 	if strings.TrimSpace(opts.SubjectKind) != "" && strings.ToLower(opts.SubjectKind) != "all" {
 		fmt.Printf("Subject kind filter: %s\n", opts.SubjectKind)
 	}
 	if strings.TrimSpace(opts.SubjectName) != "" {
 		fmt.Printf("Subject name filter: %s\n", opts.SubjectName)
 	}
+	if opts.SubjectNameFile != "" {
+		fmt.Printf("Subject name file: %s (%d entries)\n", opts.SubjectNameFile, len(opts.SubjectNames))
+	}
+	if opts.IgnoreFile != "" {
+		fmt.Printf("Ignore file: %s\n", opts.IgnoreFile)
+	}
 	if strings.TrimSpace(opts.SubjectNamespace) != "" {
 		fmt.Printf("Subject namespace filter: %s\n", opts.SubjectNamespace)
 	}
-
-	fmt.Println()
-	printHumanRBAC(opts, rbacDrift)
-	fmt.Println()
-	printHumanNetPol(opts, netpolDrift)
-	fmt.Println()
-	printHumanPSA(opts, psaDrift)
+	if opts.RBACScope != "both" {
+		fmt.Printf("RBAC scope: %s\n", opts.RBACScope)
+	}
+	if opts.SortOrder != "" {
+		fmt.Printf("Sort: %s\n", opts.SortOrder)
+	}
+	if opts.Shard != "" {
+		fmt.Printf("Shard: %s\n", opts.Shard)
+	}
+	if opts.NamespaceSelector != "" {
+		fmt.Printf("Namespace selector: %s\n", opts.NamespaceSelector)
+	}
+	if opts.Fast {
+		fmt.Println("Fast mode: NetworkPolicy changes by spec hash only")
+	}
 }
 
 func printHumanRBAC(opts Options, rbacDrift diff.RBACDrift) {
-	extra, missing := filterRBACDriftToSlices(rbacDrift, opts)
+	var tally filterTally
+	extra, missing := filterRBACDriftToSlices(rbacDrift, opts, &tally)
+	renamed := filterRenames(rbacDrift.Renamed, opts)
+	resourceNames := filterResourceNameChanges(rbacDrift.ResourceNames, opts)
 
 	hasExtra := len(extra) > 0 && (opts.DriftType == "extra" || opts.DriftType == "both")
 	hasMissing := len(missing) > 0 && (opts.DriftType == "missing" || opts.DriftType == "both")
 
-	if !hasExtra && !hasMissing {
-		fmt.Println(" No RBAC drift detected matching the current filters.")
+	if !hasExtra && !hasMissing && len(renamed) == 0 && len(resourceNames) == 0 {
+		printNoDrift("RBAC drift", tally.note())
 		return
 	}
 
@@ -543,12 +3301,13 @@ func printHumanRBAC(opts Options, rbacDrift diff.RBACDrift) {
 		for _, sp := range extra {
 			fmt.Printf("\nSubject: %s\n", sp.Subject.String())
 			fmt.Println("  Extra permissions vs baseline:")
-			for _, p := range sp.Permissions {
-				fmt.Printf("    - %s\n", p.String())
+			printPermissionLines(sp)
+			if sp.Truncated > 0 {
+				fmt.Printf("    ... +%d more\n", sp.Truncated)
 			}
 		}
 		fmt.Println()
-	} else if opts.DriftType == "extra" {
+	} else if opts.DriftType == "extra" && !opts.Quiet {
 		fmt.Println(" No extra RBAC permissions detected matching the current filters.")
 	}
 
@@ -557,24 +3316,140 @@ func printHumanRBAC(opts Options, rbacDrift diff.RBACDrift) {
 		for _, sp := range missing {
 			fmt.Printf("\nSubject: %s\n", sp.Subject.String())
 			fmt.Println("  Missing permissions vs baseline:")
-			for _, p := range sp.Permissions {
-				fmt.Printf("    - %s\n", p.String())
+			printPermissionLines(sp)
+			if sp.Truncated > 0 {
+				fmt.Printf("    ... +%d more\n", sp.Truncated)
 			}
 		}
-	} else if opts.DriftType == "missing" {
+	} else if opts.DriftType == "missing" && !opts.Quiet {
 		fmt.Println(" No missing RBAC permissions detected matching the current filters.")
 	}
+
+	if len(renamed) > 0 && (hasMissing || (!hasExtra && opts.DriftType != "both")) {
+		fmt.Println()
+	}
+	printHumanRenames(renamed)
+
+	if len(resourceNames) > 0 && (len(renamed) > 0 || hasMissing || (!hasExtra && opts.DriftType != "both")) {
+		fmt.Println()
+	}
+	printHumanResourceNames(resourceNames)
+}
+
+// printHumanResourceNames prints the resourceName set changes of the RBAC
+// section, one grant per line.
+func printHumanResourceNames(changes []model.ResourceNameChange) {
+	if len(changes) == 0 {
+		return
+	}
+	fmt.Printf(" RBAC drift: grants whose resourceNames changed (%d):\n", len(changes))
+	for _, c := range changes {
+		fmt.Printf("\nSubject: %s\n", c.Subject)
+		fmt.Printf("  %s\n", grantString(c.Grant()))
+		for _, name := range c.Added {
+			fmt.Printf("    + added resourceName %s\n", name)
+		}
+		for _, name := range c.Removed {
+			fmt.Printf("    - removed resourceName %s\n", name)
+		}
+		if len(c.Unchanged) > 0 {
+			fmt.Printf("    unchanged: %s\n", strings.Join(c.Unchanged, ", "))
+		}
+	}
+}
+
+// grantString renders a permission without its resourceName.
+func grantString(p model.Permission) string {
+	s := p.String()
+	if i := strings.LastIndex(s, " resourceName="); i >= 0 {
+		s = s[:i]
+	}
+	return s
+}
+
+// printPSARanking explains how PSA enforce levels are ordered when deciding
+// whether a namespace got weaker or stronger.
+func printPSARanking() {
+	parts := make([]string, 0, len(diff.PSARankedLevels))
+	for _, level := range diff.PSARankedLevels {
+		name := string(level)
+		if name == "" {
+			name = "unset"
+		}
+		parts = append(parts, fmt.Sprintf("%s=%d", name, diff.PSARank(level)))
+	}
+	fmt.Printf(" PSA ranking (enforce label): %s\n", strings.Join(parts, ", "))
+	fmt.Println("   A namespace without an enforce label ranks below privileged: nothing is enforced there,")
+	fmt.Println("   so a live unlabeled namespace is \"weaker\" even than a privileged baseline.")
+	fmt.Println("   Unknown (custom) values also rank 0; -strict-psa reports them as errors.")
+	fmt.Println()
+}
+
+// printHumanRenames prints the renamed-subject block of the RBAC section.
+func printHumanRenames(renamed []model.SubjectRename) {
+	if len(renamed) == 0 {
+		return
+	}
+	fmt.Printf(" RBAC drift: subjects renamed with identical permissions (%d):\n", len(renamed))
+	for _, r := range renamed {
+		fmt.Printf("  - %s -> %s (%d permissions)\n", r.From, r.To, len(r.Permissions))
+	}
+}
+
+// printNotCollected replaces a section cut short by -max-runtime.
+func printNotCollected(what string) {
+	fmt.Printf(" %s: not collected before -max-runtime expired (incomplete).\n", what)
+}
+
+// printNoDrift prints the empty-section line for what, noting when the
+// section is empty only because filters suppressed its findings.
+func printNoDrift(what string, note *suppressionNote) {
+	if note == nil {
+		fmt.Printf(" No %s detected matching the current filters.\n", what)
+		return
+	}
+	fmt.Printf(" No %s shown (%d findings suppressed by filters: %s).\n",
+		what, note.Count, strings.Join(note.Filters, ", "))
+}
+
+func printPermissionLines(sp SubjectPermissions) {
+	covered := map[string]struct{}{}
+	for _, c := range sp.CoveredByClusterWide {
+		covered[c] = struct{}{}
+	}
+	for _, p := range sp.Permissions {
+		line := p.String()
+		if _, ok := covered[line]; ok {
+			fmt.Printf("    - %s severity=%s (covered by cluster-wide grant)%s\n", line, p.Severity, definedInSuffix(p.DefinedIn...))
+			continue
+		}
+		fmt.Printf("    - %s severity=%s%s\n", line, p.Severity, definedInSuffix(p.DefinedIn...))
+	}
+}
+
+// definedInSuffix names the baseline documents declaring a finding's
+// object, or returns "" when they are unknown.
+func definedInSuffix(refs ...model.SourceRef) string {
+	if len(refs) == 0 {
+		return ""
+	}
+	where := make([]string, len(refs))
+	for i, ref := range refs {
+		where[i] = ref.String()
+	}
+	return " (defined in " + strings.Join(where, "; ") + ")"
 }
 
 func printHumanNetPol(opts Options, netpolDrift diff.NetPolDrift) {
-	j := filterNetPolDriftToJSON(netpolDrift, opts)
+	var tally filterTally
+	j := filterNetPolDriftToJSON(netpolDrift, opts, &tally)
 
 	hasExtra := len(j.Extra) > 0
 	hasMissing := len(j.Missing) > 0
 	hasChanged := len(j.Changed) > 0
 
 	if !hasExtra && !hasMissing && !hasChanged {
-		fmt.Println(" No NetworkPolicy drift detected matching the current filters.")
+		printNoDrift("NetworkPolicy drift", tally.note())
 		return
 	}
 
@@ -583,9 +3458,9 @@ func printHumanNetPol(opts Options, netpolDrift diff.NetPolDrift) {
 	if hasMissing {
 		fmt.Printf("\nPolicies present in baseline but missing in live (%d):\n", len(j.Missing))
 		for _, ref := range j.Missing {
-			fmt.Printf("  - %s\n", ref.String())
+			fmt.Printf("  - %s%s\n", ref.String(), definedInSuffix(sourceRefs(ref.DefinedIn)...))
 		}
-	} else if opts.DriftType == "missing" || opts.DriftType == "both" {
+	} else if !opts.Quiet && (opts.DriftType == "missing" || opts.DriftType == "both") {
 		fmt.Println("\nNo NetworkPolicies missing in live vs baseline (after filters).")
 	}
 
@@ -594,13 +3469,24 @@ func printHumanNetPol(opts Options, netpolDrift diff.NetPolDrift) {
 		for _, ref := range j.Extra {
 			fmt.Printf("  - %s\n", ref.String())
 		}
-	} else if opts.DriftType == "extra" || opts.DriftType == "both" {
+	} else if !opts.Quiet && (opts.DriftType == "extra" || opts.DriftType == "both") {
 		fmt.Println("\nNo extra NetworkPolicies in live vs baseline (after filters).")
 	}
 
 	if hasChanged {
 		fmt.Printf("\nPolicies whose spec changed between baseline and live (%d):\n", len(j.Changed))
+		if n := opts.ChangedDetailThreshold; n > 0 && len(j.Changed) > n {
+			fmt.Printf("  (more than %d changed; per-policy detail omitted, use -output json for it)\n", n)
+			for _, ch := range j.Changed {
+				fmt.Printf("  - %s/%s\n", ch.Namespace, ch.Name)
+			}
+			return
+		}
 		for _, ch := range j.Changed {
+			if opts.Fast {
+				fmt.Printf("  - %s/%s (spec hash differs)\n", ch.Namespace, ch.Name)
+				continue
+			}
 			fmt.Printf(
 				"  - %s/%s (types: A=%v, B=%v; ingress: A=%d, B=%d; egress: A=%d, B=%d)\n",
 				ch.Namespace, ch.Name,
@@ -608,18 +3494,79 @@ func printHumanNetPol(opts Options, netpolDrift diff.NetPolDrift) {
 				ch.Baseline.IngressCount, ch.Live.IngressCount,
 				ch.Baseline.EgressCount, ch.Live.EgressCount,
 			)
+			if ch.Rules != nil {
+				printNetPolRuleDiff(*ch.Rules)
+			}
 		}
 	}
 }
 
+func printNetPolRuleDiff(d model.NetPolRuleDiff) {
+	for _, c := range d.PodSelector {
+		fmt.Printf("      podSelector %s: baseline=%q live=%q\n", c.Key, c.Baseline, c.Live)
+	}
+	for _, r := range d.IngressRemoved {
+		fmt.Printf("      - ingress %s\n", r)
+	}
+	for _, r := range d.IngressAdded {
+		fmt.Printf("      + ingress %s\n", r)
+	}
+	for _, r := range d.EgressRemoved {
+		fmt.Printf("      - egress %s\n", r)
+	}
+	for _, r := range d.EgressAdded {
+		fmt.Printf("      + egress %s\n", r)
+	}
+	for _, p := range d.PortChanges {
+		fmt.Printf("      ~ %s %s: ports %s -> %s\n", p.Direction, p.Peers, p.Baseline, p.Live)
+	}
+}
+
 func printHumanPSA(opts Options, psaDrift diff.PSADrift) {
-	j := psaDriftToJSON(psaDrift, opts)
+	var tally filterTally
+	j := psaDriftToJSON(psaDrift, opts, &tally)
+
+	if opts.ExplainPSA {
+		printPSARanking()
+	}
+
+	sum := j.Summary
+	if !opts.Quiet {
+		fmt.Printf(" PSA summary: weaker=%d stronger=%d different=%d unchanged=%d new=%d removed=%d\n",
+			sum.Weaker, sum.Stronger, sum.Different, sum.Unchanged, sum.New, sum.Removed)
+	}
+	if sum.Exempted > 0 {
+		fmt.Printf(" PSA exemptions: %d namespaces the baseline expects to be enforced are exempted at the API server\n", sum.Exempted)
+	}
+
+	if len(j.Incomparable) > 0 {
+		fmt.Printf(" ERROR: namespaces with incomparable PSA enforce levels (%d):\n", len(j.Incomparable))
+		for _, e := range j.Incomparable {
+			fmt.Printf(" - Namespace %s: baseline=%q, live=%q\n", e.Namespace, e.Baseline, e.Live)
+		}
+		fmt.Println()
+	}
+
+	if len(j.ManagedBy) > 0 {
+		fmt.Printf(" Namespaces whose PSA labels are not controller-managed (%d):\n", len(j.ManagedBy))
+		for _, m := range j.ManagedBy {
+			if m.Reason == "absent" {
+				fmt.Printf(" - Namespace %s: annotation %s absent\n", m.Namespace, m.Annotation)
+				continue
+			}
+			fmt.Printf(" - Namespace %s: annotation %s=%q, expected %q\n", m.Namespace, m.Annotation, m.Live, m.Expected)
+		}
+		fmt.Println()
+	}
 
 	hasExtra := len(j.Extra) > 0 && (opts.DriftType == "extra" || opts.DriftType == "both")
 	hasMissing := len(j.Missing) > 0 && (opts.DriftType == "missing" || opts.DriftType == "both")
 
 	if !hasExtra && !hasMissing {
-		fmt.Println(" No Pod Security Admission (PSA) drift detected matching the current filters.")
+		if len(j.Incomparable) > 0 || len(j.ManagedBy) > 0 {
+			tally = filterTally{}
+		}
+		printNoDrift("Pod Security Admission (PSA) drift", tally.note())
 		return
 	}
 
@@ -631,17 +3578,417 @@ func printHumanPSA(opts Options, psaDrift diff.PSADrift) {
 			fmt.Printf(" - Namespace %s: baseline=%s, live=%s → %s\n",
 				e.Namespace, e.Baseline, e.Live, e.DriftType)
 		}
-	} else if opts.DriftType == "extra" {
+	} else if opts.DriftType == "extra" && !opts.Quiet {
 		fmt.Println("\nNo weaker (extra-risk) PSA drift detected (after filters).")
 	}
 
 	if hasMissing {
 		fmt.Printf("\nNamespaces stricter in live vs baseline (%d):\n", len(j.Missing))
 		for _, e := range j.Missing {
-			fmt.Printf(" - Namespace %s: baseline=%s, live=%s → %s\n",
-				e.Namespace, e.Baseline, e.Live, e.DriftType)
+			fmt.Printf(" - Namespace %s: baseline=%s, live=%s → %s%s\n",
+				e.Namespace, e.Baseline, e.Live, e.DriftType, definedInSuffix(sourceRefs(e.DefinedIn)...))
 		}
-	} else if opts.DriftType == "missing" {
+	} else if opts.DriftType == "missing" && !opts.Quiet {
 		fmt.Println("\nNo stricter (missing-risk) PSA drift detected (after filters).")
 	}
 }
+
+func printHumanRoleAudit(opts Options, findings []model.RoleRiskFinding) {
+	findings = filterRoleAudit(findings, opts)
+	if len(findings) == 0 {
+		fmt.Println(" No dangerous wildcard ClusterRole grants found.")
+		return
+	}
+
+	fmt.Printf(" ClusterRole audit: dangerous wildcard grants (%d):\n", len(findings))
+	for _, f := range findings {
+		group := f.APIGroup
+		if group == "" {
+			group = "core"
+		}
+		fmt.Printf("  - [%s] %s ClusterRole %s: verbs=%v resource=%s.%s (%s)\n",
+			f.Severity, f.Source, f.Role, f.Verbs, f.Resource, group, f.Reason)
+	}
+}
+
+func printHumanStorageClasses(opts Options, d diff.StorageClassDrift) {
+	j := storageClassDriftToJSON(d, opts)
+	if len(j.Missing) == 0 && len(j.Extra) == 0 && len(j.Changed) == 0 {
+		fmt.Println(" No StorageClass drift detected matching the current filters.")
+		return
+	}
+
+	fmt.Println(" StorageClass drift detected:")
+	if len(j.Missing) > 0 {
+		fmt.Printf("\nStorageClasses present in baseline but missing in live (%d):\n", len(j.Missing))
+		for _, sc := range j.Missing {
+			fmt.Printf("  - %s (provisioner=%s)\n", sc.Name, sc.Provisioner)
+		}
+	}
+	if len(j.Extra) > 0 {
+		fmt.Printf("\nStorageClasses present in live but not in baseline (%d):\n", len(j.Extra))
+		for _, sc := range j.Extra {
+			fmt.Printf("  - %s (provisioner=%s)\n", sc.Name, sc.Provisioner)
+		}
+	}
+	if len(j.Changed) > 0 {
+		fmt.Printf("\nStorageClasses changed between baseline and live (%d):\n", len(j.Changed))
+		for _, ch := range j.Changed {
+			b, l := ch.Baseline, ch.Live
+			fmt.Printf("  - %s (provisioner: A=%s, B=%s; reclaimPolicy: A=%s, B=%s)\n",
+				ch.Name, b.Provisioner, l.Provisioner, b.ReclaimPolicy, l.ReclaimPolicy)
+			if ch.DefaultChanged {
+				fmt.Printf("    ! default class changed: A=%v, B=%v\n", b.IsDefault, l.IsDefault)
+			}
+		}
+	}
+}
+
+func printHumanQuotas(opts Options, d diff.ResourceQuotaDrift) {
+	var tally filterTally
+	j := quotaDriftToJSON(d, opts, &tally)
+	if len(j.Missing) == 0 && len(j.Extra) == 0 && len(j.Changed) == 0 {
+		fmt.Println(" No ResourceQuota drift detected matching the current filters.")
+		return
+	}
+
+	fmt.Println(" ResourceQuota drift detected:")
+	if len(j.Missing) > 0 {
+		fmt.Printf("\nResourceQuotas present in baseline but missing in live (%d):\n", len(j.Missing))
+		for _, q := range j.Missing {
+			fmt.Printf("  - %s%s\n", q, definedInSuffix(sourceRefs(q.DefinedIn)...))
+		}
+	}
+	if len(j.Extra) > 0 {
+		fmt.Printf("\nResourceQuotas present in live but not in baseline (%d):\n", len(j.Extra))
+		for _, q := range j.Extra {
+			fmt.Printf("  - %s\n", q)
+		}
+	}
+	if len(j.Changed) > 0 {
+		fmt.Printf("\nResourceQuotas with changed hard limits (%d):\n", len(j.Changed))
+		for _, ch := range j.Changed {
+			fmt.Printf("  - %s/%s\n", ch.Namespace, ch.Name)
+			for _, l := range ch.Limits {
+				fmt.Printf("      %s: baseline=%s live=%s\n", l.Resource, quantityText(l.Baseline), quantityText(l.Live))
+			}
+		}
+	}
+}
+
+func printHumanLimitRanges(opts Options, d diff.LimitRangeDrift) {
+	var tally filterTally
+	j := limitRangeDriftToJSON(d, opts, &tally)
+	if len(j.Missing) == 0 && len(j.Extra) == 0 && len(j.Changed) == 0 {
+		fmt.Println(" No LimitRange drift detected matching the current filters.")
+		return
+	}
+
+	fmt.Println(" LimitRange drift detected:")
+	if len(j.Missing) > 0 {
+		fmt.Printf("\nLimitRanges present in baseline but missing in live (%d):\n", len(j.Missing))
+		for _, lr := range j.Missing {
+			fmt.Printf("  - %s%s\n", lr, definedInSuffix(sourceRefs(lr.DefinedIn)...))
+		}
+	}
+	if len(j.Extra) > 0 {
+		fmt.Printf("\nLimitRanges present in live but not in baseline (%d):\n", len(j.Extra))
+		for _, lr := range j.Extra {
+			fmt.Printf("  - %s\n", lr)
+		}
+	}
+	if len(j.Changed) > 0 {
+		fmt.Printf("\nLimitRanges changed between baseline and live (%d):\n", len(j.Changed))
+		for _, ch := range j.Changed {
+			fmt.Printf("  - %s/%s\n", ch.Namespace, ch.Name)
+			for _, f := range ch.Fields {
+				fmt.Printf("      %s: baseline=%s live=%s\n", f, quantityText(f.Baseline), quantityText(f.Live))
+			}
+		}
+	}
+}
+
+func printHumanWebhooks(opts Options, d diff.WebhookDrift) {
+	configs := filterWebhookDrift(d, opts)
+	if len(configs) == 0 {
+		fmt.Println(" No admission webhook drift detected matching the current filters.")
+		return
+	}
+
+	fmt.Printf(" Admission webhook drift detected (%d configurations):\n", len(configs))
+	for _, c := range configs {
+		fmt.Printf("\n%s %s:\n", c.Kind, c.Name)
+		for _, w := range c.Missing {
+			fmt.Printf("  - webhook %s missing in live (failurePolicy=%s)%s\n", w.Name, w.FailurePolicy, definedInSuffix(sourceRefs(w.DefinedIn)...))
+		}
+		for _, w := range c.Extra {
+			fmt.Printf("  - webhook %s only in live (failurePolicy=%s)\n", w.Name, w.FailurePolicy)
+		}
+		for _, ch := range c.Changed {
+			fmt.Printf("  - webhook %s changed:\n", ch.Webhook)
+			for _, f := range ch.Fields {
+				fmt.Printf("      %s: baseline=%s live=%s\n", f.Field, webhookFieldText(f.Baseline), webhookFieldText(f.Live))
+			}
+		}
+	}
+}
+
+func printHumanServiceAccounts(opts Options, d diff.ServiceAccountDrift) {
+	var tally filterTally
+	j := saDriftToJSON(d, opts, &tally)
+	if len(j.Extra) == 0 && len(j.Missing) == 0 {
+		printNoDrift("ServiceAccount drift", tally.note())
+		return
+	}
+
+	fmt.Println(" ServiceAccount drift detected:")
+	if len(j.Extra) > 0 {
+		fmt.Printf("\nServiceAccounts weaker in live vs baseline, or with changed secrets (%d):\n", len(j.Extra))
+		for _, e := range j.Extra {
+			printSAEntry(e)
+		}
+	}
+	if len(j.Missing) > 0 {
+		fmt.Printf("\nServiceAccounts stricter in live vs baseline, or missing in live (%d):\n", len(j.Missing))
+		for _, e := range j.Missing {
+			printSAEntry(e)
+		}
+	}
+}
+
+func printSAEntry(e model.ServiceAccountDriftEntry) {
+	if e.DriftType == "missing" {
+		fmt.Printf(" - ServiceAccount %s → missing%s\n", e, definedInSuffix(sourceRefs(e.DefinedIn)...))
+		return
+	}
+	fmt.Printf(" - ServiceAccount %s: automount token baseline=%v, live=%v → %s\n",
+		e, e.BaselineMountsToken, e.LiveMountsToken, e.DriftType)
+	for _, d := range []struct {
+		label string
+		names []string
+	}{
+		{"imagePullSecrets added", e.ImagePullSecretsAdded},
+		{"imagePullSecrets removed", e.ImagePullSecretsRemoved},
+		{"secrets added", e.SecretsAdded},
+		{"secrets removed", e.SecretsRemoved},
+	} {
+		if len(d.names) > 0 {
+			fmt.Printf("     %s: %s\n", d.label, strings.Join(d.names, ", "))
+		}
+	}
+}
+
+func webhookFieldText(s string) string {
+	if s == "" {
+		return "(none)"
+	}
+	return s
+}
+
+func quantityText(q string) string {
+	if q == "" {
+		return "(unset)"
+	}
+	return q
+}
+
+func printHumanImages(opts Options, violations []model.ImageViolation) {
+	violations = filterImageViolations(violations, opts)
+	if len(violations) == 0 {
+		fmt.Println(" No container images from disallowed registries found.")
+		return
+	}
+
+	fmt.Printf(" Container images from disallowed registries (%d):\n", len(violations))
+	for _, v := range violations {
+		fmt.Printf("  - Namespace %s: %s (registry %s)\n", v.Namespace, v.Image, v.Registry)
+	}
+}
+
+func printHumanLastApplied(opts Options, drift []model.LastAppliedDrift) {
+	drift = filterLastApplied(drift, opts)
+	if len(drift) == 0 {
+		fmt.Println(" No objects drifted from their last-applied configuration.")
+		return
+	}
+
+	fmt.Printf(" Objects changed since their last kubectl apply (%d):\n", len(drift))
+	for _, d := range drift {
+		fmt.Printf("  - %s %s: %s\n", d.Source, d, strings.Join(d.Fields, ", "))
+	}
+}
+
+func printHumanNetPolAudit(opts Options, findings []model.NetPolRiskFinding) {
+	findings = filterNetPolAudit(findings, opts)
+	if len(findings) == 0 {
+		fmt.Println(" No overly broad NetworkPolicy ipBlocks found.")
+		return
+	}
+
+	fmt.Printf(" NetworkPolicy audit: overly broad ipBlocks (%d):\n", len(findings))
+	for _, f := range findings {
+		fmt.Printf("  - [%s] %s NetworkPolicy %s/%s: %s %s (%s)\n",
+			f.Severity, f.Source, f.Namespace, f.Name, f.Direction, f.Detail, f.Reason)
+	}
+}
+
+func printHumanNetPolSelectorAudit(opts Options, findings []model.NetPolRiskFinding) {
+	findings = filterNetPolAudit(findings, opts)
+	if len(findings) == 0 {
+		fmt.Println(" No NetworkPolicy namespaceSelectors matching zero namespaces found.")
+		return
+	}
+
+	fmt.Printf(" NetworkPolicy advisory: namespaceSelectors matching no namespaces (%d):\n", len(findings))
+	for _, f := range findings {
+		fmt.Printf("  - %s NetworkPolicy %s/%s: %s %s\n",
+			f.Source, f.Namespace, f.Name, f.Direction, f.Detail)
+	}
+}
+
+func printHumanNetPolOpenness(opts Options, changes []model.NetPolOpennessChange) {
+	changes = filterNetPolOpenness(changes, opts)
+	if len(changes) == 0 {
+		fmt.Println(" No NetworkPolicies more open in live than in the baseline.")
+		return
+	}
+
+	fmt.Printf(" NetworkPolicies more open in live (%d):\n", len(changes))
+	for _, c := range changes {
+		if c.DriftType == "new" {
+			fmt.Printf("  - [%s] %s/%s %s: %s (not in baseline)\n",
+				netPolOpennessSeverity(c), c.Namespace, c.Name, c.Direction, c.Live)
+			continue
+		}
+		fmt.Printf("  - [%s] %s/%s %s: %s -> %s\n",
+			netPolOpennessSeverity(c), c.Namespace, c.Name, c.Direction, c.Baseline, c.Live)
+	}
+}
+
+func printHumanDefaultDeny(opts Options, gaps []model.DefaultDenyGap) {
+	gaps = filterDefaultDeny(gaps, opts)
+	if len(gaps) == 0 {
+		fmt.Println(" Every namespace has an ingress and egress default-deny NetworkPolicy.")
+		return
+	}
+
+	fmt.Printf(" Namespaces without a default-deny NetworkPolicy (%d):\n", len(gaps))
+	for _, g := range gaps {
+		if g.DriftType == "lost" {
+			fmt.Printf("  - [%s] %s: %s default-deny lost (baseline: %s)\n",
+				defaultDenySeverity(g), g.Namespace, g.Direction, strings.Join(g.BaselinePolicies, ", "))
+			continue
+		}
+		fmt.Printf("  - [%s] %s: no %s default-deny\n", defaultDenySeverity(g), g.Namespace, g.Direction)
+	}
+}
+
+func printHumanRego(opts Options, violations []model.RegoViolation) {
+	violations = filterRegoViolations(violations, opts)
+	if len(violations) == 0 {
+		fmt.Printf(" No violations of Rego policy %s.\n", opts.RegoPolicy)
+		return
+	}
+
+	fmt.Printf(" Rego policy violations (%s, %d):\n", opts.RegoPolicy, len(violations))
+	for _, v := range violations {
+		obj := v.Namespace
+		if v.Name != "" {
+			obj = v.Namespace + "/" + v.Name
+		}
+		if obj != "" {
+			fmt.Printf("  - [%s] %s: %s\n", v.Severity, obj, v.Message)
+		} else {
+			fmt.Printf("  - [%s] %s\n", v.Severity, v.Message)
+		}
+	}
+}
+
+func printHumanLabelDrift(opts Options, changes []model.LabelChange) {
+	changes = filterLabelDrift(changes, opts)
+	if len(changes) == 0 {
+		fmt.Printf(" No label drift detected for keys %v.\n", opts.CompareLabels)
+		return
+	}
+
+	fmt.Printf(" Label drift on compared keys %v (%d):\n", opts.CompareLabels, len(changes))
+	for _, ch := range changes {
+		obj := ch.Namespace
+		if ch.Name != "" {
+			obj = ch.Namespace + "/" + ch.Name
+		}
+		fmt.Printf("  - %s %s: %s baseline=%q live=%q\n", ch.Kind, obj, ch.Key, ch.Baseline, ch.Live)
+	}
+}
+
+// printHumanClusterRoleSubjects prints drifted ClusterRoles with their full
+// subject lists; roles whose subjects did not change are only counted.
+func printHumanClusterRoleSubjects(opts Options, views []model.ClusterRoleSubjects) {
+	views = filterClusterRoleSubjects(views, opts)
+	var drifted []model.ClusterRoleSubjects
+	for _, v := range views {
+		if v.Drifted {
+			drifted = append(drifted, v)
+		}
+	}
+	if len(drifted) == 0 {
+		fmt.Printf(" ClusterRole subjects: no drift in %d ClusterRoles matching the current filters.\n", len(views))
+		return
+	}
+
+	fmt.Printf(" ClusterRole subjects: %d of %d ClusterRoles changed who holds them:\n", len(drifted), len(views))
+	for _, v := range drifted {
+		fmt.Printf("\nClusterRole: %s (baseline %d, live %d subjects)\n", v.Role, len(v.Baseline), len(v.Live))
+		added := map[model.ClusterRoleGrant]bool{}
+		for _, g := range v.Added {
+			added[g] = true
+		}
+		for _, g := range v.Live {
+			if added[g] {
+				fmt.Printf("  + %s\n", g)
+			} else {
+				fmt.Printf("    %s\n", g)
+			}
+		}
+		for _, g := range v.Removed {
+			fmt.Printf("  - %s\n", g)
+		}
+	}
+}
+
+// printHumanDanglingBindings prints the (already filtered) bindings whose
+// role does not exist. Unlike other sections it is omitted when empty.
+func printHumanDanglingBindings(bindings []model.DanglingBinding) {
+	fmt.Printf(" Dangling bindings: roleRef names a role that does not exist (%d):\n", len(bindings))
+	for _, d := range bindings {
+		name := d.Name
+		if d.Namespace != "" {
+			name = d.Namespace + "/" + d.Name
+		}
+		subjects := make([]string, 0, len(d.Subjects))
+		for _, subj := range d.Subjects {
+			subjects = append(subjects, subj.String())
+		}
+		fmt.Printf("  - %s %s %s -> missing %s: %s%s\n",
+			d.Source, d.Kind, name, d.Role.String(), strings.Join(subjects, ", "), definedInSuffix(d.DefinedIn...))
+	}
+}
+
+func printHumanBindings(opts Options, changes []model.BindingChange) {
+	changes = filterBindingDrift(changes, opts)
+	if len(changes) == 0 {
+		fmt.Println(" No role binding membership drift detected matching the current filters.")
+		return
+	}
+
+	fmt.Printf(" Role binding membership drift (%d roles):\n", len(changes))
+	for _, ch := range changes {
+		fmt.Printf("\nRole: %s\n", ch.Role.String())
+		for _, subj := range ch.Added {
+			fmt.Printf("  + %s\n", subj.String())
+		}
+		for _, subj := range ch.Removed {
+			fmt.Printf("  - %s\n", subj.String())
+		}
+	}
+}
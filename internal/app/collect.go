@@ -0,0 +1,169 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Hru-s/driftwatch/internal/collectors"
+	"github.com/Hru-s/driftwatch/internal/model"
+
+	"golang.org/x/sync/errgroup"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// clusterSnapshots holds the RBAC, NetworkPolicy and PSA state collected
+// from one cluster.
+type clusterSnapshots struct {
+	rbac   *model.RBACSnapshot
+	netpol *model.NetPolSnapshot
+	psa    []model.NamespacePSA
+}
+
+// collectCluster starts the RBAC, NetworkPolicy and PSA collectors for one
+// cluster on g, so their round trips to the API server overlap. label names
+// the cluster in progress lines and errors. snap is filled in once g.Wait
+// returns nil; a section cut short by -max-runtime is left empty and marked
+// incomplete on partial.
+func collectCluster(ctx context.Context, g *errgroup.Group, client kubernetes.Interface, cfg collectors.Config, label string, prog *progress, partial *partialRun, snap *clusterSnapshots) {
+	g.Go(func() error {
+		done := prog.step("collecting RBAC from " + label)
+		rbac, err := collectors.CollectRBACFromCluster(ctx, client, cfg)
+		if partial.tolerate(sectionRBAC, err) {
+			rbac = &model.RBACSnapshot{}
+		} else if err != nil {
+			return fmt.Errorf("collecting RBAC from %s: %w", label, err)
+		}
+		done(len(rbac.Subjects), "subjects")
+		snap.rbac = rbac
+		return nil
+	})
+	g.Go(func() error {
+		done := prog.step("collecting NetworkPolicies from " + label)
+		netpol, err := collectors.CollectNetPolFromCluster(ctx, client, cfg)
+		if partial.tolerate(sectionNetPol, err) {
+			netpol = &model.NetPolSnapshot{}
+		} else if err != nil {
+			return fmt.Errorf("collecting NetworkPolicies from %s: %w", label, err)
+		}
+		done(len(netpol.Items), "NetworkPolicies")
+		snap.netpol = netpol
+		return nil
+	})
+	g.Go(func() error {
+		done := prog.step("collecting namespaces (PSA) from " + label)
+		psa, err := collectors.CollectPSAFromCluster(ctx, client, cfg)
+		if err != nil && !partial.tolerate(sectionPSA, err) {
+			return fmt.Errorf("collecting PSA from %s: %w", label, err)
+		}
+		done(len(psa), "namespaces")
+		snap.psa = psa
+		return nil
+	})
+}
+
+// scopeNamespaces lists the namespaces of the cluster behind client once
+// and returns cfg scoped to those -namespace-selector matches and -shard
+// owns, with the matching scope for the baseline. Without either option
+// cfg is returned unchanged and the scope is nil.
+func scopeNamespaces(ctx context.Context, client kubernetes.Interface, cfg collectors.Config, opts Options, label string) (collectors.Config, namespaceScope, error) {
+	if opts.NamespaceSelector == "" && opts.Shard == "" {
+		return cfg, nil, nil
+	}
+	namespaces, err := collectors.SelectNamespaces(ctx, client, opts.NamespaceSelector)
+	if err != nil {
+		return cfg, nil, fmt.Errorf("scoping %s: %w", label, err)
+	}
+	var scope namespaceScope
+	if opts.Shard != "" {
+		shard, err := parseShard(opts.Shard)
+		if err != nil {
+			return cfg, nil, fmt.Errorf("-shard: %w", err)
+		}
+		listed := len(namespaces)
+		namespaces = keepIf(namespaces, func(ns corev1.Namespace) bool { return shard.owns(ns.Name) })
+		if namespaces == nil {
+			namespaces = []corev1.Namespace{}
+		}
+		logger.Info("scoped to shard", "cluster", label, "shard", opts.Shard, "namespaces", len(namespaces), "listed", listed)
+		scope = shard.owns
+	}
+	if opts.NamespaceSelector != "" {
+		// baseline namespaces the selector did not match in this cluster
+		// are out of scope, whichever shard they hash to
+		selected := make(map[string]bool, len(namespaces))
+		for _, ns := range namespaces {
+			selected[ns.Name] = true
+		}
+		scope = func(ns string) bool { return selected[ns] }
+	}
+	cfg.Namespaces = namespaces
+	return cfg, scope, nil
+}
+
+// namespaceScope reports whether a namespace is covered by a scoped
+// collector config; nil means every namespace is.
+type namespaceScope func(ns string) bool
+
+// has reports whether objects in ns are in scope. Cluster-scoped ("") and
+// all-namespace ("*") permissions always are; applyShard keeps them on
+// shard 0 only.
+func (s namespaceScope) has(ns string) bool {
+	return s == nil || ns == "" || ns == "*" || s(ns)
+}
+
+// scopeItems keeps the baseline items whose namespace is in scope, so
+// objects outside the selected namespaces are not reported as missing.
+func scopeItems[T any](s namespaceScope, items []T, namespace func(T) string) []T {
+	if s == nil {
+		return items
+	}
+	kept := items[:0]
+	for _, item := range items {
+		if s.has(namespace(item)) {
+			kept = append(kept, item)
+		}
+	}
+	return kept
+}
+
+// scopeBaseline drops baseline RBAC, NetworkPolicies and Namespaces outside
+// scope, so they are not reported as missing. Cluster-wide permissions are
+// kept.
+func scopeBaseline(scope namespaceScope, rbac *model.RBACSnapshot, netpol *model.NetPolSnapshot, psa *[]model.NamespacePSA) {
+	if scope == nil {
+		return
+	}
+	inScope := scope.has
+
+	for subj, perms := range rbac.Subjects {
+		for p := range perms {
+			if !inScope(p.ScopeNamespace) {
+				delete(perms, p)
+				delete(rbac.DefinedIn[subj], p)
+			}
+		}
+		if len(perms) == 0 {
+			delete(rbac.Subjects, subj)
+			delete(rbac.DefinedIn, subj)
+		}
+	}
+	for ref := range rbac.Bindings {
+		if !inScope(ref.Namespace) {
+			delete(rbac.Bindings, ref)
+		}
+	}
+	dangling := rbac.Dangling[:0]
+	for _, d := range rbac.Dangling {
+		if inScope(d.Namespace) {
+			dangling = append(dangling, d)
+		}
+	}
+	rbac.Dangling = dangling
+	for key, d := range netpol.Items {
+		if !inScope(d.Namespace) {
+			delete(netpol.Items, key)
+		}
+	}
+	*psa = scopeItems(scope, *psa, func(ns model.NamespacePSA) string { return ns.Namespace })
+}
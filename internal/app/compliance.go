@@ -0,0 +1,107 @@
+package app
+
+import (
+	"sort"
+
+	"github.com/Hru-s/driftwatch/internal/model"
+)
+
+// CIS Kubernetes Benchmark (v1.8) controls that driftwatch findings map to.
+const (
+	cisClusterAdmin  = "CIS 5.1.1" // cluster-admin role is only used where required
+	cisSecretsAccess = "CIS 5.1.2" // minimize access to secrets
+	cisWildcards     = "CIS 5.1.3" // minimize wildcard use in Roles and ClusterRoles
+	cisPodCreate     = "CIS 5.1.4" // minimize access to create pods
+	cisDefaultSA     = "CIS 5.1.5" // default service accounts are not actively used
+	cisEscalation    = "CIS 5.1.8" // limit use of bind, impersonate and escalate
+	cisPolicyControl = "CIS 5.2.1" // at least one active policy control mechanism
+	cisPrivileged    = "CIS 5.2.2" // minimize admission of privileged containers
+	cisNetworkPolicy = "CIS 5.3.2" // all namespaces have NetworkPolicies defined
+)
+
+// complianceRefs is the curated lookup from finding type to CIS controls.
+var complianceRefs = map[string][]string{
+	"rbac-cluster-admin":  {cisClusterAdmin, cisWildcards},
+	"rbac-wildcard":       {cisWildcards},
+	"rbac-secrets-access": {cisSecretsAccess},
+	"rbac-pod-create":     {cisPodCreate},
+	"rbac-escalation":     {cisEscalation},
+	"rbac-default-sa":     {cisDefaultSA},
+	"netpol-missing":      {cisNetworkPolicy},
+	"psa-weaker":          {cisPolicyControl},
+	"psa-privileged":      {cisPolicyControl, cisPrivileged},
+	"role-audit-wildcard": {cisWildcards},
+	"role-audit-secrets":  {cisSecretsAccess, cisWildcards},
+}
+
+// refsFor merges the CIS references for the given finding types.
+func refsFor(findingTypes ...string) []string {
+	seen := map[string]struct{}{}
+	var out []string
+	for _, ft := range findingTypes {
+		for _, ref := range complianceRefs[ft] {
+			if _, ok := seen[ref]; ok {
+				continue
+			}
+			seen[ref] = struct{}{}
+			out = append(out, ref)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// rbacFindingTypes classifies a subject's extra permissions into finding types.
+func rbacFindingTypes(subj model.SubjectKey, perms []model.Permission) []string {
+	var types []string
+	if subj.Kind == "ServiceAccount" && subj.Name == "default" {
+		types = append(types, "rbac-default-sa")
+	}
+	for _, p := range perms {
+		clusterWide := p.ScopeNamespace == "*" || p.ScopeNamespace == ""
+		switch {
+		case p.Verb == "*" && p.Resource == "*" && clusterWide:
+			types = append(types, "rbac-cluster-admin")
+		case p.Verb == "*" || p.Resource == "*" || p.APIGroup == "*":
+			types = append(types, "rbac-wildcard")
+		}
+		switch {
+		case p.Resource == "secrets":
+			types = append(types, "rbac-secrets-access")
+		case p.Resource == "pods" && p.Verb == "create":
+			types = append(types, "rbac-pod-create")
+		}
+		switch p.Verb {
+		case "bind", "escalate", "impersonate":
+			types = append(types, "rbac-escalation")
+		}
+	}
+	return types
+}
+
+// annotateCompliance fills ComplianceRefs on the findings of a filtered report.
+func annotateCompliance(r *Report) {
+	for i := range r.RBAC.Extra {
+		sp := &r.RBAC.Extra[i]
+		sp.ComplianceRefs = refsFor(rbacFindingTypes(sp.Subject, sp.grants())...)
+	}
+	for i := range r.NetworkPolicy.Missing {
+		r.NetworkPolicy.Missing[i].ComplianceRefs = refsFor("netpol-missing")
+	}
+	for i := range r.PSA.Extra {
+		e := &r.PSA.Extra[i]
+		if e.Live == model.PSALevelPrivileged || e.Exempt {
+			e.ComplianceRefs = refsFor("psa-privileged")
+		} else if e.DriftType == "weaker" {
+			e.ComplianceRefs = refsFor("psa-weaker")
+		}
+	}
+	for i := range r.RoleAudit {
+		f := &r.RoleAudit[i]
+		if f.Resource == "secrets" {
+			f.ComplianceRefs = refsFor("role-audit-secrets")
+		} else {
+			f.ComplianceRefs = refsFor("role-audit-wildcard")
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Hru-s/driftwatch/internal/diff"
+)
+
+// Report sections that -max-runtime can leave incomplete, named after their
+// JSON keys.
+const (
+	sectionRBAC        = "rbac"
+	sectionNetPol      = "networkPolicy"
+	sectionPSA         = "psa"
+	sectionStorage     = "storageClasses"
+	sectionQuotas      = "resourceQuotas"
+	sectionLimitRanges = "limitRanges"
+	sectionWebhooks    = "admissionWebhooks"
+	sectionSAs         = "serviceAccounts"
+	sectionImages      = "imageViolations"
+	sectionLastApplied = "lastApplied"
+)
+
+// IncompleteRunError is returned by Run after rendering a partial report
+// because -max-runtime expired before every section was collected.
+type IncompleteRunError struct {
+	MaxRuntime time.Duration
+	Sections   []string
+}
+
+func (e *IncompleteRunError) Error() string {
+	return fmt.Sprintf("-max-runtime %s exceeded; report is partial (incomplete: %s)",
+		e.MaxRuntime, strings.Join(e.Sections, ", "))
+}
+
+// errMaxRuntime is the cause of a context cancelled by the -max-runtime
+// deadline, telling it apart from the per-analysis -timeout.
+var errMaxRuntime = errors.New("-max-runtime exceeded")
+
+// runContext returns the context for one analysis: parent bounded by the
+// -timeout, cut shorter by the -max-runtime deadline if there is one.
+func runContext(parent context.Context, opts Options) (context.Context, context.CancelFunc) {
+	ctx, cancel := withTimeout(parent, opts.Timeout)
+	if opts.deadline.IsZero() {
+		return ctx, cancel
+	}
+	ctx, cancelDeadline := context.WithDeadlineCause(ctx, opts.deadline, errMaxRuntime)
+	return ctx, func() {
+		cancelDeadline()
+		cancel()
+	}
+}
+
+// withTimeout bounds parent by d; a d of 0 (or less) leaves it unbounded.
+func withTimeout(parent context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	if d <= 0 {
+		return context.WithCancel(parent)
+	}
+	return context.WithTimeout(parent, d)
+}
+
+// partialRun records sections whose collection was cut off by the
+// -max-runtime deadline so the run can still render what it has.
+type partialRun struct {
+	ctx  context.Context
+	opts Options
+
+	mu       sync.Mutex // collectors run concurrently
+	sections []string
+}
+
+// tolerate reports whether a collection error for section should be
+// swallowed because the -max-runtime deadline expired; the section is then
+// marked incomplete. Any other error, including an expired -timeout, still
+// fails the run.
+func (p *partialRun) tolerate(section string, err error) bool {
+	if err == nil || p.opts.MaxRuntime <= 0 || !errors.Is(context.Cause(p.ctx), errMaxRuntime) {
+		return false
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !slices.Contains(p.sections, section) {
+		p.sections = append(p.sections, section)
+	}
+	return true
+}
+
+// apply drops the findings of incomplete sections, which were computed
+// against empty live data and would otherwise show up as false drift.
+func (p *partialRun) apply(res *driftResults) {
+	for _, section := range p.sections {
+		switch section {
+		case sectionRBAC:
+			res.RBAC = diff.RBACDrift{}
+			res.Workload = nil
+			res.Bindings = nil
+			res.RoleSubjects = nil
+			res.Dangling = nil
+			res.RoleAudit = nil
+			res.Rego = nil
+		case sectionNetPol:
+			res.NetPol = diff.NetPolDrift{}
+			res.NetAudit = nil
+			res.SelAudit = nil
+			res.Openness = nil
+			res.DefaultDeny = nil
+			res.Labels = nil
+			res.Rego = nil
+		case sectionPSA:
+			res.PSA = diff.PSADrift{}
+			res.SelAudit = nil
+			res.DefaultDeny = nil
+			res.Labels = nil
+			res.Rego = nil
+		case sectionStorage:
+			res.Storage = diff.StorageClassDrift{}
+		case sectionQuotas:
+			res.Quotas = diff.ResourceQuotaDrift{}
+		case sectionLimitRanges:
+			res.LimitRanges = diff.LimitRangeDrift{}
+		case sectionWebhooks:
+			res.Webhooks = diff.WebhookDrift{}
+		case sectionSAs:
+			res.ServiceAccounts = diff.ServiceAccountDrift{}
+		case sectionImages:
+			res.Images = nil
+		case sectionLastApplied:
+			res.LastApplied = nil
+		}
+	}
+	res.Incomplete = p.sections
+}
+
+// isIncomplete reports whether section was cut short by -max-runtime.
+func (r driftResults) isIncomplete(section string) bool {
+	return slices.Contains(r.Incomplete, section)
+}
@@ -0,0 +1,36 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestPartialRunToleratesOnlyMaxRuntime(t *testing.T) {
+	tests := []struct {
+		name     string
+		timeout  time.Duration
+		deadline time.Duration
+		want     bool
+	}{
+		{"timeout before max-runtime", time.Millisecond, time.Hour, false},
+		{"max-runtime before timeout", time.Hour, time.Millisecond, true},
+		{"max-runtime without timeout", 0, time.Millisecond, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := Options{Timeout: tt.timeout, MaxRuntime: tt.deadline, deadline: time.Now().Add(tt.deadline)}
+			ctx, cancel := runContext(context.Background(), opts)
+			defer cancel()
+			<-ctx.Done()
+
+			p := &partialRun{ctx: ctx, opts: opts}
+			if got := p.tolerate(sectionRBAC, ctx.Err()); got != tt.want {
+				t.Errorf("tolerate = %v, want %v", got, tt.want)
+			}
+			if got := len(p.sections) > 0; got != tt.want {
+				t.Errorf("section marked incomplete = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
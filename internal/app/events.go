@@ -0,0 +1,141 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Hru-s/driftwatch/internal/kube"
+	"github.com/Hru-s/driftwatch/internal/model"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	eventSource = "driftwatch"
+	// maxFindingEvents caps the per-finding Events of one run so a large
+	// drift does not flood the events store; the summary Event has the total.
+	maxFindingEvents = 50
+	// maxEventMessage keeps messages under the API server's 1 KiB limit.
+	maxEventMessage = 1000
+)
+
+// publishEvents records the run's findings as Events in the live cluster
+// (cluster B in cluster-compare mode): one summary Event plus, when
+// findingEvents is set, one Event per finding, all attached to the
+// -events-namespace Namespace.
+func publishEvents(modeLabel string, opts Options, res driftResults, findingEvents bool) error {
+	ctx, cancel := withTimeout(context.Background(), opts.Timeout)
+	defer cancel()
+
+	gateOpts := normalizeOptions(opts)
+	gateOpts.MaxPermsPerSubject = 0
+	highest := highestSeverity(buildJSONReport(modeLabel, gateOpts, res))
+	keys := findingKeys(historyFindings(modeLabel, opts, res))
+	return emitEvents(ctx, res.events, eventsNamespace(opts), keys, highest, res.Incomplete, findingEvents)
+}
+
+// eventsClient returns the client -emit-events writes with: the live
+// collection client, unless collection impersonates (-as), whose identity
+// is meant to read and usually may not create Events. Then a client with
+// the same settings acting as the caller itself is built, once per run.
+// Without -emit-events it returns nil.
+func eventsClient(live kubernetes.Interface, kubeconfig, kubeContext string, opts Options) (kubernetes.Interface, error) {
+	if !opts.EmitEvents {
+		return nil, nil
+	}
+	cfg := clientConfig(opts)
+	if cfg.Impersonate.UserName == "" {
+		return live, nil
+	}
+	cfg.Impersonate = rest.ImpersonationConfig{}
+	client, err := kube.BuildClient(kubeconfig, kubeContext, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("creating client for events: %w", err)
+	}
+	return client, nil
+}
+
+func eventsNamespace(opts Options) string {
+	if opts.EventsNamespace == "" {
+		return metav1.NamespaceDefault
+	}
+	return opts.EventsNamespace
+}
+
+// emitEvents creates the summary Event and, with findingEvents, the
+// per-finding Events. A partial run never reports "no drift": the
+// uncollected sections are named.
+func emitEvents(ctx context.Context, client kubernetes.Interface, namespace string, findings []string, highest model.Severity, incomplete []string, findingEvents bool) error {
+	var partial string
+	if len(incomplete) > 0 {
+		partial = "; report is partial, not collected: " + strings.Join(incomplete, ", ")
+	}
+
+	if len(findings) == 0 {
+		if partial != "" {
+			return createEvent(ctx, client, namespace, corev1.EventTypeWarning, "DriftCheckIncomplete",
+				"driftwatch found no drift in the collected sections"+partial)
+		}
+		return createEvent(ctx, client, namespace, corev1.EventTypeNormal, "NoDriftDetected",
+			"driftwatch found no drift matching the current filters")
+	}
+
+	summary := fmt.Sprintf("driftwatch found %d findings (highest severity: %s)", len(findings), highest)
+	switch {
+	case !findingEvents:
+		summary += ", unchanged since they were recorded as individual events"
+	case len(findings) > maxFindingEvents:
+		summary += fmt.Sprintf("; only the first %d are recorded as individual events", maxFindingEvents)
+	}
+	if err := createEvent(ctx, client, namespace, corev1.EventTypeWarning, "DriftDetected", summary+partial); err != nil {
+		return err
+	}
+	if !findingEvents {
+		return nil
+	}
+
+	for i, f := range findings {
+		if i == maxFindingEvents {
+			break
+		}
+		if err := createEvent(ctx, client, namespace, corev1.EventTypeWarning, "DriftFinding", f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func createEvent(ctx context.Context, client kubernetes.Interface, namespace, eventType, reason, message string) error {
+	if len(message) > maxEventMessage {
+		message = message[:maxEventMessage-3] + "..."
+	}
+	now := metav1.NewTime(time.Now())
+	ev := &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: eventSource + "-",
+			Namespace:    namespace,
+		},
+		InvolvedObject: corev1.ObjectReference{
+			APIVersion: "v1",
+			Kind:       "Namespace",
+			Name:       namespace,
+		},
+		Reason:              reason,
+		Message:             message,
+		Type:                eventType,
+		Source:              corev1.EventSource{Component: eventSource},
+		ReportingController: eventSource,
+		FirstTimestamp:      now,
+		LastTimestamp:       now,
+		Count:               1,
+	}
+	if _, err := client.CoreV1().Events(namespace).Create(ctx, ev, metav1.CreateOptions{}); err != nil {
+		return fmt.Errorf("creating %s event in %s: %w", reason, namespace, err)
+	}
+	return nil
+}
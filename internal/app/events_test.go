@@ -0,0 +1,35 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Hru-s/driftwatch/internal/model"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestEmitEventsFindingEvents(t *testing.T) {
+	findings := []string{"rbac extra User/alice get pods", "netpol missing web/deny-all"}
+	for _, tt := range []struct {
+		findingEvents bool
+		want          int
+	}{
+		{true, 1 + len(findings)},
+		{false, 1},
+	} {
+		client := fake.NewSimpleClientset()
+		// the fake tracker ignores GenerateName; only count the creates
+		client.PrependReactor("create", "events", func(k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, nil
+		})
+		if err := emitEvents(context.Background(), client, "default", findings, model.SeverityHigh, nil, tt.findingEvents); err != nil {
+			t.Fatal(err)
+		}
+		if got := len(client.Actions()); got != tt.want {
+			t.Errorf("findingEvents=%v: created %d events, want %d", tt.findingEvents, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,351 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Hru-s/driftwatch/internal/model"
+)
+
+// historyTimeFormat names report files so that lexical order is run order.
+const historyTimeFormat = "20060102T150405Z"
+
+// reportDelta compares the findings of this run with the last report
+// committed to the -report-history git repository, or with the previous
+// run recorded in -baseline-state.
+type reportDelta struct {
+	Against    string   `json:"against"` // report file the run was compared with
+	New        []string `json:"new"`
+	Resolved   []string `json:"resolved"`
+	Persisting []string `json:"persisting"`
+
+	since string // what Against is, for text output; "" is a committed report
+}
+
+// historyFindings is the findings view that is stored and diffed: full
+// permission lists, independent of -max-perms-per-subject.
+func historyFindings(modeLabel string, opts Options, res driftResults) driftFindingsJSON {
+	opts = normalizeOptions(opts)
+	opts.MaxPermsPerSubject = 0
+	return buildJSONReport(modeLabel, opts, res).findings()
+}
+
+// writeReportHistory stores findings as <dir>/<UTC timestamp>.json with
+// stable indentation so successive runs diff cleanly in git.
+func writeReportHistory(dir string, findings driftFindingsJSON, now time.Time) (string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := writeJSON(&buf, findings); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, now.UTC().Format(historyTimeFormat)+".json")
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// lastCommittedReport returns the newest report file in dir as committed
+// at HEAD of the enclosing git repository. Uncommitted runs are ignored so
+// the delta is always against something reviewable. name is "" when no
+// report has been committed yet.
+func lastCommittedReport(dir string) (string, driftFindingsJSON, error) {
+	var findings driftFindingsJSON
+
+	if _, err := gitOutput(dir, "rev-parse", "--is-inside-work-tree"); err != nil {
+		return "", findings, fmt.Errorf("%s is not inside a git work tree: %w", dir, err)
+	}
+	if _, err := gitOutput(dir, "rev-parse", "--verify", "-q", "HEAD"); err != nil {
+		// nothing committed yet
+		return "", findings, nil
+	}
+	out, err := gitOutput(dir, "ls-tree", "--name-only", "HEAD", "./")
+	if err != nil {
+		return "", findings, err
+	}
+
+	var names []string
+	for _, line := range strings.Split(string(out), "\n") {
+		name := filepath.Base(strings.TrimSpace(line))
+		if strings.HasSuffix(name, ".json") {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return "", findings, nil
+	}
+	sort.Strings(names)
+	name := names[len(names)-1]
+
+	data, err := gitOutput(dir, "show", "HEAD:./"+name)
+	if err != nil {
+		return "", findings, err
+	}
+	if err := json.Unmarshal(data, &findings); err != nil {
+		return "", findings, fmt.Errorf("parsing committed report %s: %w", name, err)
+	}
+	return name, findings, nil
+}
+
+func gitOutput(dir string, args ...string) ([]byte, error) {
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git %s: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
+
+// diffReports classifies every finding of cur as new or persisting and
+// every finding only in prev as resolved.
+func diffReports(against string, prev, cur driftFindingsJSON) *reportDelta {
+	before := make(map[string]bool)
+	for _, k := range findingKeys(prev) {
+		before[k] = true
+	}
+
+	d := &reportDelta{Against: against, New: []string{}, Resolved: []string{}, Persisting: []string{}}
+	for _, k := range findingKeys(cur) {
+		if before[k] {
+			d.Persisting = append(d.Persisting, k)
+			delete(before, k)
+		} else {
+			d.New = append(d.New, k)
+		}
+	}
+	for k := range before {
+		d.Resolved = append(d.Resolved, k)
+	}
+	sort.Strings(d.Resolved)
+	return d
+}
+
+// findingKeys flattens a report into one stable, human-readable line per
+// finding. RBAC findings are keyed per permission so that a subject gaining
+// one verb shows up as one new finding, not as a changed subject.
+func findingKeys(f driftFindingsJSON) []string {
+	seen := make(map[string]bool)
+	var keys []string
+	eachFindingKey(f, func(_, k string) {
+		if !seen[k] {
+			seen[k] = true
+			keys = append(keys, k)
+		}
+	})
+	sort.Strings(keys)
+	return keys
+}
+
+// eachFindingKey calls emit with the finding line of every finding of f, in
+// report order, together with its category (the report's JSON key, e.g.
+// "networkPolicy").
+func eachFindingKey(f driftFindingsJSON, emit func(category, key string)) {
+	add := func(category, format string, args ...any) {
+		emit(category, fmt.Sprintf(format, args...))
+	}
+
+	for _, sp := range f.RBAC.Extra {
+		for _, p := range sp.Permissions {
+			add("rbac", "RBAC extra: %s %s", sp.Subject, p)
+		}
+	}
+	for _, sp := range f.RBAC.Missing {
+		for _, p := range sp.Permissions {
+			add("rbac", "RBAC missing: %s %s", sp.Subject, p)
+		}
+	}
+
+	for _, r := range f.RBAC.Renamed {
+		add("rbac", "RBAC renamed: %s -> %s", r.From, r.To)
+	}
+	for _, c := range f.RBAC.ResourceNames {
+		for _, name := range c.Added {
+			add("rbac", "RBAC resourceName added: %s %s %s", c.Subject, grantString(c.Grant()), name)
+		}
+		for _, name := range c.Removed {
+			add("rbac", "RBAC resourceName removed: %s %s %s", c.Subject, grantString(c.Grant()), name)
+		}
+	}
+
+	for _, r := range f.NetworkPolicy.Missing {
+		add("networkPolicy", "NetworkPolicy missing: %s", r)
+	}
+	for _, r := range f.NetworkPolicy.Extra {
+		add("networkPolicy", "NetworkPolicy extra: %s", r)
+	}
+	for _, c := range f.NetworkPolicy.Changed {
+		add("networkPolicy", "NetworkPolicy changed: %s/%s", c.Namespace, c.Name)
+	}
+
+	psa := func(e model.PSADriftEntry) {
+		add("psa", "PSA %s: ns=%s baseline=%s live=%s", e.DriftType, e.Namespace, e.Baseline, e.Live)
+	}
+	for _, e := range f.PSA.Extra {
+		psa(e)
+	}
+	for _, e := range f.PSA.Missing {
+		psa(e)
+	}
+	for _, e := range f.PSA.Incomparable {
+		psa(e)
+	}
+	for _, m := range f.PSA.ManagedBy {
+		add("psa", "PSA managed-by %s: ns=%s annotation=%s expected=%s live=%s", m.Reason, m.Namespace, m.Annotation, m.Expected, m.Live)
+	}
+
+	for _, r := range f.RoleAudit {
+		add("roleAudit", "Role audit: %s ClusterRole %s verbs=%v resource=%s/%s", r.Source, r.Role, r.Verbs, r.APIGroup, r.Resource)
+	}
+	for _, n := range f.NetPolAudit {
+		add("netpolAudit", "NetworkPolicy audit: %s %s/%s %s %s", n.Source, n.Namespace, n.Name, n.Direction, n.Detail)
+	}
+	for _, n := range f.SelectorAudit {
+		add("netpolSelectorAudit", "NetworkPolicy advisory: %s %s/%s %s %s", n.Source, n.Namespace, n.Name, n.Direction, n.Detail)
+	}
+	for _, c := range f.Openness {
+		if c.DriftType == "new" {
+			add("netpolOpenness", "NetworkPolicy openness: new %s/%s %s %s", c.Namespace, c.Name, c.Direction, c.Live)
+			continue
+		}
+		add("netpolOpenness", "NetworkPolicy openness: %s %s/%s %s %s -> %s", c.DriftType, c.Namespace, c.Name, c.Direction, c.Baseline, c.Live)
+	}
+	for _, g := range f.DefaultDeny {
+		add("defaultDeny", "NetworkPolicy default-deny %s: ns=%s %s", g.DriftType, g.Namespace, g.Direction)
+	}
+	for _, v := range f.Rego {
+		add("regoViolations", "Rego violation: %s ns=%s name=%s %s", v.Source, v.Namespace, v.Name, v.Message)
+	}
+	for _, c := range f.LabelDrift {
+		add("labelDrift", "Label drift: %s %s/%s %s baseline=%q live=%q", c.Kind, c.Namespace, c.Name, c.Key, c.Baseline, c.Live)
+	}
+	for _, d := range f.DanglingBindings {
+		add("danglingBindings", "Dangling binding: %s %s %s/%s -> %s", d.Source, d.Kind, d.Namespace, d.Name, d.Role)
+	}
+	for _, c := range f.Bindings {
+		for _, s := range c.Added {
+			add("bindings", "Binding added: %s -> %s", c.Role, s)
+		}
+		for _, s := range c.Removed {
+			add("bindings", "Binding removed: %s -> %s", c.Role, s)
+		}
+	}
+
+	for _, v := range f.RoleSubjects {
+		for _, g := range v.Added {
+			add("clusterRoleSubjects", "ClusterRole %s granted: %s", v.Role, g)
+		}
+		for _, g := range v.Removed {
+			add("clusterRoleSubjects", "ClusterRole %s revoked: %s", v.Role, g)
+		}
+	}
+
+	if sc := f.StorageClasses; sc != nil {
+		for _, d := range sc.Missing {
+			add("storageClasses", "StorageClass missing: %s", d.Name)
+		}
+		for _, d := range sc.Extra {
+			add("storageClasses", "StorageClass extra: %s", d.Name)
+		}
+		for _, c := range sc.Changed {
+			add("storageClasses", "StorageClass changed: %s", c.Name)
+		}
+	}
+
+	if rq := f.ResourceQuotas; rq != nil {
+		for _, d := range rq.Missing {
+			add("resourceQuotas", "ResourceQuota missing: %s", d)
+		}
+		for _, d := range rq.Extra {
+			add("resourceQuotas", "ResourceQuota extra: %s", d)
+		}
+		for _, c := range rq.Changed {
+			add("resourceQuotas", "ResourceQuota changed: %s/%s", c.Namespace, c.Name)
+		}
+	}
+
+	if lr := f.LimitRanges; lr != nil {
+		for _, d := range lr.Missing {
+			add("limitRanges", "LimitRange missing: %s", d)
+		}
+		for _, d := range lr.Extra {
+			add("limitRanges", "LimitRange extra: %s", d)
+		}
+		for _, c := range lr.Changed {
+			add("limitRanges", "LimitRange changed: %s/%s", c.Namespace, c.Name)
+		}
+	}
+
+	for _, d := range f.AdmissionWebhooks {
+		for _, w := range d.Missing {
+			add("admissionWebhooks", "Admission webhook missing: %s %s/%s", d.Kind, d.Name, w.Name)
+		}
+		for _, w := range d.Extra {
+			add("admissionWebhooks", "Admission webhook extra: %s %s/%s", d.Kind, d.Name, w.Name)
+		}
+		for _, c := range d.Changed {
+			add("admissionWebhooks", "Admission webhook changed: %s %s/%s", d.Kind, d.Name, c.Webhook)
+		}
+	}
+
+	if sa := f.ServiceAccounts; sa != nil {
+		for _, e := range sa.Extra {
+			add("serviceAccounts", "ServiceAccount %s: %s", e.DriftType, e)
+		}
+		for _, e := range sa.Missing {
+			add("serviceAccounts", "ServiceAccount %s: %s", e.DriftType, e)
+		}
+	}
+
+	for _, v := range f.Images {
+		add("imageViolations", "Image from disallowed registry: ns=%s %s", v.Namespace, v.Image)
+	}
+	for _, d := range f.LastApplied {
+		add("lastApplied", "Last-applied drift: %s %s fields=%s", d.Source, d, strings.Join(d.Fields, ","))
+	}
+
+	if w := f.Workload; w != nil {
+		for _, p := range w.Extra {
+			add("workload", "Workload %s extra: %s", w.Workload, p)
+		}
+		for _, p := range w.Missing {
+			add("workload", "Workload %s missing: %s", w.Workload, p)
+		}
+	}
+}
+
+func printHumanReportDelta(d *reportDelta) {
+	if d.Against == "" {
+		fmt.Println(" Report history: no committed report to compare against yet.")
+		return
+	}
+
+	since := d.since
+	if since == "" {
+		since = "committed report"
+	}
+	fmt.Printf(" Changes since %s %s: %d new, %d resolved, %d persisting\n",
+		since, d.Against, len(d.New), len(d.Resolved), len(d.Persisting))
+	section := func(title, marker string, keys []string) {
+		if len(keys) == 0 {
+			return
+		}
+		fmt.Printf("\n %s (%d):\n", title, len(keys))
+		for _, k := range keys {
+			fmt.Printf("  %s %s\n", marker, k)
+		}
+	}
+	section("New findings", "+", d.New)
+	section("Resolved findings", "-", d.Resolved)
+	section("Persisting findings", "=", d.Persisting)
+}
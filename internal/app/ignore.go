@@ -0,0 +1,148 @@
+package app
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/Hru-s/driftwatch/internal/model"
+
+	"sigs.k8s.io/yaml"
+)
+
+// ignoreList is the accepted drift read from -ignore-file, e.g.
+//
+//	rbac:
+//	- subject: {kind: Group, name: break-glass}
+//	- subject: {kind: ServiceAccount, name: ci, namespace: build}
+//	  permissions:
+//	  - {verb: create, resource: pods}
+//	networkPolicies:
+//	- {namespace: debug, name: allow-debug}
+//	psa:
+//	- namespace: legacy
+//
+// An rbac entry without permissions accepts all of the subject's drift;
+// fields left out of a permission match any value. A networkPolicies entry
+// without a name accepts every policy in the namespace. Matching findings
+// are removed after the other filters and counted in the report.
+type ignoreList struct {
+	RBAC            []ignoreRBAC   `json:"rbac"`
+	NetworkPolicies []ignoreNetPol `json:"networkPolicies"`
+	PSA             []ignorePSA    `json:"psa"`
+}
+
+type ignoreRBAC struct {
+	Subject     model.SubjectKey   `json:"subject"`
+	Permissions []model.Permission `json:"permissions"`
+}
+
+type ignoreNetPol struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+type ignorePSA struct {
+	Namespace string `json:"namespace"`
+}
+
+// loadIgnoreFile reads and checks an -ignore-file. Unknown fields are
+// errors, so a typo cannot silently accept nothing.
+func loadIgnoreFile(path string) (*ignoreList, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var l ignoreList
+	if err := yaml.UnmarshalStrict(data, &l); err != nil {
+		return nil, fmt.Errorf("decode %s: %w", path, err)
+	}
+	for i, e := range l.RBAC {
+		if e.Subject.Kind == "" || e.Subject.Name == "" {
+			return nil, fmt.Errorf("%s: rbac entry %d: subject needs a kind and a name", path, i+1)
+		}
+	}
+	for i, e := range l.NetworkPolicies {
+		if e.Namespace == "" {
+			return nil, fmt.Errorf("%s: networkPolicies entry %d: namespace is required", path, i+1)
+		}
+	}
+	for i, e := range l.PSA {
+		if e.Namespace == "" {
+			return nil, fmt.Errorf("%s: psa entry %d: namespace is required", path, i+1)
+		}
+	}
+	return &l, nil
+}
+
+// permission reports whether the drift of p for subj is accepted. A nil
+// list accepts nothing.
+func (l *ignoreList) permission(subj model.SubjectKey, p model.Permission) bool {
+	if l == nil {
+		return false
+	}
+	for _, e := range l.RBAC {
+		if e.Subject != subj {
+			continue
+		}
+		if len(e.Permissions) == 0 {
+			return true
+		}
+		for _, want := range e.Permissions {
+			if permissionMatches(want, p) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// permissionMatches compares the fields set in want.
+func permissionMatches(want, p model.Permission) bool {
+	field := func(want, got string) bool { return want == "" || want == got }
+	return field(want.ScopeNamespace, p.ScopeNamespace) &&
+		field(want.APIGroup, p.APIGroup) &&
+		field(want.Resource, p.Resource) &&
+		field(want.ResourceName, p.ResourceName) &&
+		field(want.Verb, p.Verb) &&
+		field(want.NonResourceURL, p.NonResourceURL)
+}
+
+// netPol reports whether the drift of the policy namespace/name is
+// accepted.
+func (l *ignoreList) netPol(namespace, name string) bool {
+	if l == nil {
+		return false
+	}
+	for _, e := range l.NetworkPolicies {
+		if e.Namespace == namespace && (e.Name == "" || e.Name == name) {
+			return true
+		}
+	}
+	return false
+}
+
+// psa reports whether the PSA drift of namespace is accepted.
+func (l *ignoreList) psa(namespace string) bool {
+	if l == nil {
+		return false
+	}
+	for _, e := range l.PSA {
+		if e.Namespace == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// ignoredCount returns how many findings -ignore-file removed from the
+// RBAC, NetworkPolicy and PSA sections of res.
+func ignoredCount(opts Options, res driftResults) int {
+	if opts.ignores == nil {
+		return 0
+	}
+	var tally filterTally
+	filterRBACDriftToSlices(res.RBAC, opts, &tally)
+	filterNetPolDriftToJSON(res.NetPol, opts, &tally)
+	psaDriftToJSON(res.PSA, opts, &tally)
+	return tally.byFilter["ignore-file"]
+}
@@ -0,0 +1,207 @@
+package app
+
+import (
+	"bufio"
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// streamDepth is how many levels of struct nesting are written field by
+// field; deeper values, and every array element, are encoded on their own.
+const streamDepth = 2
+
+// writeJSONStream writes v exactly as writeJSON would, but a section at a
+// time: the fields of the top streamDepth levels of structs and the
+// elements of their arrays are each encoded by a json.Encoder of their
+// own, so only one element is ever held encoded. A struct encoding/json
+// treats specially (a Marshaler, an embedded field, a ",string" field) is
+// encoded whole.
+func writeJSONStream(out io.Writer, v any) error {
+	s := &jsonStream{w: bufio.NewWriter(out)}
+	if err := s.value(reflect.ValueOf(v), "", 0); err != nil {
+		return err
+	}
+	s.w.WriteString("\n")
+	return s.w.Flush()
+}
+
+type jsonStream struct {
+	w   *bufio.Writer
+	buf bytes.Buffer
+}
+
+func (s *jsonStream) value(v reflect.Value, indent string, depth int) error {
+	if v.Kind() == reflect.Pointer && !v.IsNil() && depth < streamDepth && streamable(v.Type()) {
+		v = v.Elem()
+	}
+	switch {
+	case v.Kind() == reflect.Struct && depth < streamDepth && streamable(v.Type()):
+		return s.object(v, indent, depth)
+	case v.Kind() == reflect.Slice && v.Len() > 0 && streamable(v.Type()):
+		s.w.WriteString("[")
+		for i := 0; i < v.Len(); i++ {
+			if i > 0 {
+				s.w.WriteString(",")
+			}
+			s.w.WriteString("\n" + indent + "  ")
+			if err := s.encode(v.Index(i), indent+"  "); err != nil {
+				return err
+			}
+		}
+		s.w.WriteString("\n" + indent + "]")
+		return nil
+	default:
+		return s.encode(v, indent)
+	}
+}
+
+func (s *jsonStream) object(v reflect.Value, indent string, depth int) error {
+	t := v.Type()
+	wrote := false
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, omit, ok := jsonField(field)
+		if !ok {
+			continue
+		}
+		fv := v.Field(i)
+		if omit(fv) {
+			continue
+		}
+
+		if wrote {
+			s.w.WriteString(",")
+		} else {
+			s.w.WriteString("{")
+		}
+		wrote = true
+		s.w.WriteString("\n" + indent + "  ")
+		if err := s.encode(reflect.ValueOf(name), ""); err != nil {
+			return err
+		}
+		s.w.WriteString(": ")
+		if err := s.value(fv, indent+"  ", depth+1); err != nil {
+			return err
+		}
+	}
+	if !wrote {
+		s.w.WriteString("{}")
+		return nil
+	}
+	s.w.WriteString("\n" + indent + "}")
+	return nil
+}
+
+// encode writes v with a json.Encoder, as the part of an indented document
+// starting at indent.
+func (s *jsonStream) encode(v reflect.Value, indent string) error {
+	s.buf.Reset()
+	enc := json.NewEncoder(&s.buf)
+	enc.SetIndent(indent, "  ")
+	if err := enc.Encode(v.Interface()); err != nil {
+		return err
+	}
+	_, err := s.w.Write(bytes.TrimSuffix(s.buf.Bytes(), []byte("\n")))
+	return err
+}
+
+var (
+	jsonMarshaler = reflect.TypeFor[json.Marshaler]()
+	textMarshaler = reflect.TypeFor[encoding.TextMarshaler]()
+)
+
+// streamable reports whether values of t may be written piecewise: t does
+// not marshal itself and, for a struct, encoding/json maps each field to
+// one key, with no embedding and no option but omitempty and omitzero.
+// Byte slices are base64 strings, not arrays.
+func streamable(t reflect.Type) bool {
+	for _, m := range []reflect.Type{jsonMarshaler, textMarshaler} {
+		if t.Implements(m) || reflect.PointerTo(t).Implements(m) {
+			return false
+		}
+	}
+	switch t.Kind() {
+	case reflect.Pointer:
+		return streamable(t.Elem())
+	case reflect.Slice:
+		return t.Elem().Kind() != reflect.Uint8
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if f.Anonymous {
+				return false
+			}
+			if !f.IsExported() {
+				continue
+			}
+			_, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+			for _, o := range strings.Split(opts, ",") {
+				if o != "" && o != "omitempty" && o != "omitzero" {
+					return false
+				}
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// jsonField returns the key of f and whether its value is left out, the
+// way encoding/json reads the `json` tag; ok is false for "-".
+func jsonField(f reflect.StructField) (name string, omit func(reflect.Value) bool, ok bool) {
+	tag := f.Tag.Get("json")
+	if tag == "-" {
+		return "", nil, false
+	}
+	name, opts, _ := strings.Cut(tag, ",")
+	if name == "" {
+		name = f.Name
+	}
+	var omitEmpty, omitZero bool
+	for _, o := range strings.Split(opts, ",") {
+		omitEmpty = omitEmpty || o == "omitempty"
+		omitZero = omitZero || o == "omitzero"
+	}
+	omit = func(v reflect.Value) bool {
+		return omitEmpty && isEmptyJSONValue(v) || omitZero && isZeroJSONValue(v)
+	}
+	return name, omit, true
+}
+
+// isEmptyJSONValue matches encoding/json's definition of empty for omitempty.
+func isEmptyJSONValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Pointer:
+		return v.IsNil()
+	}
+	return false
+}
+
+// isZeroJSONValue matches encoding/json's definition of zero for omitzero:
+// an IsZero method decides when the type has one.
+func isZeroJSONValue(v reflect.Value) bool {
+	if z, ok := v.Interface().(interface{ IsZero() bool }); ok {
+		if v.Kind() == reflect.Pointer && v.IsNil() {
+			return true
+		}
+		return z.IsZero()
+	}
+	return v.IsZero()
+}
@@ -0,0 +1,114 @@
+package app
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/Hru-s/driftwatch/internal/diff"
+	"github.com/Hru-s/driftwatch/internal/model"
+)
+
+// writeJSONStream must write byte for byte what encoding/json writes.
+func TestWriteJSONStreamMatchesEncoder(t *testing.T) {
+	alice := model.SubjectKey{Kind: "User", Name: "alice <admin>"}
+	sa := model.SubjectKey{Kind: "ServiceAccount", Name: "ci", Namespace: "team-a"}
+	res := driftResults{
+		RBAC: diff.RBACDrift{
+			Extra: map[model.SubjectKey][]model.Permission{
+				alice: {
+					{ScopeNamespace: "*", Resource: "secrets", Verb: "get"},
+					{ScopeNamespace: "team-a", APIGroup: "apps", Resource: "deployments", ResourceName: "web", Verb: "patch"},
+				},
+			},
+			Missing: map[model.SubjectKey][]model.Permission{
+				sa: {{ScopeNamespace: "*", Verb: "get", NonResourceURL: "/metrics"}},
+			},
+		},
+		NetPol: diff.NetPolDrift{
+			Missing: []model.NetPolRef{{Namespace: "team-a", Name: "deny-all", DefinedIn: &model.SourceRef{File: "netpol.yaml", Document: 3}}},
+			Extra:   []model.NetPolRef{},
+		},
+		PSA: diff.PSADrift{
+			Extra:     []model.PSADriftEntry{{Namespace: "team-b", Live: "privileged", DriftType: "extra"}},
+			Unchanged: []string{"default"},
+		},
+		Quotas: diff.ResourceQuotaDrift{
+			Extra: []model.ResourceQuotaDigest{{Namespace: "team-a", Name: "compute", Hard: map[string]string{"pods": "20", "cpu": "2"}}},
+		},
+		Warnings:   []string{"a & b", "1 < 2"},
+		Incomplete: []string{},
+	}
+	opts := normalizeOptions(Options{Mode: "single", DriftType: "both", ResourceQuotas: true, Labels: map[string]string{"env": "prod"}})
+	report := buildJSONReport("baseline vs live", opts, res)
+
+	special := specialJSON{
+		Embedded: Embedded{Inner: "x"},
+		Count:    3,
+		At:       time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC),
+		Raw:      []byte("hi"),
+		Quoted:   7,
+		List:     []quotedInt{1, 2},
+		Nested:   &specialJSON{Count: 1},
+		Tags:     map[string]string{"b": "2", "a": "1"},
+		Dash:     "dash",
+	}
+	wrapped := struct {
+		Special  specialJSON   `json:"special"`
+		Specials []specialJSON `json:"specials"`
+		Ptr      *specialJSON  `json:"ptr,omitempty"`
+		Report   *Report       `json:"report"`
+		Any      any           `json:"any"`
+		Empty    struct{}      `json:"empty"`
+		Zero     Embedded      `json:"zero,omitzero"`
+	}{Special: special, Specials: []specialJSON{special, {}}, Report: &report, Any: []int{1}}
+
+	for name, v := range map[string]any{
+		"report":       report,
+		"findings":     report.findings(),
+		"empty report": Report{},
+		"nil pointer":  (*Report)(nil),
+		"special":      special,
+		"wrapped":      wrapped,
+	} {
+		t.Run(name, func(t *testing.T) {
+			var want, got bytes.Buffer
+			if err := writeJSON(&want, v); err != nil {
+				t.Fatal(err)
+			}
+			if err := writeJSONStream(&got, v); err != nil {
+				t.Fatal(err)
+			}
+			if got.String() != want.String() {
+				t.Errorf("streamed:\n%s\nencoding/json:\n%s", got.String(), want.String())
+			}
+		})
+	}
+}
+
+// specialJSON holds what encoding/json treats specially; writeJSONStream
+// encodes such values whole.
+type specialJSON struct {
+	Embedded
+	Count   int               `json:"count,string"`
+	At      time.Time         `json:"at"`
+	Zero    time.Time         `json:"zero,omitzero"`
+	Raw     []byte            `json:"raw"`
+	Quoted  quotedInt         `json:"quoted"`
+	List    []quotedInt       `json:"list"`
+	Nested  *specialJSON      `json:"nested,omitempty"`
+	Tags    map[string]string `json:"tags"`
+	Skipped string            `json:"-"`
+	Dash    string            `json:"-,"`
+}
+
+type Embedded struct {
+	Inner string `json:"inner"`
+}
+
+type quotedInt int
+
+func (q quotedInt) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(strconv.Itoa(int(q)))), nil
+}
@@ -0,0 +1,30 @@
+package app
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/Hru-s/driftwatch/internal/collectors"
+)
+
+// logger receives the -log-level diagnostics on stderr: which cluster is
+// contacted, what each phase collected and how long it took. It discards
+// everything until Run configures it.
+var logger = slog.New(slog.DiscardHandler)
+
+// parseLogLevel validates a -log-level value.
+func parseLogLevel(s string) (slog.Level, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(s)); err != nil {
+		return 0, fmt.Errorf("unknown log level %q (supported: debug, info, warn, error)", s)
+	}
+	return level, nil
+}
+
+// configureLogging sends logger and the collectors' logger to stderr at
+// level.
+func configureLogging(level slog.Level) {
+	logger = slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level}))
+	collectors.Log = logger
+}
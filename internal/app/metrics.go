@@ -0,0 +1,165 @@
+package app
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// metricFamily is one gauge of the -metrics-out exposition.
+type metricFamily struct {
+	name, help string
+	samples    []metricSample
+}
+
+type metricSample struct {
+	labels [][2]string // extra labels, after cluster and mode
+	value  int64
+}
+
+// driftMetrics returns the gauges of a run: named counts for the RBAC,
+// NetworkPolicy and PSA buckets (always present, 0 when clean), one
+// driftwatch_findings sample per report section with findings, and the run
+// metadata, finished being when the run ended. Counts respect the report
+// filters but not -max-perms-per-subject.
+func driftMetrics(modeLabel string, opts Options, res driftResults, finished time.Time) []metricFamily {
+	gateOpts := normalizeOptions(opts)
+	gateOpts.MaxPermsPerSubject = 0
+	r := buildJSONReport(modeLabel, gateOpts, res)
+
+	var extraPerms, missingPerms int
+	for _, sp := range r.RBAC.Extra {
+		extraPerms += len(sp.Permissions)
+	}
+	for _, sp := range r.RBAC.Missing {
+		missingPerms += len(sp.Permissions)
+	}
+	var psaWeaker int
+	for _, e := range r.PSA.Extra {
+		if e.DriftType == "weaker" {
+			psaWeaker++
+		}
+	}
+
+	gauge := func(name, help string, v int) metricFamily {
+		return metricFamily{name: name, help: help, samples: []metricSample{{value: int64(v)}}}
+	}
+	families := []metricFamily{
+		gauge("driftwatch_rbac_extra_subjects", "Subjects with permissions the baseline does not grant.", len(r.RBAC.Extra)),
+		gauge("driftwatch_rbac_missing_subjects", "Subjects missing permissions the baseline grants.", len(r.RBAC.Missing)),
+		gauge("driftwatch_rbac_extra_permissions_total", "Permissions granted beyond the baseline, over all subjects.", extraPerms),
+		gauge("driftwatch_rbac_missing_permissions_total", "Baseline permissions no longer granted, over all subjects.", missingPerms),
+		gauge("driftwatch_netpol_missing_total", "Baseline NetworkPolicies missing from the cluster.", len(r.NetworkPolicy.Missing)),
+		gauge("driftwatch_netpol_extra_total", "NetworkPolicies not in the baseline.", len(r.NetworkPolicy.Extra)),
+		gauge("driftwatch_netpol_changed_total", "NetworkPolicies whose spec differs from the baseline.", len(r.NetworkPolicy.Changed)),
+		gauge("driftwatch_psa_weaker_total", "Namespaces whose PSA enforce level is weaker than the baseline's.", psaWeaker),
+		gauge("driftwatch_psa_missing_total", "Baseline namespaces whose PSA labels are missing.", len(r.PSA.Missing)),
+	}
+
+	counts := map[string]int{}
+	eachFinding(r, func(section string, _ any) { counts[section]++ })
+	sections := make([]string, 0, len(counts))
+	for s := range counts {
+		sections = append(sections, s)
+	}
+	sort.Strings(sections)
+	findings := metricFamily{name: "driftwatch_findings", help: "Findings per report section; sections without findings are omitted."}
+	for _, s := range sections {
+		findings.samples = append(findings.samples, metricSample{labels: [][2]string{{"section", s}}, value: int64(counts[s])})
+	}
+	families = append(families, findings)
+
+	incomplete := 0
+	if len(res.Incomplete) > 0 {
+		incomplete = 1
+	}
+	families = append(families,
+		gauge("driftwatch_ignored_findings", "Findings accepted by -ignore-file.", r.Ignored),
+		gauge("driftwatch_report_incomplete", "1 when -max-runtime cut collection short.", incomplete),
+		metricFamily{
+			name:    "driftwatch_last_run_timestamp_seconds",
+			help:    "Unix time the run finished.",
+			samples: []metricSample{{value: finished.Unix()}},
+		},
+	)
+	return families
+}
+
+// metricsCluster returns the cluster label: -label cluster=... if given,
+// otherwise the cluster the findings are about (cluster B, or snapshot B).
+func metricsCluster(opts Options) string {
+	if c := opts.Labels["cluster"]; c != "" {
+		return c
+	}
+	switch opts.Mode {
+	case "cluster-compare":
+		if opts.ContextB != "" {
+			return opts.ContextB
+		}
+		return opts.KubeconfigB
+	case "snapshot-compare":
+		return opts.SnapshotB
+	}
+	switch {
+	case opts.Context != "":
+		return opts.Context
+	case opts.APIServer != "":
+		return opts.APIServer
+	case opts.InCluster:
+		return "in-cluster"
+	}
+	return opts.Kubeconfig
+}
+
+var metricLabelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// writeMetrics writes the run's gauges in the Prometheus text exposition
+// format, each sample labeled with cluster and mode.
+func writeMetrics(w io.Writer, modeLabel string, opts Options, res driftResults, finished time.Time) error {
+	base := [][2]string{{"cluster", metricsCluster(opts)}, {"mode", opts.Mode}}
+
+	var buf bytes.Buffer
+	for _, f := range driftMetrics(modeLabel, opts, res, finished) {
+		fmt.Fprintf(&buf, "# HELP %s %s\n# TYPE %s gauge\n", f.name, f.help, f.name)
+		for _, s := range f.samples {
+			labels := make([]string, 0, len(base)+len(s.labels))
+			for _, l := range append(base[:len(base):len(base)], s.labels...) {
+				labels = append(labels, l[0]+`="`+metricLabelEscaper.Replace(l[1])+`"`)
+			}
+			fmt.Fprintf(&buf, "%s{%s} %d\n", f.name, strings.Join(labels, ","), s.value)
+		}
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// writeMetricsFile writes -metrics-out for the node exporter's textfile
+// collector, which may read the file at any time: the metrics go to a temp
+// file in the same directory that is then renamed over path.
+func writeMetricsFile(path, modeLabel string, opts Options, res driftResults) error {
+	var buf bytes.Buffer
+	if err := writeMetrics(&buf, modeLabel, opts, res, time.Now()); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(buf.Bytes()); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
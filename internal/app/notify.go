@@ -0,0 +1,136 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// maxNotifyFindings caps the finding lines of a notification's text and
+// topFindings; the full report is attached.
+const maxNotifyFindings = 10
+
+// notifyPayload is the JSON body POSTed to -notify-webhook. Text is what a
+// Slack incoming webhook displays; the other fields are for receivers that
+// parse the drift.
+type notifyPayload struct {
+	Text        string         `json:"text"`
+	Cluster     string         `json:"cluster,omitempty"`
+	Mode        string         `json:"mode"`
+	Summary     findingSummary `json:"summary"`
+	TopFindings []string       `json:"topFindings"`
+	Report      Report         `json:"report"`
+}
+
+// checkNotifyWebhook validates a -notify-webhook URL.
+func checkNotifyWebhook(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("-notify-webhook must be an http or https URL")
+	}
+	return nil
+}
+
+// buildNotifyPayload summarizes the run, or returns false when it has no
+// findings (with -notify-on new: none that are new). TopFindings are the
+// first findings in report (-sort) order, or the first new ones.
+func buildNotifyPayload(modeLabel string, opts Options, res driftResults) (notifyPayload, bool) {
+	opts = normalizeOptions(opts)
+	onlyNew := opts.NotifyOn == "new"
+	sum := summarizeFindings(modeLabel, opts, res)
+	if sum.Total == 0 || onlyNew && (res.History == nil || len(res.History.New) == 0) {
+		return notifyPayload{}, false
+	}
+
+	gateOpts := opts
+	gateOpts.MaxPermsPerSubject = 0
+	report := buildJSONReport(modeLabel, gateOpts, res)
+	var lines []string
+	more := 0
+	add := func(key string) {
+		if len(lines) < maxNotifyFindings {
+			lines = append(lines, key)
+		} else {
+			more++
+		}
+	}
+	if onlyNew {
+		for _, key := range res.History.New {
+			add(key)
+		}
+	} else {
+		eachFindingKey(report.findings(), func(_, key string) { add(key) })
+	}
+
+	cluster := metricsCluster(opts)
+	var text strings.Builder
+	// counted per finding line, like the -emit-events summary
+	if onlyNew {
+		fmt.Fprintf(&text, "driftwatch found %d new findings (%d in total, highest severity: %s)",
+			len(lines)+more, len(findingKeys(report.findings())), sum.HighestSeverity)
+	} else {
+		fmt.Fprintf(&text, "driftwatch found %d findings (highest severity: %s)", len(lines)+more, sum.HighestSeverity)
+	}
+	if cluster != "" {
+		fmt.Fprintf(&text, " in %s", cluster)
+	}
+	fmt.Fprintf(&text, " [%s]", modeLabel)
+	if len(res.Incomplete) > 0 {
+		fmt.Fprintf(&text, "; report is partial, not collected: %s", strings.Join(res.Incomplete, ", "))
+	}
+	for _, l := range lines {
+		text.WriteString("\n• " + l)
+	}
+	if more > 0 {
+		fmt.Fprintf(&text, "\n… and %d more", more)
+	}
+
+	return notifyPayload{
+		Text:        text.String(),
+		Cluster:     cluster,
+		Mode:        modeLabel,
+		Summary:     sum,
+		TopFindings: lines,
+		Report:      report,
+	}, true
+}
+
+// notifyWebhook POSTs the run's drift to -notify-webhook. Runs without
+// findings send nothing.
+func notifyWebhook(modeLabel string, opts Options, res driftResults) error {
+	payload, ok := buildNotifyPayload(modeLabel, opts, res)
+	if !ok {
+		return nil
+	}
+	var body bytes.Buffer
+	if err := writeJSON(&body, payload); err != nil {
+		return err
+	}
+
+	ctx, cancel := withTimeout(context.Background(), opts.Timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, opts.NotifyWebhook, &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		// webhook URLs embed their credential; keep it out of the error
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("posting notification: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("posting notification: webhook returned %s", resp.Status)
+	}
+	logger.Info("drift notification sent", "findings", payload.Summary.Total)
+	return nil
+}
@@ -0,0 +1,68 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// progress prints lightweight phase/count updates to stderr so long
+// collections on big clusters don't look hung. It is a no-op when disabled;
+// the phases are logged at info level (-log-level) either way.
+type progress struct {
+	enabled bool
+	name    string
+	start   time.Time
+}
+
+// newProgress enables the indicator only for interactive text runs: stderr
+// must be a terminal and the report format must be human-readable.
+func newProgress(opts Options) *progress {
+	enabled := opts.Progress &&
+		normalizeOutputFormat(opts.OutputFormat) == "text" &&
+		isTerminal(os.Stderr)
+	return &progress{enabled: enabled}
+}
+
+func (p *progress) phase(name string) {
+	p.name, p.start = name, time.Now()
+	logger.Info(name)
+	if !p.enabled {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "[driftwatch] %s...\n", name)
+}
+
+func (p *progress) done(count int, unit string) {
+	elapsed := time.Since(p.start).Round(time.Millisecond)
+	logger.Info("collected", "phase", p.name, "count", count, "unit", unit, "elapsed", elapsed)
+	if !p.enabled {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "[driftwatch]   collected %d %s in %s\n", count, unit, elapsed)
+}
+
+// step is phase for collections that run concurrently with others: each
+// keeps its own start time, reported by the returned done func.
+func (p *progress) step(name string) (done func(count int, unit string)) {
+	start := time.Now()
+	logger.Info(name)
+	if p.enabled {
+		fmt.Fprintf(os.Stderr, "[driftwatch] %s...\n", name)
+	}
+	return func(count int, unit string) {
+		elapsed := time.Since(start).Round(time.Millisecond)
+		logger.Info("collected", "phase", name, "count", count, "unit", unit, "elapsed", elapsed)
+		if p.enabled {
+			fmt.Fprintf(os.Stderr, "[driftwatch]   collected %d %s in %s\n", count, unit, elapsed)
+		}
+	}
+}
+
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,126 @@
+package app
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/Hru-s/driftwatch/internal/model"
+)
+
+// reportDirFileNames overrides the file name of a findings section whose
+// JSON key is not the name downstream tools expect.
+var reportDirFileNames = map[string]string{
+	"networkPolicy": "netpol",
+}
+
+// reportDirAlways lists the sections written even when they have no
+// findings, so consumers can rely on the files existing.
+var reportDirAlways = map[string]bool{
+	"rbac":          true,
+	"networkPolicy": true,
+	"psa":           true,
+}
+
+// reportDirSummary is summary.json of -output-dir: the run metadata and
+// the list of section files written. The metadata is omitted under
+// -findings-only.
+type reportDirSummary struct {
+	Title           string                      `json:"title,omitempty"`
+	Labels          map[string]string           `json:"labels,omitempty"`
+	Mode            string                      `json:"mode,omitempty"`
+	DriftType       string                      `json:"driftType,omitempty"`
+	Stats           *collectionStats            `json:"stats,omitempty"`
+	Files           []string                    `json:"files"`
+	HighestSeverity model.Severity              `json:"highestSeverity,omitempty"`
+	Incomplete      []string                    `json:"incomplete,omitempty"`
+	HistoryDelta    *reportDelta                `json:"historyDelta,omitempty"`
+	Suppressed      map[string]*suppressionNote `json:"suppressed,omitempty"`
+	Ignored         int                         `json:"ignored,omitempty"`
+	Warnings        []string                    `json:"warnings,omitempty"`
+}
+
+// writeReportDir splits the JSON report into one file per findings section
+// (rbac.json, netpol.json, psa.json, plus any optional section with
+// findings, named after its JSON key) and a summary.json. Files of
+// optional sections that are now empty are removed, so a re-run (or a
+// -watch cycle) never leaves stale findings behind.
+func writeReportDir(dir, modeLabel string, opts Options, res driftResults) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	r := buildJSONReport(modeLabel, opts, res)
+
+	summary := reportDirSummary{Files: []string{}}
+	f := reflect.ValueOf(r.findings())
+	t := f.Type()
+	for i := 0; i < t.NumField(); i++ {
+		key, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if key == "incomplete" {
+			continue
+		}
+		name := key
+		if n, ok := reportDirFileNames[key]; ok {
+			name = n
+		}
+		name += ".json"
+		path := filepath.Join(dir, name)
+
+		v := f.Field(i)
+		if !reportDirAlways[key] && isEmptySection(v) {
+			if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+			continue
+		}
+		if err := writeReportFile(path, v.Interface()); err != nil {
+			return err
+		}
+		summary.Files = append(summary.Files, name)
+	}
+
+	gateOpts := opts
+	gateOpts.MaxPermsPerSubject = 0
+	summary.HighestSeverity = highestSeverity(buildJSONReport(modeLabel, gateOpts, res))
+	summary.Incomplete = r.Incomplete
+	if !opts.FindingsOnly {
+		summary.Title = r.Title
+		summary.Labels = r.Labels
+		summary.Mode = r.Mode
+		summary.DriftType = r.DriftType
+		summary.Stats = r.Stats
+		summary.HistoryDelta = r.HistoryDelta
+		summary.Suppressed = r.Suppressed
+		summary.Ignored = r.Ignored
+		summary.Warnings = r.Warnings
+	}
+	if err := writeReportFile(filepath.Join(dir, "summary.json"), summary); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "report written to %s (%d section files)\n", dir, len(summary.Files))
+	return nil
+}
+
+func isEmptySection(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	default:
+		return v.IsZero()
+	}
+}
+
+func writeReportFile(path string, v any) error {
+	var buf bytes.Buffer
+	if err := writeJSON(&buf, v); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package app
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+)
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Skipped  int             `xml:"skipped,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Skipped   *junitSkipped `xml:"skipped,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr"`
+}
+
+// junitCategory is one testsuite of -output junit. section is the
+// -max-runtime section the category is collected in.
+type junitCategory struct {
+	name    string
+	section string
+}
+
+// junitCategories lists the report categories the run checked: the core
+// RBAC, NetworkPolicy and PSA comparison plus every enabled optional check.
+func junitCategories(opts Options, res driftResults) []junitCategory {
+	cats := []junitCategory{
+		{"rbac", sectionRBAC},
+		{"networkPolicy", sectionNetPol},
+		{"psa", sectionPSA},
+		{"danglingBindings", sectionRBAC},
+	}
+	optional := []struct {
+		enabled bool
+		junitCategory
+	}{
+		{res.Workload != nil, junitCategory{"workload", ""}},
+		{opts.BindingDiff, junitCategory{"bindings", sectionRBAC}},
+		{opts.ClusterRoleView, junitCategory{"clusterRoleSubjects", sectionRBAC}},
+		{len(opts.CompareLabels) > 0, junitCategory{"labelDrift", sectionNetPol}},
+		{opts.AuditRoles, junitCategory{"roleAudit", sectionRBAC}},
+		{opts.StorageClasses, junitCategory{"storageClasses", sectionStorage}},
+		{opts.ResourceQuotas, junitCategory{"resourceQuotas", sectionQuotas}},
+		{opts.LimitRanges, junitCategory{"limitRanges", sectionLimitRanges}},
+		{opts.AdmissionWebhooks, junitCategory{"admissionWebhooks", sectionWebhooks}},
+		{opts.ServiceAccounts, junitCategory{"serviceAccounts", sectionSAs}},
+		{opts.CheckImages, junitCategory{"imageViolations", sectionImages}},
+		{opts.LastApplied, junitCategory{"lastApplied", sectionLastApplied}},
+		{opts.AuditIPBlocks, junitCategory{"netpolAudit", sectionNetPol}},
+		{opts.AuditNetPolSelectors, junitCategory{"netpolSelectorAudit", sectionNetPol}},
+		{opts.NetPolOpenness, junitCategory{"netpolOpenness", sectionNetPol}},
+		{opts.DefaultDenyCoverage, junitCategory{"defaultDeny", sectionNetPol}},
+		{opts.RegoPolicy != "", junitCategory{"regoViolations", ""}},
+	}
+	for _, c := range optional {
+		if c.enabled {
+			cats = append(cats, c.junitCategory)
+		}
+	}
+	return cats
+}
+
+// printJUnitReport writes the filtered report as a JUnit XML document: one
+// testsuite per category and one failed testcase per finding, named and
+// described by its finding line (as in -report-history). A category without
+// drift gets a single passing testcase so the suite still shows up in CI; one
+// not collected before -max-runtime expired gets a skipped testcase.
+func printJUnitReport(modeLabel string, opts Options, res driftResults) error {
+	r := buildJSONReport(modeLabel, opts, res)
+
+	byCategory := map[string][]string{}
+	eachFindingKey(r.findings(), func(category, key string) {
+		byCategory[category] = append(byCategory[category], key)
+	})
+
+	doc := junitTestSuites{Name: "driftwatch"}
+	if r.Title != "" {
+		doc.Name = r.Title
+	}
+	for _, c := range junitCategories(opts, res) {
+		suite := junitTestSuite{Name: c.name}
+		className := "driftwatch." + c.name
+		switch keys := byCategory[c.name]; {
+		case c.section != "" && res.isIncomplete(c.section):
+			suite.Skipped = 1
+			suite.Cases = append(suite.Cases, junitTestCase{
+				ClassName: className,
+				Name:      c.name,
+				Skipped:   &junitSkipped{Message: "not collected before -max-runtime expired"},
+			})
+		case len(keys) == 0:
+			suite.Cases = append(suite.Cases, junitTestCase{ClassName: className, Name: "no " + c.name + " findings"})
+		default:
+			for _, k := range keys {
+				suite.Cases = append(suite.Cases, junitTestCase{
+					ClassName: className,
+					Name:      k,
+					Failure:   &junitFailure{Message: k, Type: c.name, Text: k},
+				})
+			}
+			suite.Failures = len(keys)
+		}
+		suite.Tests = len(suite.Cases)
+		doc.Tests += suite.Tests
+		doc.Failures += suite.Failures
+		doc.Skipped += suite.Skipped
+		doc.Suites = append(doc.Suites, suite)
+	}
+
+	out, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(os.Stdout, "%s%s\n", xml.Header, out)
+	return err
+}
@@ -0,0 +1,147 @@
+package app
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/Hru-s/driftwatch/internal/model"
+)
+
+// printKubeDiffReport renders the filtered findings in a `kubectl diff`-like
+// unified format: one ---/+++ header per object, "-" lines for what only the
+// baseline has and "+" lines for what only live has.
+func printKubeDiffReport(modeLabel string, opts Options, res driftResults) error {
+	r := buildJSONReport(modeLabel, opts, res)
+	w := os.Stdout
+
+	writeRBACKubeDiff(w, r.RBAC)
+	writeNetPolKubeDiff(w, r.NetworkPolicy)
+	writePSAKubeDiff(w, r.PSA)
+	return nil
+}
+
+func kubeDiffHeader(w io.Writer, kind, name string) {
+	fmt.Fprintf(w, "diff -u -N baseline/%s/%s live/%s/%s\n", kind, name, kind, name)
+	fmt.Fprintf(w, "--- baseline/%s/%s\n", kind, name)
+	fmt.Fprintf(w, "+++ live/%s/%s\n", kind, name)
+}
+
+func writeRBACKubeDiff(w io.Writer, d RBACReport) {
+	type subjectLines struct {
+		minus []model.Permission
+		plus  []model.Permission
+		more  int
+	}
+	bySubject := map[model.SubjectKey]*subjectLines{}
+	get := func(s model.SubjectKey) *subjectLines {
+		if bySubject[s] == nil {
+			bySubject[s] = &subjectLines{}
+		}
+		return bySubject[s]
+	}
+	for _, sp := range d.Missing {
+		l := get(sp.Subject)
+		l.minus = append(l.minus, sp.grants()...)
+		l.more += sp.Truncated
+	}
+	for _, sp := range d.Extra {
+		l := get(sp.Subject)
+		l.plus = append(l.plus, sp.grants()...)
+		l.more += sp.Truncated
+	}
+	for _, c := range d.ResourceNames {
+		l := get(c.Subject)
+		for _, name := range c.Removed {
+			p := c.Grant()
+			p.ResourceName = name
+			l.minus = append(l.minus, p)
+		}
+		for _, name := range c.Added {
+			p := c.Grant()
+			p.ResourceName = name
+			l.plus = append(l.plus, p)
+		}
+	}
+
+	subjects := make([]model.SubjectKey, 0, len(bySubject))
+	for s := range bySubject {
+		subjects = append(subjects, s)
+	}
+	sort.Slice(subjects, func(i, j int) bool { return subjects[i].String() < subjects[j].String() })
+
+	for _, s := range subjects {
+		l := bySubject[s]
+		name := s.Name
+		if s.Namespace != "" {
+			name = s.Namespace + "/" + s.Name
+		}
+		kubeDiffHeader(w, "rbac/"+s.Kind, name)
+		fmt.Fprintf(w, "@@ permissions @@\n")
+		for _, p := range l.minus {
+			fmt.Fprintf(w, "-%s\n", p.String())
+		}
+		for _, p := range l.plus {
+			fmt.Fprintf(w, "+%s\n", p.String())
+		}
+		if l.more > 0 {
+			fmt.Fprintf(w, " ... %d more permission changes not shown\n", l.more)
+		}
+	}
+}
+
+func writeNetPolKubeDiff(w io.Writer, d NetPolReport) {
+	for _, ref := range d.Missing {
+		kubeDiffHeader(w, "networkpolicies", ref.String())
+		fmt.Fprintf(w, "@@ object @@\n-kind: NetworkPolicy\n-metadata:\n-  namespace: %s\n-  name: %s\n", ref.Namespace, ref.Name)
+	}
+	for _, ref := range d.Extra {
+		kubeDiffHeader(w, "networkpolicies", ref.String())
+		fmt.Fprintf(w, "@@ object @@\n+kind: NetworkPolicy\n+metadata:\n+  namespace: %s\n+  name: %s\n", ref.Namespace, ref.Name)
+	}
+	for _, ch := range d.Changed {
+		kubeDiffHeader(w, "networkpolicies", ch.Namespace+"/"+ch.Name)
+		fmt.Fprintf(w, "@@ spec @@\n")
+		b, l := ch.Baseline, ch.Live
+		if fmt.Sprint(b.PolicyTypes) != fmt.Sprint(l.PolicyTypes) {
+			fmt.Fprintf(w, "-  policyTypes: %v\n+  policyTypes: %v\n", b.PolicyTypes, l.PolicyTypes)
+		}
+		if b.IngressCount != l.IngressCount {
+			fmt.Fprintf(w, "-  ingress: %d rule(s)\n+  ingress: %d rule(s)\n", b.IngressCount, l.IngressCount)
+		}
+		if b.EgressCount != l.EgressCount {
+			fmt.Fprintf(w, "-  egress: %d rule(s)\n+  egress: %d rule(s)\n", b.EgressCount, l.EgressCount)
+		}
+		fmt.Fprintf(w, "-  # specHash: %s\n+  # specHash: %s\n", b.SpecHash, l.SpecHash)
+	}
+}
+
+func writePSAKubeDiff(w io.Writer, d PSAReport) {
+	entries := append(append(append([]model.PSADriftEntry(nil), d.Extra...), d.Missing...), d.Incomparable...)
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Namespace < entries[j].Namespace })
+
+	for _, e := range entries {
+		kubeDiffHeader(w, "namespaces", e.Namespace)
+		fmt.Fprintf(w, "@@ metadata.labels @@\n")
+		if e.DriftType != "extra" {
+			fmt.Fprintf(w, "-  pod-security.kubernetes.io/enforce: %s\n", e.Baseline)
+		}
+		if e.DriftType != "missing" {
+			fmt.Fprintf(w, "+  pod-security.kubernetes.io/enforce: %s\n", e.Live)
+		}
+		if e.Exempt {
+			fmt.Fprintf(w, "+  # exempted by the API server PodSecurity configuration\n")
+		}
+	}
+	for _, m := range d.ManagedBy {
+		kubeDiffHeader(w, "namespaces", m.Namespace)
+		fmt.Fprintf(w, "@@ metadata.annotations @@\n")
+		if m.Expected != "" {
+			fmt.Fprintf(w, "-  %s: %s\n", m.Annotation, m.Expected)
+		}
+		if m.Live != "" {
+			fmt.Fprintf(w, "+  %s: %s\n", m.Annotation, m.Live)
+		}
+	}
+}
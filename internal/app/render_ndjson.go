@@ -0,0 +1,134 @@
+package app
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+
+	"github.com/Hru-s/driftwatch/internal/model"
+)
+
+// ndjsonFinding is one line of -output ndjson-findings.
+type ndjsonFinding struct {
+	Type    string `json:"type"` // always "finding"
+	Section string `json:"section"`
+	Finding any    `json:"finding"`
+}
+
+// ndjsonSummary is the last line of -output ndjson-findings. The run
+// metadata is omitted under -findings-only.
+type ndjsonSummary struct {
+	Type            string            `json:"type"` // always "summary"
+	Title           string            `json:"title,omitempty"`
+	Labels          map[string]string `json:"labels,omitempty"`
+	Mode            string            `json:"mode,omitempty"`
+	DriftType       string            `json:"driftType,omitempty"`
+	Counts          map[string]int    `json:"counts"`
+	Total           int               `json:"total"`
+	HighestSeverity model.Severity    `json:"highestSeverity,omitempty"`
+	Incomplete      []string          `json:"incomplete,omitempty"`
+	Warnings        []string          `json:"warnings,omitempty"`
+}
+
+// printNDJSONReport writes one compact JSON object per finding, tagged with
+// its report section (e.g. "rbac.extra"), followed by a summary object with
+// per-section counts so streaming consumers get totals at the end.
+func printNDJSONReport(modeLabel string, opts Options, res driftResults) error {
+	r := buildJSONReport(modeLabel, opts, res)
+	w := bufio.NewWriter(os.Stdout)
+	enc := json.NewEncoder(w)
+
+	summary := ndjsonSummary{Type: "summary", Counts: map[string]int{}}
+	var err error
+	emit := func(section string, finding any) {
+		if err != nil {
+			return
+		}
+		summary.Counts[section]++
+		summary.Total++
+		err = enc.Encode(ndjsonFinding{Type: "finding", Section: section, Finding: finding})
+	}
+
+	eachFinding(r, emit)
+	if err != nil {
+		return err
+	}
+
+	gateOpts := opts
+	gateOpts.MaxPermsPerSubject = 0
+	summary.HighestSeverity = highestSeverity(buildJSONReport(modeLabel, gateOpts, res))
+	summary.Incomplete = r.Incomplete
+	if !opts.FindingsOnly {
+		summary.Title = r.Title
+		summary.Labels = r.Labels
+		summary.Mode = r.Mode
+		summary.DriftType = r.DriftType
+		summary.Warnings = r.Warnings
+	}
+	if err := enc.Encode(summary); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+// eachFinding calls emit with every finding of r, tagged with its report
+// section, in report order.
+func eachFinding(r Report, emit func(section string, finding any)) {
+	emitEach(emit, "rbac.extra", r.RBAC.Extra)
+	emitEach(emit, "rbac.missing", r.RBAC.Missing)
+	emitEach(emit, "rbac.renamed", r.RBAC.Renamed)
+	emitEach(emit, "rbac.resourceNames", r.RBAC.ResourceNames)
+	emitEach(emit, "networkPolicy.missing", r.NetworkPolicy.Missing)
+	emitEach(emit, "networkPolicy.extra", r.NetworkPolicy.Extra)
+	emitEach(emit, "networkPolicy.changed", r.NetworkPolicy.Changed)
+	emitEach(emit, "psa.extra", r.PSA.Extra)
+	emitEach(emit, "psa.missing", r.PSA.Missing)
+	emitEach(emit, "psa.incomparable", r.PSA.Incomparable)
+	emitEach(emit, "psa.managedBy", r.PSA.ManagedBy)
+	emitEach(emit, "roleAudit", r.RoleAudit)
+	emitEach(emit, "netpolAudit", r.NetPolAudit)
+	emitEach(emit, "netpolSelectorAudit", r.SelectorAudit)
+	emitEach(emit, "netpolOpenness", r.Openness)
+	emitEach(emit, "defaultDeny", r.DefaultDeny)
+	emitEach(emit, "regoViolations", r.Rego)
+	emitEach(emit, "labelDrift", r.LabelDrift)
+	emitEach(emit, "bindings", r.Bindings)
+	emitEach(emit, "danglingBindings", r.DanglingBindings)
+	for _, v := range r.RoleSubjects {
+		if v.Drifted {
+			emit("clusterRoleSubjects", v)
+		}
+	}
+	if sc := r.StorageClasses; sc != nil {
+		emitEach(emit, "storageClasses.missing", sc.Missing)
+		emitEach(emit, "storageClasses.extra", sc.Extra)
+		emitEach(emit, "storageClasses.changed", sc.Changed)
+	}
+	if rq := r.ResourceQuotas; rq != nil {
+		emitEach(emit, "resourceQuotas.missing", rq.Missing)
+		emitEach(emit, "resourceQuotas.extra", rq.Extra)
+		emitEach(emit, "resourceQuotas.changed", rq.Changed)
+	}
+	if lr := r.LimitRanges; lr != nil {
+		emitEach(emit, "limitRanges.missing", lr.Missing)
+		emitEach(emit, "limitRanges.extra", lr.Extra)
+		emitEach(emit, "limitRanges.changed", lr.Changed)
+	}
+	emitEach(emit, "admissionWebhooks", r.AdmissionWebhooks)
+	if sa := r.ServiceAccounts; sa != nil {
+		emitEach(emit, "serviceAccounts.extra", sa.Extra)
+		emitEach(emit, "serviceAccounts.missing", sa.Missing)
+	}
+	emitEach(emit, "imageViolations", r.Images)
+	emitEach(emit, "lastApplied", r.LastApplied)
+	if wl := r.Workload; wl != nil {
+		emitEach(emit, "workload.extra", wl.Extra)
+		emitEach(emit, "workload.missing", wl.Missing)
+	}
+}
+
+func emitEach[T any](emit func(string, any), section string, items []T) {
+	for _, item := range items {
+		emit(section, item)
+	}
+}
@@ -0,0 +1,294 @@
+package app
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/Hru-s/driftwatch/internal/model"
+	"github.com/Hru-s/driftwatch/internal/source"
+)
+
+const sarifSchema = "https://json.schemastore.org/sarif-2.1.0.json"
+
+// sarifRules are the rules of -output sarif, one per finding category.
+var sarifRules = []sarifRule{
+	{"rbac-extra", sarifMessage{"Subject holds an RBAC permission in live that the baseline does not grant"}},
+	{"rbac-missing", sarifMessage{"Subject lacks an RBAC permission in live that the baseline grants"}},
+	{"rbac-renamed", sarifMessage{"Subject was replaced in live by a new subject with the same permissions"}},
+	{"rbac-resourcename", sarifMessage{"resourceNames of a named RBAC grant differ between baseline and live"}},
+	{"netpol-missing", sarifMessage{"NetworkPolicy from the baseline is missing in live"}},
+	{"netpol-extra", sarifMessage{"NetworkPolicy exists in live but not in the baseline"}},
+	{"netpol-changed", sarifMessage{"NetworkPolicy spec differs between baseline and live"}},
+	{"psa-weaker", sarifMessage{"Namespace PSA enforce level is weaker in live than in the baseline"}},
+	{"psa-stronger", sarifMessage{"Namespace PSA enforce level is stronger in live than in the baseline"}},
+	{"psa-different", sarifMessage{"Namespace PSA enforce level differs between baseline and live"}},
+	{"psa-exempted", sarifMessage{"Namespace is exempt from PSA in live although the baseline enforces a level"}},
+	{"psa-extra", sarifMessage{"Namespace has PSA labels in live but is not in the baseline"}},
+	{"psa-missing", sarifMessage{"Namespace from the baseline is missing in live"}},
+	{"psa-incomparable", sarifMessage{"Namespace PSA enforce levels cannot be ranked against each other"}},
+	{"psa-managed-by", sarifMessage{"Namespace PSA labels lack the expected managed-by annotation"}},
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifResult struct {
+	RuleID              string            `json:"ruleId"`
+	Level               string            `json:"level"`
+	Message             sarifMessage      `json:"message"`
+	Locations           []sarifLocation   `json:"locations,omitempty"`
+	PartialFingerprints map[string]string `json:"partialFingerprints"`
+	Properties          sarifProperties   `json:"properties"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifProperties struct {
+	Severity model.Severity `json:"severity"`
+}
+
+// printSARIFReport writes the RBAC, NetworkPolicy and PSA findings of the
+// filtered report as a SARIF 2.1.0 log, one result per finding (per
+// permission for RBAC). Code scanning drops results without a location, so
+// every result has one: in single mode the baseline file declaring the
+// object, for a live-only permission the files declaring the subject's
+// other grants, and otherwise the baseline (or snapshot A, or cluster B's
+// kubeconfig) as a whole.
+func printSARIFReport(modeLabel string, opts Options, res driftResults) error {
+	r := buildJSONReport(modeLabel, opts, res)
+	root := sarifRootURI(opts)
+	subjectFiles := sarifSubjectRefs(res, opts)
+
+	var results []sarifResult
+	add := func(ruleID string, sev model.Severity, definedIn []model.SourceRef, format string, args ...any) {
+		msg := fmt.Sprintf(format, args...)
+		sum := sha256.Sum256([]byte(ruleID + "\n" + msg))
+		result := sarifResult{
+			RuleID:              ruleID,
+			Level:               sarifLevel(sev),
+			Message:             sarifMessage{Text: msg},
+			PartialFingerprints: map[string]string{"driftwatchFinding/v1": hex.EncodeToString(sum[:16])},
+			Properties:          sarifProperties{Severity: sev},
+		}
+		for _, ref := range definedIn {
+			result.Locations = append(result.Locations, sarifFileLocation(sarifURI(opts.BaselineDir, ref.File)))
+		}
+		if len(result.Locations) == 0 {
+			result.Locations = []sarifLocation{sarifFileLocation(root)}
+		}
+		results = append(results, result)
+	}
+
+	for _, sp := range r.RBAC.Extra {
+		for _, p := range sp.Permissions {
+			definedIn := p.DefinedIn
+			if len(definedIn) == 0 {
+				definedIn = subjectFiles[sp.Subject]
+			}
+			add("rbac-extra", p.Severity, definedIn, "%s has extra permission %s", sp.Subject, p)
+		}
+	}
+	for _, sp := range r.RBAC.Missing {
+		for _, p := range sp.Permissions {
+			add("rbac-missing", model.SeverityLow, p.DefinedIn, "%s is missing permission %s", sp.Subject, p)
+		}
+	}
+	for _, rn := range r.RBAC.Renamed {
+		add("rbac-renamed", model.SeverityLow, subjectFiles[rn.From], "%s was renamed to %s (%d permissions)", rn.From, rn.To, len(rn.Permissions))
+	}
+	for _, c := range r.RBAC.ResourceNames {
+		add("rbac-resourcename", resourceNameChangeSeverity(c, opts.severity), subjectFiles[c.Subject], "%s %s: resourceNames added %v, removed %v",
+			c.Subject, grantString(c.Grant()), c.Added, c.Removed)
+	}
+
+	for _, ref := range r.NetworkPolicy.Missing {
+		add("netpol-missing", model.SeverityMedium, sourceRefs(ref.DefinedIn), "NetworkPolicy %s is missing in live", ref)
+	}
+	for _, ref := range r.NetworkPolicy.Extra {
+		add("netpol-extra", model.SeverityLow, nil, "NetworkPolicy %s exists in live but not in the baseline", ref)
+	}
+	for _, c := range r.NetworkPolicy.Changed {
+		add("netpol-changed", model.SeverityMedium, sourceRefs(c.Baseline.DefinedIn), "NetworkPolicy %s/%s changed (spec hash %s -> %s)",
+			c.Namespace, c.Name, shortHash(c.Baseline.SpecHash), shortHash(c.Live.SpecHash))
+	}
+
+	psa := func(ruleID string, sev model.Severity, e model.PSADriftEntry) {
+		add(ruleID, sev, sourceRefs(e.DefinedIn), "Namespace %s PSA enforce level: baseline=%s live=%s",
+			e.Namespace, psaLevelText(e.Baseline), psaLevelText(e.Live))
+	}
+	for _, e := range r.PSA.Extra {
+		psa("psa-"+e.DriftType, psaEntrySeverity(e), e)
+	}
+	for _, e := range r.PSA.Missing {
+		psa("psa-"+e.DriftType, model.SeverityLow, e)
+	}
+	for _, e := range r.PSA.Incomparable {
+		psa("psa-incomparable", model.SeverityHigh, e)
+	}
+	for _, m := range r.PSA.ManagedBy {
+		add("psa-managed-by", model.SeverityMedium, sourceRefs(m.DefinedIn), "Namespace %s has PSA labels but annotation %s is %s (expected %q, live %q)",
+			m.Namespace, m.Annotation, m.Reason, m.Expected, m.Live)
+	}
+
+	if results == nil {
+		results = []sarifResult{}
+	}
+	doc := sarifLog{
+		Schema:  sarifSchema,
+		Version: "2.1.0",
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           "driftwatch",
+				InformationURI: "https://github.com/Hru-s/driftwatch",
+				Rules:          sarifRules,
+			}},
+			Results: results,
+		}},
+	}
+	return writeJSON(os.Stdout, doc)
+}
+
+func sarifFileLocation(uri string) sarifLocation {
+	return sarifLocation{PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: uri}}}
+}
+
+// sarifSubjectRefs returns, per (redacted) subject, one reference to each
+// baseline file granting it a permission, sorted by file.
+func sarifSubjectRefs(res driftResults, opts Options) map[model.SubjectKey][]model.SourceRef {
+	out := map[model.SubjectKey][]model.SourceRef{}
+	for subj, perms := range res.RBAC.DefinedIn {
+		files := map[string]bool{}
+		for _, refs := range perms {
+			for _, ref := range refs {
+				files[ref.File] = true
+			}
+		}
+		key := redactSubject(subj, opts)
+		for f := range files {
+			out[key] = append(out[key], model.SourceRef{File: f})
+		}
+		sort.Slice(out[key], func(i, j int) bool { return out[key][i].File < out[key][j].File })
+	}
+	return out
+}
+
+// sarifRootURI is the location of findings no baseline file declares: the
+// baseline itself, snapshot A, or in cluster-compare mode the repository
+// root, since neither cluster is a file of the scanned repository (and a
+// kubeconfig path has no place in an uploaded report).
+func sarifRootURI(opts Options) string {
+	switch opts.Mode {
+	case "cluster-compare":
+		return "."
+	case "snapshot-compare":
+		return sarifPathURI(opts.SnapshotA)
+	}
+	switch {
+	case opts.BaselineDir == source.Stdin:
+		return source.StdinFile
+	case source.IsRemote(opts.BaselineDir):
+		return "."
+	}
+	if info, err := os.Stat(opts.BaselineDir); err == nil && !info.IsDir() {
+		return sarifPathURI(opts.BaselineDir)
+	}
+	return sarifURI(opts.BaselineDir, "")
+}
+
+func sourceRefs(ref *model.SourceRef) []model.SourceRef {
+	if ref == nil {
+		return nil
+	}
+	return []model.SourceRef{*ref}
+}
+
+// sarifLevel maps a severity to a SARIF result level.
+func sarifLevel(s model.Severity) string {
+	switch s {
+	case model.SeverityCritical, model.SeverityHigh:
+		return "error"
+	case model.SeverityMedium:
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+// sarifURI locates file (relative to the baseline directory) the way code
+// scanning expects: relative to the working directory the scan ran in.
+func sarifURI(baselineDir, file string) string {
+	if baselineDir == source.Stdin || source.IsRemote(baselineDir) {
+		// the file is inside the fetched bundle, not on this disk
+		return file
+	}
+	if info, err := os.Stat(baselineDir); err == nil && !info.IsDir() {
+		// a single-file baseline; file is its base name
+		baselineDir = filepath.Dir(baselineDir)
+	}
+	return sarifPathURI(filepath.Join(baselineDir, file))
+}
+
+// sarifPathURI locates the local path p: relative to the working directory,
+// or as a file:// URI when absolute.
+func sarifPathURI(p string) string {
+	p = filepath.Clean(p)
+	if filepath.IsAbs(p) {
+		return "file://" + filepath.ToSlash(p)
+	}
+	return filepath.ToSlash(p)
+}
+
+func psaLevelText(l model.PSALevel) string {
+	if l == "" {
+		return "(none)"
+	}
+	return string(l)
+}
+
+func shortHash(h string) string {
+	if len(h) > 12 {
+		return h[:12]
+	}
+	return h
+}
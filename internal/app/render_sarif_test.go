@@ -0,0 +1,37 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Hru-s/driftwatch/internal/source"
+)
+
+func TestSarifRootURI(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "baseline.yaml")
+	if err := os.WriteFile(file, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		opts Options
+		want string
+	}{
+		{"cluster-compare", Options{Mode: "cluster-compare", KubeconfigA: "/home/me/.kube/a", KubeconfigB: "/home/me/.kube/config"}, "."},
+		{"stdin baseline", Options{Mode: "single", BaselineDir: source.Stdin}, source.StdinFile},
+		{"remote baseline", Options{Mode: "single", BaselineDir: "https://example.com/baseline.tgz"}, "."},
+		{"relative directory", Options{Mode: "single", BaselineDir: "policies"}, "policies"},
+		{"absolute snapshot A", Options{Mode: "snapshot-compare", SnapshotA: "/snaps/a.json"}, "file:///snaps/a.json"},
+		{"single file", Options{Mode: "single", BaselineDir: file}, "file://" + filepath.ToSlash(file)},
+		{"snapshot A", Options{Mode: "snapshot-compare", SnapshotA: "snap/a.json"}, "snap/a.json"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sarifRootURI(tt.opts); got != tt.want {
+				t.Errorf("sarifRootURI = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Hru-s/driftwatch/internal/model"
+
+	"sigs.k8s.io/yaml"
+)
+
+// findingSummary is the summary object of -summary: finding counts keyed by
+// report section ("rbac.extra", "networkPolicy.missing", ...), as in the
+// -output ndjson-findings summary line. Sections without findings are
+// omitted. RBAC sections count subjects, PSA sections namespaces.
+type findingSummary struct {
+	Counts          map[string]int `json:"counts"`
+	Total           int            `json:"total"`
+	HighestSeverity model.Severity `json:"highestSeverity,omitempty"`
+
+	// sections lists the keys of Counts in report order for text output.
+	sections []string
+}
+
+// summaryReport is the -summary JSON/YAML document: the run metadata of the
+// full report (omitted under -findings-only) with the findings replaced by
+// their counts.
+type summaryReport struct {
+	Title      string            `json:"title,omitempty"`
+	Labels     map[string]string `json:"labels,omitempty"`
+	Mode       string            `json:"mode,omitempty"`
+	DriftType  string            `json:"driftType,omitempty"`
+	Summary    findingSummary    `json:"summary"`
+	Incomplete []string          `json:"incomplete,omitempty"`
+	Warnings   []string          `json:"warnings,omitempty"`
+}
+
+func summarizeFindings(modeLabel string, opts Options, res driftResults) findingSummary {
+	sum := findingSummary{Counts: map[string]int{}}
+	eachFinding(buildJSONReport(modeLabel, opts, res), func(section string, _ any) {
+		if sum.Counts[section] == 0 {
+			sum.sections = append(sum.sections, section)
+		}
+		sum.Counts[section]++
+		sum.Total++
+	})
+	gateOpts := opts
+	gateOpts.MaxPermsPerSubject = 0
+	sum.HighestSeverity = highestSeverity(buildJSONReport(modeLabel, gateOpts, res))
+	return sum
+}
+
+// printSummaryReport renders -summary in the text, json or yaml output
+// format.
+func printSummaryReport(modeLabel string, opts Options, res driftResults) error {
+	sum := summarizeFindings(modeLabel, opts, res)
+
+	if opts.OutputFormat == "text" {
+		printHumanSummary(modeLabel, opts, res, sum)
+		return nil
+	}
+
+	report := summaryReport{Summary: sum, Incomplete: res.Incomplete}
+	if !opts.FindingsOnly {
+		report.Title = opts.ReportTitle
+		report.Labels = opts.Labels
+		report.Mode = modeLabel
+		report.DriftType = opts.DriftType
+		report.Warnings = res.Warnings
+	}
+	if opts.OutputFormat == "yaml" {
+		out, err := yaml.Marshal(report)
+		if err != nil {
+			return err
+		}
+		_, err = os.Stdout.Write(out)
+		return err
+	}
+	return writeJSON(os.Stdout, report)
+}
+
+func printHumanSummary(modeLabel string, opts Options, res driftResults, sum findingSummary) {
+	if !opts.FindingsOnly && !opts.Quiet {
+		printHumanHeader(modeLabel, opts)
+		fmt.Println()
+	}
+	if len(res.Incomplete) > 0 {
+		fmt.Printf(" PARTIAL REPORT: -max-runtime expired before these sections were collected: %s\n\n",
+			strings.Join(res.Incomplete, ", "))
+	}
+	fmt.Println(" Drift summary:")
+	if sum.Total == 0 {
+		fmt.Println("  No drift detected matching the current filters.")
+	}
+	for _, section := range sum.sections {
+		fmt.Printf("  %-28s %d\n", section+":", sum.Counts[section])
+	}
+	if sum.Total > 0 {
+		fmt.Printf("  %-28s %d (highest severity: %s)\n", "total:", sum.Total, sum.HighestSeverity)
+	}
+	if len(res.Warnings) > 0 {
+		fmt.Println()
+		fmt.Printf(" Warnings (%d):\n", len(res.Warnings))
+		for _, w := range res.Warnings {
+			fmt.Printf("  - %s\n", w)
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package app
+
+import (
+	"os"
+
+	"sigs.k8s.io/yaml"
+)
+
+// printYAMLReport writes the -output json report as YAML. sigs.k8s.io/yaml
+// goes through encoding/json, so field names and omitempty match the JSON
+// report and the rbac, networkPolicy and psa sections are always present.
+func printYAMLReport(modeLabel string, opts Options, res driftResults) error {
+	report := buildJSONReport(modeLabel, opts, res)
+	var v any = report
+	if opts.FindingsOnly {
+		v = report.findings()
+	}
+	out, err := yaml.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = os.Stdout.Write(out)
+	return err
+}
@@ -0,0 +1,138 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// driftServer holds the latest analysis of -serve. collect serializes the
+// analyses; mu guards the published result, so every request sees one
+// complete run.
+type driftServer struct {
+	opts    Options
+	collect sync.Mutex
+
+	mu       sync.RWMutex
+	report   *Report
+	finished time.Time
+	lastErr  error
+}
+
+// runServe analyzes every ServeInterval and serves the latest result on
+// opts.Serve: Prometheus gauges at /metrics and the JSON report at /report.
+// A failed cycle keeps the previous result. It returns when the server
+// fails or the process is interrupted.
+func runServe(opts Options) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	s := &driftServer{opts: opts}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /metrics", s.serveMetrics)
+	mux.HandleFunc("GET /report", s.serveReport)
+	srv := &http.Server{Addr: opts.Serve, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	serveErr := make(chan error, 1)
+	go func() { serveErr <- srv.ListenAndServe() }()
+	logger.Info("serving drift reports", "addr", opts.Serve, "interval", opts.ServeInterval)
+
+	go func() {
+		ticker := time.NewTicker(opts.ServeInterval)
+		defer ticker.Stop()
+		for {
+			s.refresh(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	select {
+	case err := <-serveErr:
+		return fmt.Errorf("-serve: %w", err)
+	case <-ctx.Done():
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("-serve: %w", err)
+	}
+	return nil
+}
+
+// refresh runs one analysis and publishes it, or records why it failed.
+func (s *driftServer) refresh(ctx context.Context) {
+	s.collect.Lock()
+	defer s.collect.Unlock()
+
+	report, err := Analyze(ctx, s.opts)
+	if ctx.Err() != nil {
+		return
+	}
+
+	s.mu.Lock()
+	if err != nil {
+		// keep serving the last good report; an API server blip should not
+		// blank the dashboards
+		s.lastErr = err
+		s.mu.Unlock()
+		fmt.Fprintf(os.Stderr, "serve cycle failed: %v\n", err)
+		return
+	}
+	s.report, s.finished, s.lastErr = report, time.Now(), nil
+	s.mu.Unlock()
+	logger.Info("serve cycle finished", "incomplete", len(report.Incomplete))
+
+	if s.opts.NotifyWebhook != "" {
+		if err := notifyWebhook(report.Mode, report.opts, report.res); err != nil {
+			fmt.Fprintf(os.Stderr, "-notify-webhook: %v\n", err)
+		}
+	}
+}
+
+// latest returns the published report, or an error to answer with when
+// there is none yet.
+func (s *driftServer) latest() (*Report, time.Time, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.report == nil {
+		if s.lastErr != nil {
+			return nil, time.Time{}, fmt.Errorf("no report yet: %w", s.lastErr)
+		}
+		return nil, time.Time{}, errors.New("no report yet: first analysis in progress")
+	}
+	return s.report, s.finished, nil
+}
+
+func (s *driftServer) serveMetrics(w http.ResponseWriter, _ *http.Request) {
+	report, finished, err := s.latest()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	if err := writeMetrics(w, report.Mode, report.opts, report.res, finished); err != nil {
+		logger.Warn("writing /metrics", "err", err)
+	}
+}
+
+func (s *driftServer) serveReport(w http.ResponseWriter, _ *http.Request) {
+	report, _, err := s.latest()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := writeJSONStream(w, *report); err != nil {
+		logger.Warn("writing /report", "err", err)
+	}
+}
@@ -0,0 +1,216 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/Hru-s/driftwatch/internal/model"
+)
+
+// SeverityThresholdError is returned by Run when a finding at or above
+// Options.FailOnSeverity survives filtering. The CLI maps it to a distinct
+// exit code so CI can tell "policy gate failed" apart from runtime errors.
+type SeverityThresholdError struct {
+	Threshold model.Severity
+	Highest   model.Severity
+}
+
+func (e *SeverityThresholdError) Error() string {
+	return fmt.Sprintf("drift at severity %s found (fail-on-severity=%s)", e.Highest, e.Threshold)
+}
+
+// highestSeverity returns the most severe finding in the (already filtered)
+// report, or "" when the report has no findings.
+func highestSeverity(r Report) model.Severity {
+	var highest model.Severity
+	bump := func(s model.Severity) {
+		if s.Rank() > highest.Rank() {
+			highest = s
+		}
+	}
+
+	for _, sp := range r.RBAC.Extra {
+		for _, p := range sp.Permissions {
+			bump(p.Severity)
+		}
+	}
+	if len(r.RBAC.Missing) > 0 || len(r.RBAC.Renamed) > 0 {
+		bump(model.SeverityLow)
+	}
+	for _, c := range r.RBAC.ResourceNames {
+		bump(resourceNameChangeSeverity(c, r.opts.severity))
+	}
+
+	if len(r.NetworkPolicy.Missing) > 0 || len(r.NetworkPolicy.Changed) > 0 {
+		bump(model.SeverityMedium)
+	}
+	if len(r.NetworkPolicy.Extra) > 0 {
+		bump(model.SeverityLow)
+	}
+
+	for _, e := range r.PSA.Extra {
+		bump(psaEntrySeverity(e))
+	}
+	if len(r.PSA.Incomparable) > 0 {
+		bump(model.SeverityHigh)
+	}
+	if len(r.PSA.ManagedBy) > 0 {
+		bump(model.SeverityMedium)
+	}
+	if len(r.PSA.Missing) > 0 {
+		bump(model.SeverityLow)
+	}
+
+	for _, f := range r.RoleAudit {
+		bump(f.Severity)
+	}
+	if len(r.DanglingBindings) > 0 {
+		bump(model.SeverityMedium)
+	}
+	for _, f := range r.NetPolAudit {
+		bump(f.Severity)
+	}
+	for _, f := range r.SelectorAudit {
+		bump(f.Severity)
+	}
+	for _, c := range r.Openness {
+		bump(netPolOpennessSeverity(c))
+	}
+	for _, g := range r.DefaultDeny {
+		bump(defaultDenySeverity(g))
+	}
+	for _, v := range r.Rego {
+		bump(v.Severity)
+	}
+	if sc := r.StorageClasses; sc != nil {
+		for _, ch := range sc.Changed {
+			if ch.DefaultChanged {
+				bump(model.SeverityMedium)
+			}
+		}
+		if len(sc.Missing) > 0 || len(sc.Extra) > 0 || len(sc.Changed) > 0 {
+			bump(model.SeverityLow)
+		}
+	}
+	if rq := r.ResourceQuotas; rq != nil {
+		if len(rq.Missing) > 0 || len(rq.Changed) > 0 {
+			bump(model.SeverityMedium)
+		}
+		if len(rq.Extra) > 0 {
+			bump(model.SeverityLow)
+		}
+	}
+	if lr := r.LimitRanges; lr != nil {
+		if len(lr.Missing) > 0 || len(lr.Changed) > 0 {
+			bump(model.SeverityMedium)
+		}
+		if len(lr.Extra) > 0 {
+			bump(model.SeverityLow)
+		}
+	}
+	for _, d := range r.AdmissionWebhooks {
+		bump(webhookConfigSeverity(d))
+	}
+	if sa := r.ServiceAccounts; sa != nil {
+		for _, e := range sa.Extra {
+			bump(saEntrySeverity(e))
+		}
+		if len(sa.Missing) > 0 {
+			bump(model.SeverityLow)
+		}
+	}
+	if len(r.Images) > 0 {
+		bump(model.SeverityMedium)
+	}
+	if len(r.LastApplied) > 0 {
+		bump(model.SeverityMedium)
+	}
+	for _, v := range r.RoleSubjects {
+		if v.Drifted {
+			bump(model.SeverityLow)
+		}
+	}
+	if len(r.LabelDrift) > 0 || len(r.Bindings) > 0 {
+		bump(model.SeverityLow)
+	}
+
+	return highest
+}
+
+// psaEntrySeverity rates a PSA entry from the Extra (weaker) bucket.
+func psaEntrySeverity(e model.PSADriftEntry) model.Severity {
+	switch e.DriftType {
+	case "exempted":
+		return model.SeverityHigh
+	case "weaker":
+		if e.Live == model.PSALevelPrivileged || e.Live == "" {
+			return model.SeverityHigh
+		}
+		return model.SeverityMedium
+	case "different":
+		return model.SeverityMedium
+	default:
+		return model.SeverityLow
+	}
+}
+
+// netPolOpennessSeverity rates a newly open NetworkPolicy direction: one
+// that admits every peer on every port is high, every peer on some ports
+// medium.
+func netPolOpennessSeverity(c model.NetPolOpennessChange) model.Severity {
+	if c.Live == model.NetPolOpen {
+		return model.SeverityHigh
+	}
+	return model.SeverityMedium
+}
+
+// defaultDenySeverity rates a default-deny gap: losing the baseline's
+// default-deny is a regression; one that never existed is advisory.
+func defaultDenySeverity(g model.DefaultDenyGap) model.Severity {
+	if g.DriftType == "lost" {
+		return model.SeverityHigh
+	}
+	return model.SeverityLow
+}
+
+// webhookConfigSeverity rates one configuration's webhook drift. A webhook
+// that is gone, or whose failures are now ignored, no longer guarantees its
+// check runs.
+func webhookConfigSeverity(d model.WebhookConfigDrift) model.Severity {
+	if len(d.Missing) > 0 {
+		return model.SeverityHigh
+	}
+	for _, c := range d.Changed {
+		for _, f := range c.Fields {
+			if f.Field == "failurePolicy" && f.Live == "Ignore" {
+				return model.SeverityHigh
+			}
+		}
+	}
+	if len(d.Changed) > 0 {
+		return model.SeverityMedium
+	}
+	return model.SeverityLow
+}
+
+// saEntrySeverity rates a ServiceAccount entry from the Extra bucket: a
+// token newly mounted into every pod of the account is a regression.
+func saEntrySeverity(e model.ServiceAccountDriftEntry) model.Severity {
+	if e.DriftType == "weaker" {
+		return model.SeverityMedium
+	}
+	return model.SeverityLow
+}
+
+// resourceNameChangeSeverity classifies added names like extra permissions;
+// a change that only removes names is low.
+func resourceNameChangeSeverity(c model.ResourceNameChange, sev model.SeverityConfig) model.Severity {
+	highest := model.SeverityLow
+	for _, name := range c.Added {
+		p := c.Grant()
+		p.ResourceName = name
+		if s := sev.ClassifySubjectPermission(c.Subject, p); s.Rank() > highest.Rank() {
+			highest = s
+		}
+	}
+	return highest
+}
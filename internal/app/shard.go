@@ -0,0 +1,119 @@
+package app
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+
+	"github.com/Hru-s/driftwatch/internal/diff"
+	"github.com/Hru-s/driftwatch/internal/model"
+)
+
+// shardSpec selects one of N namespace shards (-shard i/N). Namespaced
+// findings go to shard hash(namespace) % N; cluster-scoped findings always
+// go to shard 0, so the union of all shard reports equals a full run.
+type shardSpec struct {
+	index, count int
+}
+
+func parseShard(s string) (shardSpec, error) {
+	i, n, ok := strings.Cut(s, "/")
+	if !ok {
+		return shardSpec{}, fmt.Errorf("invalid shard %q (want i/N)", s)
+	}
+	index, err1 := strconv.Atoi(strings.TrimSpace(i))
+	count, err2 := strconv.Atoi(strings.TrimSpace(n))
+	if err1 != nil || err2 != nil || count < 1 || index < 0 || index >= count {
+		return shardSpec{}, fmt.Errorf("invalid shard %q (want i/N with 0 <= i < N)", s)
+	}
+	return shardSpec{index: index, count: count}, nil
+}
+
+// owns reports whether a finding in namespace ns ("" or "*" for
+// cluster-scoped) belongs to this shard.
+func (s shardSpec) owns(ns string) bool {
+	if ns == "" || ns == "*" {
+		return s.index == 0
+	}
+	h := fnv.New32a()
+	h.Write([]byte(ns))
+	return int(h.Sum32()%uint32(s.count)) == s.index
+}
+
+// applyShard drops every finding that belongs to another shard.
+func applyShard(s shardSpec, res *driftResults) {
+	res.RBAC.Extra = shardRBACBucket(s, res.RBAC.Extra)
+	res.RBAC.Missing = shardRBACBucket(s, res.RBAC.Missing)
+	// the ClusterRole view is cluster-scoped and kept whole on shard 0
+	res.RoleSubjects = keepIf(res.RoleSubjects, func(model.ClusterRoleSubjects) bool { return s.owns("") })
+	res.RBAC.Renamed = keepIf(res.RBAC.Renamed, func(r model.SubjectRename) bool { return s.owns(r.To.Namespace) })
+	res.RBAC.ResourceNames = keepIf(res.RBAC.ResourceNames, func(c model.ResourceNameChange) bool { return s.owns(c.ScopeNamespace) })
+
+	res.NetPol.Extra = keepIf(res.NetPol.Extra, func(r model.NetPolRef) bool { return s.owns(r.Namespace) })
+	res.NetPol.Missing = keepIf(res.NetPol.Missing, func(r model.NetPolRef) bool { return s.owns(r.Namespace) })
+	res.NetPol.Changed = keepIf(res.NetPol.Changed, func(c model.NetPolChange) bool { return s.owns(c.Namespace) })
+
+	res.PSA.Extra = keepIf(res.PSA.Extra, func(e model.PSADriftEntry) bool { return s.owns(e.Namespace) })
+	res.PSA.Missing = keepIf(res.PSA.Missing, func(e model.PSADriftEntry) bool { return s.owns(e.Namespace) })
+	res.PSA.Unchanged = keepIf(res.PSA.Unchanged, s.owns)
+	res.PSA.ManagedBy = keepIf(res.PSA.ManagedBy, func(m model.PSAManagedByDrift) bool { return s.owns(m.Namespace) })
+
+	res.NetAudit = keepIf(res.NetAudit, func(f model.NetPolRiskFinding) bool { return s.owns(f.Namespace) })
+	res.SelAudit = keepIf(res.SelAudit, func(f model.NetPolRiskFinding) bool { return s.owns(f.Namespace) })
+	res.Openness = keepIf(res.Openness, func(c model.NetPolOpennessChange) bool { return s.owns(c.Namespace) })
+	res.DefaultDeny = keepIf(res.DefaultDeny, func(g model.DefaultDenyGap) bool { return s.owns(g.Namespace) })
+	res.Rego = keepIf(res.Rego, func(v model.RegoViolation) bool { return s.owns(v.Namespace) })
+	res.Quotas.Missing = keepIf(res.Quotas.Missing, func(d model.ResourceQuotaDigest) bool { return s.owns(d.Namespace) })
+	res.Quotas.Extra = keepIf(res.Quotas.Extra, func(d model.ResourceQuotaDigest) bool { return s.owns(d.Namespace) })
+	res.Quotas.Changed = keepIf(res.Quotas.Changed, func(c model.ResourceQuotaChange) bool { return s.owns(c.Namespace) })
+	res.LimitRanges.Missing = keepIf(res.LimitRanges.Missing, func(d model.LimitRangeDigest) bool { return s.owns(d.Namespace) })
+	res.LimitRanges.Extra = keepIf(res.LimitRanges.Extra, func(d model.LimitRangeDigest) bool { return s.owns(d.Namespace) })
+	res.LimitRanges.Changed = keepIf(res.LimitRanges.Changed, func(c model.LimitRangeChange) bool { return s.owns(c.Namespace) })
+	res.ServiceAccounts.Extra = keepIf(res.ServiceAccounts.Extra, func(e model.ServiceAccountDriftEntry) bool { return s.owns(e.Namespace) })
+	res.ServiceAccounts.Missing = keepIf(res.ServiceAccounts.Missing, func(e model.ServiceAccountDriftEntry) bool { return s.owns(e.Namespace) })
+	res.Images = keepIf(res.Images, func(v model.ImageViolation) bool { return s.owns(v.Namespace) })
+	res.LastApplied = keepIf(res.LastApplied, func(d model.LastAppliedDrift) bool {
+		if d.Kind == "Namespace" {
+			return s.owns(d.Name)
+		}
+		return s.owns(d.Namespace)
+	})
+	res.Labels = keepIf(res.Labels, func(c model.LabelChange) bool { return s.owns(c.Namespace) })
+	res.Bindings = keepIf(res.Bindings, func(c model.BindingChange) bool { return s.owns(c.Role.Namespace) })
+	res.Dangling = keepIf(res.Dangling, func(d model.DanglingBinding) bool { return s.owns(d.Namespace) })
+
+	if s.index != 0 {
+		res.RoleAudit = nil
+		res.Storage = diff.StorageClassDrift{}
+		res.Webhooks = diff.WebhookDrift{}
+		res.Warnings = nil
+	}
+	if res.Workload != nil && !s.owns(res.Workload.ServiceAccount.Namespace) {
+		res.Workload = nil
+	}
+}
+
+// shardRBACBucket keeps each subject's permissions scoped to an owned
+// namespace; a subject may therefore appear in several shards with
+// disjoint permission sets.
+func shardRBACBucket(s shardSpec, bucket map[model.SubjectKey][]model.Permission) map[model.SubjectKey][]model.Permission {
+	out := make(map[model.SubjectKey][]model.Permission, len(bucket))
+	for subj, perms := range bucket {
+		kept := keepIf(perms, func(p model.Permission) bool { return s.owns(p.ScopeNamespace) })
+		if len(kept) > 0 {
+			out[subj] = kept
+		}
+	}
+	return out
+}
+
+func keepIf[T any](in []T, keep func(T) bool) []T {
+	var out []T
+	for _, v := range in {
+		if keep(v) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
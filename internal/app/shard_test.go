@@ -0,0 +1,59 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/Hru-s/driftwatch/internal/collectors"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// A shard lists namespaced kinds only in the namespaces it owns, and keeps
+// owned baseline namespaces the cluster no longer has.
+func TestShardScopesCollection(t *testing.T) {
+	var objs []runtime.Object
+	for i := 0; i < 8; i++ {
+		ns := fmt.Sprintf("ns-%d", i)
+		objs = append(objs,
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: ns}},
+			&corev1.ResourceQuota{ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: "q"}})
+	}
+	client := fake.NewSimpleClientset(objs...)
+	shard := shardSpec{index: 1, count: 3}
+
+	cfg, scope, err := scopeNamespaces(context.Background(), client, collectors.Config{}, Options{Shard: "1/3"}, "live cluster")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, ns := range cfg.Namespaces {
+		if !shard.owns(ns.Name) {
+			t.Errorf("scoped to %s, which shard 1/3 does not own", ns.Name)
+		}
+	}
+	client.ClearActions()
+
+	quotas, err := collectors.CollectQuotaFromCluster(context.Background(), client, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(quotas) != len(cfg.Namespaces) {
+		t.Errorf("collected %d quotas, want one per owned namespace (%d)", len(quotas), len(cfg.Namespaces))
+	}
+	for _, a := range client.Actions() {
+		if l, ok := a.(k8stesting.ListAction); ok && !shard.owns(l.GetNamespace()) {
+			t.Errorf("%s listed in namespace %q outside the shard", a.GetResource().Resource, l.GetNamespace())
+		}
+	}
+
+	for _, ns := range []string{"gone-1", "gone-2", "gone-3", "gone-4"} {
+		if scope.has(ns) != shard.owns(ns) {
+			t.Errorf("baseline namespace %s in scope = %v, want %v", ns, scope.has(ns), shard.owns(ns))
+		}
+	}
+}
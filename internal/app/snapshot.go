@@ -0,0 +1,252 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Hru-s/driftwatch/internal/audit"
+	"github.com/Hru-s/driftwatch/internal/collectors"
+	"github.com/Hru-s/driftwatch/internal/diff"
+	"github.com/Hru-s/driftwatch/internal/kube"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// defaultSnapshotName is the file -snapshot-out writes inside a directory.
+const defaultSnapshotName = "snapshot.yaml"
+
+// snapshotPath resolves -snapshot-out: an existing directory, or a path
+// ending in a separator, receives snapshot.yaml; anything else is the file.
+func snapshotPath(out string) (string, error) {
+	if strings.HasSuffix(out, "/") || strings.HasSuffix(out, string(filepath.Separator)) {
+		if err := os.MkdirAll(out, 0o755); err != nil {
+			return "", err
+		}
+		return filepath.Join(out, defaultSnapshotName), nil
+	}
+	if info, err := os.Stat(out); err == nil && info.IsDir() {
+		return filepath.Join(out, defaultSnapshotName), nil
+	}
+	return out, nil
+}
+
+// writeSnapshot saves the live state to -snapshot-out, if set. A partial
+// collection is not saved: as a baseline it would report everything that
+// was not collected as missing.
+func writeSnapshot(opts Options, partial *partialRun, live clusterSnapshots) error {
+	if opts.SnapshotOut == "" {
+		return nil
+	}
+	if len(partial.sections) > 0 {
+		fmt.Fprintln(os.Stderr, "snapshot not written: collection is partial")
+		return nil
+	}
+	path, err := snapshotPath(opts.SnapshotOut)
+	if err != nil {
+		return fmt.Errorf("-snapshot-out: %w", err)
+	}
+	err = collectors.WriteSnapshotFile(path, collectors.Snapshot{
+		CapturedAt: time.Now(),
+		RBAC:       live.rbac,
+		NetPol:     live.netpol,
+		PSA:        live.psa,
+	})
+	if err != nil {
+		return fmt.Errorf("-snapshot-out: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "snapshot written to %s\n", path)
+	return nil
+}
+
+// captureSnapshot is a single-mode run without -baseline: it collects the
+// live cluster and writes -snapshot-out, without a report.
+func captureSnapshot(ctx context.Context, opts Options) error {
+	if err := checkLiveCluster(opts); err != nil {
+		return err
+	}
+	client, err := kube.BuildClient(opts.Kubeconfig, opts.Context, clientConfig(opts))
+	if err != nil {
+		return fmt.Errorf("creating client for live cluster: %w", err)
+	}
+
+	ctx, cancel := runContext(ctx, opts)
+	defer cancel()
+	partial := &partialRun{ctx: ctx, opts: opts}
+
+	cfg, _, err := scopeNamespaces(ctx, client, collectorConfig(opts), opts, "live cluster")
+	if err != nil {
+		return err
+	}
+
+	var live clusterSnapshots
+	g, gctx := errgroup.WithContext(ctx)
+	collectCluster(gctx, g, client, cfg, "live cluster", newProgress(opts), partial, &live)
+	if err := g.Wait(); err != nil {
+		return err
+	}
+	if len(partial.sections) > 0 {
+		return &IncompleteRunError{MaxRuntime: opts.MaxRuntime, Sections: partial.sections}
+	}
+	return writeSnapshot(opts, partial, live)
+}
+
+// manifestOnlyFlag returns the first enabled flag that compares baseline
+// objects a snapshot does not hold, or "".
+func manifestOnlyFlag(opts Options) string {
+	for _, f := range []struct {
+		enabled bool
+		name    string
+	}{
+		{opts.ValidateBaselineSchema, "-validate-baseline-schema"},
+		{opts.StorageClasses, "-storage-classes"},
+		{opts.ResourceQuotas, "-resource-quotas"},
+		{opts.LimitRanges, "-limit-ranges"},
+		{opts.AdmissionWebhooks, "-admission-webhooks"},
+		{opts.ServiceAccounts, "-service-accounts"},
+		{opts.CheckImages, "-check-images"},
+	} {
+		if f.enabled {
+			return f.name
+		}
+	}
+	return ""
+}
+
+// clusterOnlyFlag returns the first enabled flag that needs a live cluster,
+// which snapshot-compare mode does not have, or "".
+func clusterOnlyFlag(opts Options) string {
+	if f := manifestOnlyFlag(opts); f != "" {
+		return f
+	}
+	for _, f := range []struct {
+		enabled bool
+		name    string
+	}{
+		{opts.Workload != "", "-workload"},
+		{opts.ExpectedSAAnnotation != "", "-expected-sa-annotation"},
+		{opts.LastApplied, "-baseline-last-applied-config"},
+		{opts.NamespaceSelector != "", "-namespace-selector"},
+		{opts.EmitEvents, "-emit-events"},
+	} {
+		if f.enabled {
+			return f.name
+		}
+	}
+	return ""
+}
+
+// loadSnapshot reads a snapshot-compare input, which must be a snapshot
+// file.
+func loadSnapshot(flag, path string, cfg collectors.Config) (collectors.Snapshot, error) {
+	snap, ok, err := collectors.LoadSnapshotFile(path, cfg)
+	if err != nil {
+		return collectors.Snapshot{}, fmt.Errorf("%s: %w", flag, err)
+	}
+	if !ok {
+		return collectors.Snapshot{}, fmt.Errorf("%s: %s is not a %s (write one with -snapshot-out)", flag, path, collectors.SnapshotKind)
+	}
+	return snap, nil
+}
+
+// runSnapshotCompare diffs two snapshot files written by -snapshot-out,
+// snapshot A taking the place of the baseline. No cluster is contacted.
+func runSnapshotCompare(ctx context.Context, opts Options) (string, driftResults, error) {
+	if opts.SnapshotA == "" || opts.SnapshotB == "" {
+		return "", driftResults{}, fmt.Errorf("both -snapshot-a and -snapshot-b are required for snapshot-compare mode")
+	}
+	if flag := clusterOnlyFlag(opts); flag != "" {
+		return "", driftResults{}, fmt.Errorf("%s is not supported in snapshot-compare mode", flag)
+	}
+
+	a, err := loadSnapshot("-snapshot-a", opts.SnapshotA, collectorConfig(opts))
+	if err != nil {
+		return "", driftResults{}, err
+	}
+	b, err := loadSnapshot("-snapshot-b", opts.SnapshotB, collectorConfig(opts))
+	if err != nil {
+		return "", driftResults{}, err
+	}
+	rbacA, netpolA, psaA := a.RBAC, a.NetPol, a.PSA
+	rbacB, netpolB, psaB := b.RBAC, b.NetPol, b.PSA
+
+	// -------- RBAC --------
+	if err := expandGroups(opts, rbacA, rbacB); err != nil {
+		return "", driftResults{}, err
+	}
+	normalizeVerbs(opts, rbacA, rbacB)
+	rbacDrift := diff.DiffRBAC(rbacA, rbacB)
+	if opts.RBACSemantic {
+		diff.DropCoveredPermissions(&rbacDrift, rbacA, rbacB)
+	}
+	if opts.DetectRenames {
+		diff.DetectSubjectRenames(&rbacDrift, rbacA, rbacB)
+	}
+	if opts.ResourceNamesAsSet {
+		diff.GroupResourceNameChanges(&rbacDrift, rbacA, rbacB)
+	}
+
+	// ------ NetworkPolicy ------
+	netpolDrift := diff.DiffNetworkPolicies(netpolA, netpolB)
+
+	// ------ PSA (Pod Security Admission) ------
+	exemptions, err := loadPSAExemptions(opts)
+	if err != nil {
+		return "", driftResults{}, err
+	}
+	psaDrift := diff.DiffPSA(psaA, psaB, exemptions.Namespaces)
+	if opts.PSAManagedBy != "" {
+		key, want := parsePSAManagedBy(opts.PSAManagedBy)
+		diff.CheckPSAManagedBy(&psaDrift, psaA, psaB, key, want)
+	}
+
+	res := driftResults{RBAC: rbacDrift, NetPol: netpolDrift, PSA: psaDrift}
+	res.addWarnings("psa exemptions", psaExemptionWarnings(exemptions))
+	if opts.Stats {
+		res.Stats = &collectionStats{
+			Baseline: snapshotStatsOf(rbacA, netpolA, psaA),
+			Live:     snapshotStatsOf(rbacB, netpolB, psaB),
+		}
+	}
+
+	if opts.BindingDiff {
+		res.Bindings = diff.DiffBindings(rbacA, rbacB)
+	}
+	if opts.ClusterRoleView {
+		res.RoleSubjects = diff.ClusterRoleSubjects(rbacA, rbacB)
+	}
+	res.Labels = append(res.Labels, diff.DiffNetPolLabels(netpolA, netpolB, opts.CompareLabels)...)
+	res.Labels = append(res.Labels, diff.DiffNamespaceLabels(psaA, psaB, opts.CompareLabels)...)
+	res.Dangling = append(danglingBindings(rbacA, "baseline"), danglingBindings(rbacB, "live")...)
+	if opts.AuditRoles {
+		res.RoleAudit = append(res.RoleAudit, audit.AuditClusterRoles(rbacA, "baseline")...)
+		res.RoleAudit = append(res.RoleAudit, audit.AuditClusterRoles(rbacB, "live")...)
+	}
+	if opts.AuditIPBlocks {
+		res.NetAudit = append(res.NetAudit, audit.AuditNetPolIPBlocks(netpolA, "baseline", ipBlockMaxPrefix(opts))...)
+		res.NetAudit = append(res.NetAudit, audit.AuditNetPolIPBlocks(netpolB, "live", ipBlockMaxPrefix(opts))...)
+	}
+	if opts.AuditNetPolSelectors {
+		res.SelAudit = append(res.SelAudit, audit.AuditNetPolNamespaceSelectors(netpolA, "baseline", psaA)...)
+		res.SelAudit = append(res.SelAudit, audit.AuditNetPolNamespaceSelectors(netpolB, "live", psaB)...)
+	}
+	if opts.NetPolOpenness {
+		res.Openness = diff.DiffNetPolOpenness(netpolA, netpolB)
+	}
+	if opts.DefaultDenyCoverage {
+		res.DefaultDeny = diff.DiffDefaultDeny(netpolA, netpolB, psaB)
+	}
+	if opts.RegoPolicy != "" {
+		res.Rego, err = audit.AuditRego(ctx, opts.RegoPolicy, audit.NewRegoInput("live", rbacB, netpolB, psaB))
+		if err != nil {
+			return "", driftResults{}, fmt.Errorf("-rego: %w", err)
+		}
+	}
+
+	modeLabel := fmt.Sprintf("snapshot-compare (snapshot A captured %s vs snapshot B captured %s)",
+		a.CapturedAt.Format(time.RFC3339), b.CapturedAt.Format(time.RFC3339))
+	return modeLabel, res, nil
+}
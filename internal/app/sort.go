@@ -0,0 +1,217 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Hru-s/driftwatch/internal/model"
+)
+
+// Finding orders accepted by -sort. The empty order keeps each section's
+// default ordering (alphabetical by subject / namespace).
+const (
+	sortBySeverity  = "severity"
+	sortByNamespace = "namespace"
+	sortByName      = "name"
+	sortBySubject   = "subject"
+)
+
+// parseSortOrder validates a -sort value.
+func parseSortOrder(s string) (string, error) {
+	switch order := strings.ToLower(strings.TrimSpace(s)); order {
+	case "", sortBySeverity, sortByNamespace, sortByName, sortBySubject:
+		return order, nil
+	default:
+		return "", fmt.Errorf("unknown sort order %q (supported: severity, namespace, name, subject)", s)
+	}
+}
+
+// findingSortKey holds the attributes a finding can be ordered by; fields a
+// finding does not have are left empty.
+type findingSortKey struct {
+	severity  model.Severity
+	namespace string
+	name      string
+	subject   string
+}
+
+// sortFindings reorders items by the -sort order. The sort is stable, so
+// ties keep the section's default order; severity sorts most severe first.
+func sortFindings[T any](items []T, order string, key func(T) findingSortKey) {
+	if order == "" || len(items) < 2 {
+		return
+	}
+	keys := make(map[int]findingSortKey, len(items))
+	idx := make([]int, len(items))
+	for i := range items {
+		idx[i] = i
+		keys[i] = key(items[i])
+	}
+	sort.SliceStable(idx, func(i, j int) bool {
+		a, b := keys[idx[i]], keys[idx[j]]
+		switch order {
+		case sortBySeverity:
+			return a.severity.Rank() > b.severity.Rank()
+		case sortByNamespace:
+			return a.namespace < b.namespace
+		case sortByName:
+			return a.name < b.name
+		default:
+			return a.subject < b.subject
+		}
+	})
+	sorted := make([]T, len(items))
+	for i, k := range idx {
+		sorted[i] = items[k]
+	}
+	copy(items, sorted)
+}
+
+// maxPermissionSeverity returns the most severe classification of subj's perms.
+func maxPermissionSeverity(subj model.SubjectKey, perms []model.Permission, sev model.SeverityConfig) model.Severity {
+	var highest model.Severity
+	for _, p := range perms {
+		if s := sev.ClassifySubjectPermission(subj, p); s.Rank() > highest.Rank() {
+			highest = s
+		}
+	}
+	return highest
+}
+
+func subjectPermissionsSortKey(sp SubjectPermissions) findingSortKey {
+	var highest model.Severity
+	for _, p := range sp.Permissions {
+		if p.Severity.Rank() > highest.Rank() {
+			highest = p.Severity
+		}
+	}
+	return findingSortKey{
+		severity:  highest,
+		namespace: sp.Subject.Namespace,
+		name:      sp.Subject.Name,
+		subject:   sp.Subject.String(),
+	}
+}
+
+func netPolRefSortKey(r model.NetPolRef) findingSortKey {
+	return findingSortKey{severity: model.SeverityLow, namespace: r.Namespace, name: r.Name}
+}
+
+func netPolChangeSortKey(c model.NetPolChange) findingSortKey {
+	return findingSortKey{severity: model.SeverityMedium, namespace: c.Namespace, name: c.Name}
+}
+
+func psaEntrySortKey(e model.PSADriftEntry) findingSortKey {
+	return findingSortKey{severity: psaEntrySeverity(e), namespace: e.Namespace, name: e.Namespace}
+}
+
+func psaManagedBySortKey(m model.PSAManagedByDrift) findingSortKey {
+	return findingSortKey{severity: model.SeverityMedium, namespace: m.Namespace, name: m.Namespace}
+}
+
+func roleRiskSortKey(f model.RoleRiskFinding) findingSortKey {
+	return findingSortKey{severity: f.Severity, name: f.Role}
+}
+
+func netPolRiskSortKey(f model.NetPolRiskFinding) findingSortKey {
+	return findingSortKey{severity: f.Severity, namespace: f.Namespace, name: f.Name}
+}
+
+func netPolOpennessSortKey(c model.NetPolOpennessChange) findingSortKey {
+	return findingSortKey{severity: netPolOpennessSeverity(c), namespace: c.Namespace, name: c.Name}
+}
+
+func defaultDenySortKey(g model.DefaultDenyGap) findingSortKey {
+	return findingSortKey{severity: defaultDenySeverity(g), namespace: g.Namespace, name: g.Namespace}
+}
+
+func labelChangeSortKey(c model.LabelChange) findingSortKey {
+	return findingSortKey{severity: model.SeverityLow, namespace: c.Namespace, name: c.Name}
+}
+
+func bindingChangeSortKey(c model.BindingChange) findingSortKey {
+	k := findingSortKey{severity: model.SeverityLow, namespace: c.Role.Namespace, name: c.Role.Name}
+	if subjects := append(append([]model.SubjectKey(nil), c.Added...), c.Removed...); len(subjects) > 0 {
+		k.subject = subjects[0].String()
+	}
+	return k
+}
+
+func danglingBindingSortKey(d model.DanglingBinding) findingSortKey {
+	k := findingSortKey{severity: model.SeverityMedium, namespace: d.Namespace, name: d.Name}
+	if len(d.Subjects) > 0 {
+		k.subject = d.Subjects[0].String()
+	}
+	return k
+}
+
+func lastAppliedSortKey(d model.LastAppliedDrift) findingSortKey {
+	return findingSortKey{severity: model.SeverityMedium, namespace: d.Namespace, name: d.Name}
+}
+
+func quotaDigestSortKey(d model.ResourceQuotaDigest) findingSortKey {
+	return findingSortKey{severity: model.SeverityMedium, namespace: d.Namespace, name: d.Name}
+}
+
+func quotaChangeSortKey(c model.ResourceQuotaChange) findingSortKey {
+	return findingSortKey{severity: model.SeverityMedium, namespace: c.Namespace, name: c.Name}
+}
+
+func limitRangeDigestSortKey(d model.LimitRangeDigest) findingSortKey {
+	return findingSortKey{severity: model.SeverityMedium, namespace: d.Namespace, name: d.Name}
+}
+
+func limitRangeChangeSortKey(c model.LimitRangeChange) findingSortKey {
+	return findingSortKey{severity: model.SeverityMedium, namespace: c.Namespace, name: c.Name}
+}
+
+func webhookConfigSortKey(d model.WebhookConfigDrift) findingSortKey {
+	return findingSortKey{severity: webhookConfigSeverity(d), name: d.Name}
+}
+
+func saEntrySortKey(e model.ServiceAccountDriftEntry) findingSortKey {
+	return findingSortKey{severity: saEntrySeverity(e), namespace: e.Namespace, name: e.Name}
+}
+
+func imageViolationSortKey(v model.ImageViolation) findingSortKey {
+	return findingSortKey{severity: model.SeverityMedium, namespace: v.Namespace, name: v.Image}
+}
+
+func subjectRenameSortKey(sev model.SeverityConfig) func(model.SubjectRename) findingSortKey {
+	return func(r model.SubjectRename) findingSortKey {
+		return findingSortKey{
+			severity:  maxPermissionSeverity(r.To, r.Permissions, sev),
+			namespace: r.To.Namespace,
+			name:      r.To.Name,
+			subject:   r.To.String(),
+		}
+	}
+}
+
+func resourceNameChangeSortKey(sev model.SeverityConfig) func(model.ResourceNameChange) findingSortKey {
+	return func(c model.ResourceNameChange) findingSortKey {
+		return findingSortKey{
+			severity:  resourceNameChangeSeverity(c, sev),
+			namespace: c.Subject.Namespace,
+			name:      c.Subject.Name,
+			subject:   c.Subject.String(),
+		}
+	}
+}
+
+func regoViolationSortKey(v model.RegoViolation) findingSortKey {
+	return findingSortKey{severity: v.Severity, namespace: v.Namespace, name: v.Name}
+}
+
+func clusterRoleSubjectsSortKey(v model.ClusterRoleSubjects) findingSortKey {
+	k := findingSortKey{name: v.Role}
+	if v.Drifted {
+		k.severity = model.SeverityLow
+	}
+	if grants := append(append([]model.ClusterRoleGrant(nil), v.Added...), v.Removed...); len(grants) > 0 {
+		k.namespace = grants[0].Namespace
+		k.subject = grants[0].Subject.String()
+	}
+	return k
+}
@@ -0,0 +1,100 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+)
+
+// DriftFoundError is returned by Run when -fail-on-drift finds drift (or,
+// with "new", drift the previous run did not have). The CLI maps it to the
+// same exit code as SeverityThresholdError.
+type DriftFoundError struct {
+	Findings int
+	OnlyNew  bool
+}
+
+func (e *DriftFoundError) Error() string {
+	if e.OnlyNew {
+		return fmt.Sprintf("%d new drift findings since the previous run (fail-on-drift=new)", e.Findings)
+	}
+	return fmt.Sprintf("%d drift findings (fail-on-drift=any)", e.Findings)
+}
+
+// checkDriftTrigger validates a -fail-on-drift or -notify-on value; "new"
+// needs the previous run's findings from -baseline-state.
+func checkDriftTrigger(flag, value string, opts Options) error {
+	switch value {
+	case "", "any":
+		return nil
+	case "new":
+		if opts.BaselineState == "" {
+			return fmt.Errorf("%s new requires -baseline-state", flag)
+		}
+		return nil
+	default:
+		return fmt.Errorf("%s must be any or new, got %q", flag, value)
+	}
+}
+
+// loadBaselineState reads the findings -baseline-state recorded. A missing
+// file is the first run: there are no previous findings.
+func loadBaselineState(path string) (driftFindingsJSON, error) {
+	var findings driftFindingsJSON
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return findings, nil
+	}
+	if err != nil {
+		return findings, err
+	}
+	if err := json.Unmarshal(data, &findings); err != nil {
+		return findings, fmt.Errorf("decode %s: %w", path, err)
+	}
+	return findings, nil
+}
+
+func writeBaselineState(path string, findings driftFindingsJSON) error {
+	var buf bytes.Buffer
+	if err := writeJSON(&buf, findings); err != nil {
+		return err
+	}
+	return os.WriteFile(path, buf.Bytes(), 0o644)
+}
+
+// trackBaselineState classifies the findings of res against the previous
+// run's into res.History and returns the findings to record once the run
+// has been reported. ok is false for a partial run, which would show every
+// uncollected finding as resolved and is neither compared nor recorded.
+func trackBaselineState(modeLabel string, opts Options, res *driftResults) (findings driftFindingsJSON, ok bool, err error) {
+	if len(res.Incomplete) > 0 {
+		fmt.Fprintln(os.Stderr, "baseline state not updated: report is partial")
+		return findings, false, nil
+	}
+	prev, err := loadBaselineState(opts.BaselineState)
+	if err != nil {
+		return findings, false, err
+	}
+	findings = historyFindings(modeLabel, opts, *res)
+	res.History = diffReports(opts.BaselineState, prev, findings)
+	res.History.since = "previous run"
+	return findings, true, nil
+}
+
+// driftGate applies -fail-on-drift to a reported run.
+func driftGate(modeLabel string, opts Options, res driftResults) error {
+	switch opts.FailOnDrift {
+	case "any":
+		if n := len(findingKeys(historyFindings(modeLabel, opts, res))); n > 0 {
+			return &DriftFoundError{Findings: n}
+		}
+	case "new":
+		if res.History != nil && len(res.History.New) > 0 {
+			return &DriftFoundError{Findings: len(res.History.New), OnlyNew: true}
+		}
+	}
+	return nil
+}
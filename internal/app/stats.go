@@ -0,0 +1,91 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/Hru-s/driftwatch/internal/model"
+)
+
+// snapshotStats is the inventory of one side of the comparison, counted
+// from the collected snapshots before any diffing or filtering.
+type snapshotStats struct {
+	Subjects                   int            `json:"subjects"`
+	Permissions                int            `json:"permissions"` // distinct across all subjects
+	NetworkPolicies            int            `json:"networkPolicies"`
+	NetworkPoliciesByNamespace map[string]int `json:"networkPoliciesByNamespace,omitempty"`
+	Namespaces                 int            `json:"namespaces"`
+	PSALabeledNamespaces       int            `json:"psaLabeledNamespaces"`
+}
+
+// collectionStats is the -stats block. In cluster-compare mode Baseline is
+// cluster A and Live is cluster B.
+type collectionStats struct {
+	Baseline snapshotStats `json:"baseline"`
+	Live     snapshotStats `json:"live"`
+}
+
+func snapshotStatsOf(rbac *model.RBACSnapshot, netpol *model.NetPolSnapshot, psa []model.NamespacePSA) snapshotStats {
+	st := snapshotStats{
+		Subjects:                   len(rbac.Subjects),
+		NetworkPolicies:            len(netpol.Items),
+		NetworkPoliciesByNamespace: map[string]int{},
+	}
+
+	distinct := map[model.Permission]struct{}{}
+	for _, perms := range rbac.Subjects {
+		for p := range perms {
+			distinct[p] = struct{}{}
+		}
+	}
+	st.Permissions = len(distinct)
+
+	for _, d := range netpol.Items {
+		st.NetworkPoliciesByNamespace[d.Namespace]++
+	}
+	// baseline directories may declare a namespace more than once
+	labeled := map[string]bool{}
+	for _, ns := range psa {
+		labeled[ns.Namespace] = labeled[ns.Namespace] || ns.Enforce != "" || ns.Audit != "" || ns.Warn != ""
+	}
+	st.Namespaces = len(labeled)
+	for _, l := range labeled {
+		if l {
+			st.PSALabeledNamespaces++
+		}
+	}
+	return st
+}
+
+func printHumanStats(st *collectionStats, baselineLabel, liveLabel string) {
+	fmt.Println(" Collection statistics:")
+	fmt.Printf("  %-28s %12s %12s\n", "", baselineLabel, liveLabel)
+	row := func(name string, b, l int) {
+		fmt.Printf("  %-28s %12d %12d\n", name, b, l)
+	}
+	row("RBAC subjects", st.Baseline.Subjects, st.Live.Subjects)
+	row("distinct permissions", st.Baseline.Permissions, st.Live.Permissions)
+	row("NetworkPolicies", st.Baseline.NetworkPolicies, st.Live.NetworkPolicies)
+	row("namespaces", st.Baseline.Namespaces, st.Live.Namespaces)
+	row("PSA-labeled namespaces", st.Baseline.PSALabeledNamespaces, st.Live.PSALabeledNamespaces)
+
+	namespaces := map[string]struct{}{}
+	for ns := range st.Baseline.NetworkPoliciesByNamespace {
+		namespaces[ns] = struct{}{}
+	}
+	for ns := range st.Live.NetworkPoliciesByNamespace {
+		namespaces[ns] = struct{}{}
+	}
+	if len(namespaces) == 0 {
+		return
+	}
+	sorted := make([]string, 0, len(namespaces))
+	for ns := range namespaces {
+		sorted = append(sorted, ns)
+	}
+	sort.Strings(sorted)
+	fmt.Println("  NetworkPolicies per namespace:")
+	for _, ns := range sorted {
+		fmt.Printf("    %-26s %12d %12d\n", ns, st.Baseline.NetworkPoliciesByNamespace[ns], st.Live.NetworkPoliciesByNamespace[ns])
+	}
+}
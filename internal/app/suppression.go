@@ -0,0 +1,41 @@
+package app
+
+import "sort"
+
+// suppressionNote explains an empty report section whose findings were all
+// hidden by filters, so a clean result can be told apart from a filtered one.
+type suppressionNote struct {
+	Count   int      `json:"count"`
+	Filters []string `json:"filters"`
+}
+
+// filterTally counts the findings each filter removed. A nil *filterTally
+// is valid and records nothing.
+type filterTally struct {
+	total    int
+	byFilter map[string]int
+}
+
+func (t *filterTally) add(filter string, n int) {
+	if t == nil || n == 0 {
+		return
+	}
+	if t.byFilter == nil {
+		t.byFilter = map[string]int{}
+	}
+	t.total += n
+	t.byFilter[filter] += n
+}
+
+// note returns nil when nothing was suppressed.
+func (t *filterTally) note() *suppressionNote {
+	if t == nil || t.total == 0 {
+		return nil
+	}
+	filters := make([]string, 0, len(t.byFilter))
+	for f := range t.byFilter {
+		filters = append(filters, f)
+	}
+	sort.Strings(filters)
+	return &suppressionNote{Count: t.total, Filters: filters}
+}
@@ -0,0 +1,66 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Hru-s/driftwatch/internal/collectors"
+	"github.com/Hru-s/driftwatch/internal/source"
+)
+
+// validateBaseline runs the baseline collectors over -baseline and prints
+// what they read, without contacting a cluster.
+func validateBaseline(ctx context.Context, opts Options) error {
+	if opts.BaselineDir == "" {
+		return fmt.Errorf("-validate-baseline requires -baseline")
+	}
+	cfg := collectorConfig(opts)
+
+	ctx, cancel := runContext(ctx, opts)
+	defer cancel()
+	baselineDir, cleanup, err := source.Resolve(ctx, opts.BaselineDir, opts.BaselineSHA256, cfg.MaxFileBytes())
+	if err != nil {
+		return fmt.Errorf("resolving baseline %s: %w", opts.BaselineDir, err)
+	}
+	defer cleanup()
+
+	if _, fromSnapshot, err := collectors.LoadSnapshotFile(baselineDir, cfg); err != nil {
+		return fmt.Errorf("loading baseline %s: %w", opts.BaselineDir, err)
+	} else if fromSnapshot {
+		return fmt.Errorf("-validate-baseline checks manifest baselines; %s is a snapshot", opts.BaselineDir)
+	}
+
+	inv, err := collectors.InventoryBaselineDir(baselineDir, cfg)
+	if err != nil {
+		return fmt.Errorf("validating baseline %s: %w", opts.BaselineDir, err)
+	}
+
+	fmt.Printf("Baseline: %s\n\n", opts.BaselineDir)
+	for _, c := range []struct {
+		label string
+		n     int
+	}{
+		{"Roles", inv.Roles},
+		{"ClusterRoles", inv.ClusterRoles},
+		{"RoleBindings", inv.RoleBindings},
+		{"ClusterRoleBindings", inv.ClusterRoleBindings},
+		{"NetworkPolicies", inv.NetworkPolicies},
+		{"Namespaces", inv.Namespaces},
+	} {
+		fmt.Printf("  %-20s %d\n", c.label+":", c.n)
+	}
+
+	if len(inv.Skipped) > 0 {
+		fmt.Printf("\nSkipped files (no recognized kinds): %d\n", len(inv.Skipped))
+		for _, f := range inv.Skipped {
+			fmt.Printf("  - %s\n", f)
+		}
+	}
+	if len(inv.Warnings) > 0 {
+		fmt.Printf("\nWarnings (%d):\n", len(inv.Warnings))
+		for _, w := range inv.Warnings {
+			fmt.Printf("  - %s\n", w)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Hru-s/driftwatch/internal/model"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// workloadJSON is the workload-centric view behind -workload: what the pods
+// of one Deployment can do through their ServiceAccount.
+type workloadJSON struct {
+	Workload       string             `json:"workload"`
+	ServiceAccount model.SubjectKey   `json:"serviceAccount"`
+	Permissions    []model.Permission `json:"permissions"`
+	// Extra / Missing compare the effective set against the baseline side.
+	Extra   []model.Permission `json:"extra,omitempty"`
+	Missing []model.Permission `json:"missing,omitempty"`
+}
+
+// resolveWorkload looks up the Deployment named by -workload
+// ("namespace/name") on the live side and computes its ServiceAccount's
+// effective permissions in both snapshots.
+func resolveWorkload(
+	ctx context.Context,
+	workload string,
+	client kubernetes.Interface,
+	baseline, live *model.RBACSnapshot,
+) (*workloadJSON, error) {
+	ns, name, ok := strings.Cut(workload, "/")
+	if !ok || ns == "" || name == "" {
+		return nil, fmt.Errorf("-workload must be namespace/deployment, got %q", workload)
+	}
+
+	deploy, err := client.AppsV1().Deployments(ns).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("getting Deployment %s: %w", workload, err)
+	}
+	saName := deploy.Spec.Template.Spec.ServiceAccountName
+	if saName == "" {
+		saName = "default"
+	}
+	sa := model.SubjectKey{Kind: "ServiceAccount", Name: saName, Namespace: ns}
+
+	livePerms := effectivePermissions(live, sa)
+	basePerms := effectivePermissions(baseline, sa)
+
+	w := &workloadJSON{
+		Workload:       workload,
+		ServiceAccount: sa,
+		Permissions:    sortedPermissions(livePerms),
+	}
+	for p := range livePerms {
+		if _, ok := basePerms[p]; !ok {
+			w.Extra = append(w.Extra, p)
+		}
+	}
+	for p := range basePerms {
+		if _, ok := livePerms[p]; !ok {
+			w.Missing = append(w.Missing, p)
+		}
+	}
+	sortPermissions(w.Extra)
+	sortPermissions(w.Missing)
+	return w, nil
+}
+
+// effectivePermissions unions what sa is granted directly with what it
+// inherits through the implicit ServiceAccount groups.
+func effectivePermissions(snap *model.RBACSnapshot, sa model.SubjectKey) map[model.Permission]struct{} {
+	out := map[model.Permission]struct{}{}
+	if snap == nil {
+		return out
+	}
+	holders := []model.SubjectKey{
+		sa,
+		{Kind: "Group", Name: "system:serviceaccounts"},
+		{Kind: "Group", Name: "system:serviceaccounts:" + sa.Namespace},
+		{Kind: "Group", Name: "system:authenticated"},
+	}
+	for _, h := range holders {
+		for p := range snap.Subjects[h] {
+			out[p] = struct{}{}
+		}
+	}
+	return out
+}
+
+func sortedPermissions(set map[model.Permission]struct{}) []model.Permission {
+	out := make([]model.Permission, 0, len(set))
+	for p := range set {
+		out = append(out, p)
+	}
+	sortPermissions(out)
+	return out
+}
+
+func sortPermissions(perms []model.Permission) {
+	sort.Slice(perms, func(i, j int) bool { return perms[i].String() < perms[j].String() })
+}
+
+// redactWorkload returns w with its ServiceAccount redacted under -redact.
+func redactWorkload(w *workloadJSON, opts Options) *workloadJSON {
+	if w == nil {
+		return nil
+	}
+	out := *w
+	out.ServiceAccount = redactSubject(w.ServiceAccount, opts)
+	return &out
+}
+
+func printHumanWorkload(opts Options, w *workloadJSON) {
+	w = redactWorkload(w, opts)
+	fmt.Printf(" Workload %s runs as %s (%d effective permissions):\n",
+		w.Workload, w.ServiceAccount.String(), len(w.Permissions))
+	for _, p := range w.Permissions {
+		fmt.Printf("    - %s\n", p.String())
+	}
+	if len(w.Extra) > 0 {
+		fmt.Printf("  Extra vs baseline (%d):\n", len(w.Extra))
+		for _, p := range w.Extra {
+			fmt.Printf("    + %s\n", p.String())
+		}
+	}
+	if len(w.Missing) > 0 {
+		fmt.Printf("  Missing vs baseline (%d):\n", len(w.Missing))
+		for _, p := range w.Missing {
+			fmt.Printf("    - %s\n", p.String())
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package audit
+
+import (
+	"fmt"
+	"net"
+	"sort"
+
+	"github.com/Hru-s/driftwatch/internal/model"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// ipv6BroadPrefix is the IPv6 prefix length at or below which an ipBlock is
+// considered overly broad (IPv6 allocations are much larger than IPv4).
+const ipv6BroadPrefix = 32
+
+// AuditNetPolIPBlocks flags NetworkPolicy ipBlocks that allow the whole
+// internet (0.0.0.0/0, ::/0) or IPv4 ranges with a prefix length at or below
+// maxIPv4Prefix. Such policies look restrictive but allow almost everything.
+func AuditNetPolIPBlocks(snap *model.NetPolSnapshot, source string, maxIPv4Prefix int) []model.NetPolRiskFinding {
+	var out []model.NetPolRiskFinding
+	if snap == nil {
+		return out
+	}
+
+	for _, d := range snap.Items {
+		check := func(direction string, cidrs []string) {
+			for _, cidr := range cidrs {
+				_, ipnet, err := net.ParseCIDR(cidr)
+				if err != nil {
+					continue
+				}
+				ones, bits := ipnet.Mask.Size()
+
+				f := model.NetPolRiskFinding{
+					Source:    source,
+					Namespace: d.Namespace,
+					Name:      d.Name,
+					Direction: direction,
+					Detail:    "ipBlock " + cidr,
+				}
+				switch {
+				case ones == 0:
+					f.Reason = "ipBlock allows all addresses"
+					f.Severity = model.SeverityHigh
+				case bits == 32 && ones <= maxIPv4Prefix:
+					f.Reason = fmt.Sprintf("very broad ipBlock range /%d (threshold /%d)", ones, maxIPv4Prefix)
+					f.Severity = model.SeverityMedium
+				case bits == 128 && ones <= ipv6BroadPrefix:
+					f.Reason = fmt.Sprintf("very broad ipBlock range /%d (threshold /%d)", ones, ipv6BroadPrefix)
+					f.Severity = model.SeverityMedium
+				default:
+					continue
+				}
+				out = append(out, f)
+			}
+		}
+		check("ingress", d.IngressCIDRs)
+		check("egress", d.EgressCIDRs)
+	}
+
+	sortNetPolFindings(out)
+	return out
+}
+
+// AuditNetPolNamespaceSelectors flags NetworkPolicy peers whose
+// namespaceSelector matches none of the given namespaces. Such rules are
+// dead: they silently allow nothing, which usually points at a typo in a
+// label or a namespace that was renamed. Findings are advisory (low).
+func AuditNetPolNamespaceSelectors(snap *model.NetPolSnapshot, source string, namespaces []model.NamespacePSA) []model.NetPolRiskFinding {
+	var out []model.NetPolRiskFinding
+	if snap == nil {
+		return out
+	}
+
+	for _, d := range snap.Items {
+		check := func(direction string, selectors []metav1.LabelSelector) {
+			seen := make(map[string]bool)
+			for i := range selectors {
+				selector, err := metav1.LabelSelectorAsSelector(&selectors[i])
+				if err != nil || selector.Empty() {
+					continue
+				}
+				detail := "namespaceSelector " + selector.String()
+				if seen[detail] || matchesAnyNamespace(selector, namespaces) {
+					continue
+				}
+				seen[detail] = true
+				out = append(out, model.NetPolRiskFinding{
+					Source:    source,
+					Namespace: d.Namespace,
+					Name:      d.Name,
+					Direction: direction,
+					Detail:    detail,
+					Reason:    "namespaceSelector matches no namespaces",
+					Severity:  model.SeverityLow,
+				})
+			}
+		}
+		check("ingress", d.IngressNamespaceSelectors)
+		check("egress", d.EgressNamespaceSelectors)
+	}
+
+	sortNetPolFindings(out)
+	return out
+}
+
+func matchesAnyNamespace(selector labels.Selector, namespaces []model.NamespacePSA) bool {
+	for _, ns := range namespaces {
+		// the API server sets this label on every namespace; fixtures may lack it
+		set := labels.Set{"kubernetes.io/metadata.name": ns.Namespace}
+		for k, v := range ns.Labels {
+			set[k] = v
+		}
+		if selector.Matches(set) {
+			return true
+		}
+	}
+	return false
+}
+
+func sortNetPolFindings(out []model.NetPolRiskFinding) {
+	sort.Slice(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		if a.Direction != b.Direction {
+			return a.Direction < b.Direction
+		}
+		return a.Detail < b.Detail
+	})
+}
@@ -0,0 +1,151 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/Hru-s/driftwatch/internal/model"
+
+	"github.com/open-policy-agent/opa/v1/rego"
+)
+
+// RegoQuery is the rule a -rego policy must define. Each element of the
+// deny set is either a message string or an object with "msg" and the
+// optional "severity", "namespace" and "name" keys.
+const RegoQuery = "data.driftwatch.deny"
+
+// RegoInput is the document a -rego policy sees as input.
+type RegoInput struct {
+	Source          string               `json:"source"`
+	RBAC            []RegoSubject        `json:"rbac"`
+	NetworkPolicies []model.NetPolDigest `json:"networkPolicies"`
+	Namespaces      []model.NamespacePSA `json:"namespaces"`
+}
+
+// RegoSubject is one subject and its effective permissions.
+type RegoSubject struct {
+	Subject     model.SubjectKey   `json:"subject"`
+	Permissions []model.Permission `json:"permissions"`
+}
+
+// NewRegoInput flattens the snapshots into a stably ordered input document.
+func NewRegoInput(source string, rbac *model.RBACSnapshot, netpol *model.NetPolSnapshot, namespaces []model.NamespacePSA) RegoInput {
+	in := RegoInput{
+		Source:          source,
+		RBAC:            []RegoSubject{},
+		NetworkPolicies: []model.NetPolDigest{},
+		Namespaces:      append([]model.NamespacePSA{}, namespaces...),
+	}
+	for subj, perms := range rbac.Subjects {
+		s := RegoSubject{Subject: subj, Permissions: make([]model.Permission, 0, len(perms))}
+		for p := range perms {
+			s.Permissions = append(s.Permissions, p)
+		}
+		sort.Slice(s.Permissions, func(i, j int) bool { return s.Permissions[i].String() < s.Permissions[j].String() })
+		in.RBAC = append(in.RBAC, s)
+	}
+	sort.Slice(in.RBAC, func(i, j int) bool { return in.RBAC[i].Subject.String() < in.RBAC[j].Subject.String() })
+	for _, d := range netpol.Items {
+		in.NetworkPolicies = append(in.NetworkPolicies, d)
+	}
+	sort.Slice(in.NetworkPolicies, func(i, j int) bool {
+		a, b := in.NetworkPolicies[i], in.NetworkPolicies[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+	sort.Slice(in.Namespaces, func(i, j int) bool { return in.Namespaces[i].Namespace < in.Namespaces[j].Namespace })
+	return in
+}
+
+// AuditRego evaluates the deny rule of the Rego policy in policyFile against
+// input and returns one violation per element of the deny set.
+func AuditRego(ctx context.Context, policyFile string, input RegoInput) ([]model.RegoViolation, error) {
+	src, err := os.ReadFile(policyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	// Round-trip through JSON so the policy sees the documented field names.
+	raw, err := json.Marshal(input)
+	if err != nil {
+		return nil, err
+	}
+	var doc interface{}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, err
+	}
+
+	query, err := rego.New(
+		rego.Query(RegoQuery),
+		rego.Module(policyFile, string(src)),
+	).PrepareForEval(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("compiling %s: %w", policyFile, err)
+	}
+	rs, err := query.Eval(ctx, rego.EvalInput(doc))
+	if err != nil {
+		return nil, fmt.Errorf("evaluating %s: %w", policyFile, err)
+	}
+
+	var out []model.RegoViolation
+	for _, result := range rs {
+		for _, expr := range result.Expressions {
+			items, ok := expr.Value.([]interface{})
+			if !ok {
+				return nil, fmt.Errorf("%s: %s must be a set or array, got %T", policyFile, RegoQuery, expr.Value)
+			}
+			for _, item := range items {
+				v, err := regoViolation(input.Source, item)
+				if err != nil {
+					return nil, fmt.Errorf("%s: %w", policyFile, err)
+				}
+				out = append(out, v)
+			}
+		}
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Namespace != out[j].Namespace {
+			return out[i].Namespace < out[j].Namespace
+		}
+		if out[i].Name != out[j].Name {
+			return out[i].Name < out[j].Name
+		}
+		return out[i].Message < out[j].Message
+	})
+	return out, nil
+}
+
+func regoViolation(source string, item interface{}) (model.RegoViolation, error) {
+	v := model.RegoViolation{Source: source, Severity: model.SeverityMedium}
+	switch item := item.(type) {
+	case string:
+		v.Message = item
+	case map[string]interface{}:
+		str := func(key string) string {
+			s, _ := item[key].(string)
+			return s
+		}
+		v.Message = str("msg")
+		v.Namespace = str("namespace")
+		v.Name = str("name")
+		if s := str("severity"); s != "" {
+			sev, err := model.ParseSeverity(s)
+			if err != nil {
+				return v, fmt.Errorf("violation %q: %w", v.Message, err)
+			}
+			v.Severity = sev
+		}
+		if v.Message == "" {
+			return v, fmt.Errorf("deny object without a \"msg\" string: %v", item)
+		}
+	default:
+		return v, fmt.Errorf("deny element must be a string or an object with \"msg\", got %T", item)
+	}
+	return v, nil
+}
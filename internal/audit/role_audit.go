@@ -0,0 +1,104 @@
+package audit
+
+import (
+	"sort"
+
+	"github.com/Hru-s/driftwatch/internal/model"
+)
+
+// sensitiveResources are resources where a wildcard verb grant is effectively
+// a cluster takeover (credential theft, RBAC self-escalation, code execution).
+var sensitiveResources = map[string]model.Severity{
+	"secrets":                    model.SeverityCritical,
+	"clusterroles":               model.SeverityHigh,
+	"clusterrolebindings":        model.SeverityHigh,
+	"roles":                      model.SeverityHigh,
+	"rolebindings":               model.SeverityHigh,
+	"pods/exec":                  model.SeverityHigh,
+	"serviceaccounts/token":      model.SeverityHigh,
+	"nodes/proxy":                model.SeverityHigh,
+	"certificatesigningrequests": model.SeverityHigh,
+}
+
+// AuditClusterRoles enumerates ClusterRoles in the snapshot that grant "*"
+// verbs on "*" resources or on sensitive resources such as secrets.
+// source labels where the snapshot came from ("baseline" / "live").
+func AuditClusterRoles(snap *model.RBACSnapshot, source string) []model.RoleRiskFinding {
+	var out []model.RoleRiskFinding
+	if snap == nil {
+		return out
+	}
+
+	for name, rules := range snap.ClusterRoles {
+		for _, rule := range rules {
+			if !contains(rule.Verbs, "*") {
+				continue
+			}
+
+			apiGroups := rule.APIGroups
+			if len(apiGroups) == 0 {
+				apiGroups = []string{""}
+			}
+
+			for _, res := range rule.Resources {
+				for _, group := range apiGroups {
+					f := model.RoleRiskFinding{
+						Source:   source,
+						Role:     name,
+						Verbs:    rule.Verbs,
+						APIGroup: group,
+						Resource: res,
+					}
+
+					switch {
+					case res == "*":
+						f.Reason = "wildcard verbs on all resources"
+						f.Severity = model.SeverityCritical
+					default:
+						sev, ok := sensitiveResources[res]
+						if !ok || (group != "" && group != "*" && !isRBACGroup(group, res)) {
+							continue
+						}
+						f.Reason = "wildcard verbs on sensitive resource " + res
+						f.Severity = sev
+					}
+					out = append(out, f)
+				}
+			}
+		}
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Role != out[j].Role {
+			return out[i].Role < out[j].Role
+		}
+		if out[i].Resource != out[j].Resource {
+			return out[i].Resource < out[j].Resource
+		}
+		return out[i].APIGroup < out[j].APIGroup
+	})
+
+	return out
+}
+
+// isRBACGroup reports whether group is the API group that serves the RBAC
+// or certificate resources listed in sensitiveResources.
+func isRBACGroup(group, resource string) bool {
+	switch resource {
+	case "clusterroles", "clusterrolebindings", "roles", "rolebindings":
+		return group == "rbac.authorization.k8s.io"
+	case "certificatesigningrequests":
+		return group == "certificates.k8s.io"
+	default:
+		return false
+	}
+}
+
+func contains(list []string, v string) bool {
+	for _, s := range list {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
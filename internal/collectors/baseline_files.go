@@ -0,0 +1,194 @@
+package collectors
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Hru-s/driftwatch/internal/model"
+)
+
+// DefaultMaxBaselineFileBytes and DefaultBaselineReadTimeout are the
+// baseline file limits of a Config that does not set them.
+const (
+	DefaultMaxBaselineFileBytes int64 = 10 << 20
+	DefaultBaselineReadTimeout        = 30 * time.Second
+)
+
+// MaxFileBytes returns the size limit of a single baseline file, resolving
+// the default, or 0 when there is none.
+func (c Config) MaxFileBytes() int64 {
+	switch {
+	case c.MaxBaselineFileBytes == 0:
+		return DefaultMaxBaselineFileBytes
+	case c.MaxBaselineFileBytes < 0:
+		return 0
+	}
+	return c.MaxBaselineFileBytes
+}
+
+// openBaselineFile opens path for decoding, enforcing the limits of cfg.
+// The size limit is checked up front (via stat) and while reading, for
+// pipes and files that grow underneath us, so a huge (or hostile) manifest
+// in a shared baseline directory can't exhaust memory; a file over the
+// limit fails instead of being decoded cut short.
+// The read timeout bounds the time from opening the file to its last
+// read: a pipe that stops delivering data fails at the deadline, any other
+// file at its first read after it.
+func openBaselineFile(path string, cfg Config) (io.ReadCloser, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	var r io.Reader = f
+
+	if limit := cfg.MaxFileBytes(); limit > 0 {
+		info, err := f.Stat()
+		if err != nil {
+			f.Close()
+			return nil, err
+		}
+		if info.Size() > limit {
+			f.Close()
+			return nil, fmt.Errorf("file is %d bytes, exceeds baseline size limit of %d bytes", info.Size(), limit)
+		}
+		r = &sizeLimitReader{r: r, limit: limit}
+	}
+
+	timeout := cfg.BaselineReadTimeout
+	if timeout == 0 {
+		timeout = DefaultBaselineReadTimeout
+	}
+	if timeout > 0 {
+		deadline := time.Now().Add(timeout)
+		// interrupts a blocked read of a pipe; regular files return
+		// ErrNoDeadline and are checked between reads instead
+		_ = f.SetReadDeadline(deadline)
+		r = &deadlineReader{r: r, deadline: deadline, timeout: timeout}
+	}
+	return limitedFile{Reader: r, Closer: f}, nil
+}
+
+type limitedFile struct {
+	io.Reader
+	io.Closer
+}
+
+// sizeLimitReader reads up to limit bytes and fails when r has more.
+type sizeLimitReader struct {
+	r     io.Reader
+	n     int64
+	limit int64
+}
+
+func (l *sizeLimitReader) Read(p []byte) (int, error) {
+	if l.n > l.limit {
+		return 0, fmt.Errorf("baseline file exceeds %d bytes", l.limit)
+	}
+	// read one byte past the limit to tell a file of exactly limit bytes
+	// from a longer one
+	if room := l.limit + 1 - l.n; int64(len(p)) > room {
+		p = p[:room]
+	}
+	n, err := l.r.Read(p)
+	l.n += int64(n)
+	if l.n > l.limit {
+		return n - int(l.n-l.limit), fmt.Errorf("baseline file exceeds %d bytes", l.limit)
+	}
+	return n, err
+}
+
+// deadlineReader fails reads once deadline has passed.
+type deadlineReader struct {
+	r        io.Reader
+	deadline time.Time
+	timeout  time.Duration
+}
+
+func (d *deadlineReader) Read(p []byte) (int, error) {
+	if time.Now().After(d.deadline) {
+		return 0, fmt.Errorf("exceeds baseline read timeout of %s: %w", d.timeout, os.ErrDeadlineExceeded)
+	}
+	n, err := d.r.Read(p)
+	if errors.Is(err, os.ErrDeadlineExceeded) {
+		err = fmt.Errorf("exceeds baseline read timeout of %s: %w", d.timeout, err)
+	}
+	return n, err
+}
+
+// duplicateEffect says what the snapshot builders do with a baseline object
+// declared more than once.
+var duplicateEffect = map[string]string{
+	"NetworkPolicy":      "the last one read wins",
+	"Role":               "their rules are merged",
+	"ClusterRole":        "their rules are merged",
+	"RoleBinding":        "all of their subjects are bound",
+	"ClusterRoleBinding": "all of their subjects are bound",
+}
+
+// baselineSources records where each baseline object was declared, so
+// copy-pasted duplicates can be reported instead of silently merged or
+// overwritten, and findings can point back at the baseline file.
+type baselineSources map[baselineObject][]model.SourceRef
+
+type baselineObject struct {
+	Kind string
+	Key  string // namespace/name, or name for cluster-scoped kinds
+}
+
+func (s baselineSources) add(ref model.SourceRef, kind, key string) {
+	obj := baselineObject{Kind: kind, Key: key}
+	s[obj] = append(s[obj], ref)
+}
+
+// refs returns where kind/key was declared, in reading order.
+func (s baselineSources) refs(kind, key string) []model.SourceRef {
+	return s[baselineObject{Kind: kind, Key: key}]
+}
+
+// baselineFileName returns path relative to the baseline directory dir,
+// as recorded in SourceRef.File; for a baseline that is a single file it
+// is the file's base name. Document numbers count the non-empty documents
+// of the file from 1.
+func baselineFileName(dir, path string) string {
+	if rel, err := filepath.Rel(dir, path); err == nil {
+		if rel == "." {
+			return filepath.Base(path)
+		}
+		return rel
+	}
+	return path
+}
+
+// duplicates returns one warning per object declared more than once,
+// sorted by kind and key.
+func (s baselineSources) duplicates() []string {
+	var objs []baselineObject
+	for obj, files := range s {
+		if len(files) > 1 {
+			objs = append(objs, obj)
+		}
+	}
+	sort.Slice(objs, func(i, j int) bool {
+		if objs[i].Kind != objs[j].Kind {
+			return objs[i].Kind < objs[j].Kind
+		}
+		return objs[i].Key < objs[j].Key
+	})
+
+	var out []string
+	for _, obj := range objs {
+		where := make([]string, 0, len(s[obj]))
+		for _, ref := range s[obj] {
+			where = append(where, ref.String())
+		}
+		out = append(out, fmt.Sprintf("duplicate %s %q declared %d times (%s); %s",
+			obj.Kind, obj.Key, len(s[obj]), strings.Join(where, "; "), duplicateEffect[obj.Kind]))
+	}
+	return out
+}
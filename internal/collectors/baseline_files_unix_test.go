@@ -0,0 +1,57 @@
+//go:build unix
+
+package collectors
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+)
+
+// A FIFO has no size to stat; reading past the limit must fail rather
+// than hand the decoder a truncated document.
+func TestOpenBaselineFileFIFOOverrun(t *testing.T) {
+	for _, tt := range []struct {
+		size    int
+		wantErr bool
+	}{
+		{size: 1024, wantErr: false},
+		{size: 1025, wantErr: true},
+	} {
+		path := filepath.Join(t.TempDir(), "roles.yaml")
+		if err := syscall.Mkfifo(path, 0o600); err != nil {
+			t.Skipf("mkfifo: %v", err)
+		}
+		go func() {
+			w, err := os.OpenFile(path, os.O_WRONLY, 0)
+			if err != nil {
+				return
+			}
+			defer w.Close()
+			w.Write(bytes.Repeat([]byte("#"), tt.size))
+		}()
+
+		f, err := openBaselineFile(path, Config{MaxBaselineFileBytes: 1024})
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := io.ReadAll(f)
+		f.Close()
+		if tt.wantErr {
+			if err == nil || !strings.Contains(err.Error(), "baseline file exceeds 1024 bytes") {
+				t.Errorf("%d bytes: err = %v, want size limit error", tt.size, err)
+			}
+			if len(data) > 1024 {
+				t.Errorf("%d bytes: read %d bytes past the limit", tt.size, len(data))
+			}
+			continue
+		}
+		if err != nil || len(data) != tt.size {
+			t.Errorf("%d bytes: read %d bytes, err = %v", tt.size, len(data), err)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package collectors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Hru-s/driftwatch/internal/model"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// baselineKinds are the kinds some baseline collector reads.
+var baselineKinds = map[string]bool{
+	"Role":                                   true,
+	"ClusterRole":                            true,
+	"RoleBinding":                            true,
+	"ClusterRoleBinding":                     true,
+	"NetworkPolicy":                          true,
+	"Namespace":                              true,
+	"StorageClass":                           true,
+	"ResourceQuota":                          true,
+	"LimitRange":                             true,
+	"ServiceAccount":                         true,
+	"ConfigMap":                              true,
+	model.ValidatingWebhookConfigurationKind: true,
+	model.MutatingWebhookConfigurationKind:   true,
+}
+
+// unrecognizedPolicyKind reports whether a document of kind, which no
+// collector reads, still looks like it was meant to be policy: a near miss
+// of a kind one does read (Rolebinding), anything else in the RBAC group,
+// or a PodSecurityPolicy, whose constraints are not compared (the PSA
+// namespace labels are).
+func unrecognizedPolicyKind(apiVersion, kind string) bool {
+	if baselineKinds[kind] {
+		return false
+	}
+	if knownBaselineKind(kind) != "" || kind == "PodSecurityPolicy" {
+		return true
+	}
+	group, _, found := strings.Cut(apiVersion, "/")
+	return found && group == "rbac.authorization.k8s.io"
+}
+
+// knownBaselineKind returns the kind a collector reads that kind differs
+// from only in case, or "".
+func knownBaselineKind(kind string) string {
+	for k := range baselineKinds {
+		if k != kind && strings.EqualFold(k, kind) {
+			return k
+		}
+	}
+	return ""
+}
+
+// BaselineInventory is what the baseline collectors read from a baseline
+// directory.
+type BaselineInventory struct {
+	Roles               int
+	ClusterRoles        int
+	RoleBindings        int
+	ClusterRoleBindings int
+	NetworkPolicies     int
+	Namespaces          int
+
+	// Skipped lists the YAML files without a document of a kind any
+	// collector reads, sorted by file.
+	Skipped []SkippedBaselineFile
+	// Warnings are the policy-relevant documents of unrecognized kinds and
+	// the objects declared more than once.
+	Warnings []string
+}
+
+// SkippedBaselineFile is a baseline file no collector reads anything from.
+type SkippedBaselineFile struct {
+	File  string   // relative to the baseline directory
+	Kinds []string // kinds of its documents, "" for a document without one
+}
+
+func (f SkippedBaselineFile) String() string {
+	kinds := make([]string, len(f.Kinds))
+	for i, k := range f.Kinds {
+		if k == "" {
+			k = "<no kind>"
+		}
+		kinds[i] = k
+	}
+	if len(kinds) == 0 {
+		return f.File + " (no documents)"
+	}
+	return f.File + " (" + strings.Join(kinds, ", ") + ")"
+}
+
+// InventoryBaselineDir runs the RBAC, NetworkPolicy and PSA baseline
+// collectors over dir and counts the objects they read, without building
+// snapshots. It also lists the YAML files skipped because none of their
+// documents is of a kind any collector reads.
+func InventoryBaselineDir(dir string, cfg Config) (BaselineInventory, error) {
+	var inv BaselineInventory
+
+	rbac, err := loadRBACYAMLFromDir(dir, cfg)
+	if err != nil {
+		return inv, fmt.Errorf("loading RBAC: %w", err)
+	}
+	if err := rbac.strictErr(cfg.Strict); err != nil {
+		return inv, err
+	}
+	inv.Roles = len(rbac.roles)
+	inv.ClusterRoles = len(rbac.clusterRoles)
+	inv.RoleBindings = len(rbac.roleBindings)
+	inv.ClusterRoleBindings = len(rbac.clusterRoleBindings)
+
+	netpols, netpolSources, err := loadNetPolYAMLFromDir(dir, cfg)
+	if err != nil {
+		return inv, fmt.Errorf("loading NetworkPolicies: %w", err)
+	}
+	inv.NetworkPolicies = len(netpols)
+
+	namespaces, err := CollectPSAFromBaselineDir(dir, cfg)
+	if err != nil {
+		return inv, fmt.Errorf("loading namespaces: %w", err)
+	}
+	inv.Namespaces = len(namespaces)
+
+	inv.Warnings = append(rbac.skippedWarnings(), rbac.sources.duplicates()...)
+	inv.Warnings = append(inv.Warnings, netpolSources.duplicates()...)
+
+	walkErr := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !isYAMLFile(path) {
+			return nil
+		}
+
+		f, err := openBaselineFile(path, cfg)
+		if err != nil {
+			return fmt.Errorf("opening %s: %w", path, err)
+		}
+		defer f.Close()
+
+		kinds, err := documentKinds(f)
+		if err != nil {
+			return fmt.Errorf("decoding %s: %w", path, err)
+		}
+		for _, k := range kinds {
+			if baselineKinds[k] {
+				return nil
+			}
+		}
+		inv.Skipped = append(inv.Skipped, SkippedBaselineFile{File: baselineFileName(dir, path), Kinds: kinds})
+		return nil
+	})
+	if walkErr != nil {
+		return inv, walkErr
+	}
+	sort.Slice(inv.Skipped, func(i, j int) bool { return inv.Skipped[i].File < inv.Skipped[j].File })
+	return inv, nil
+}
+
+// documentKinds returns the kind of each non-empty document in r, in order.
+func documentKinds(r io.Reader) ([]string, error) {
+	dec := yaml.NewYAMLOrJSONDecoder(r, 4096)
+	var kinds []string
+	for {
+		var raw runtime.RawExtension
+		if err := dec.Decode(&raw); err != nil {
+			if errors.Is(err, io.EOF) {
+				return kinds, nil
+			}
+			return nil, err
+		}
+		if len(raw.Raw) == 0 || string(raw.Raw) == "null" {
+			continue
+		}
+		var tm metav1.TypeMeta
+		// a document that is not an object has no kind either
+		_ = json.Unmarshal(raw.Raw, &tm)
+		kinds = append(kinds, tm.Kind)
+	}
+}
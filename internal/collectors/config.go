@@ -0,0 +1,48 @@
+package collectors
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Config holds the collector settings of one run. The zero value collects
+// everything in every namespace, with the default baseline file limits.
+type Config struct {
+	// MaxBaselineFileBytes caps the size of a single baseline file; see
+	// openBaselineFile. 0 selects DefaultMaxBaselineFileBytes and a
+	// negative value disables the limit.
+	MaxBaselineFileBytes int64
+
+	// BaselineReadTimeout bounds reading a single baseline file; see
+	// openBaselineFile. 0 selects DefaultBaselineReadTimeout and a
+	// negative value disables the timeout.
+	BaselineReadTimeout time.Duration
+
+	// Strict makes the baseline RBAC collectors fail on documents of an
+	// unrecognized but policy-relevant kind instead of warning about them.
+	Strict bool
+
+	// SkipDefaultClusterRoles drops the ClusterRoles Kubernetes ships on
+	// every cluster, and the bootstrap bindings that grant them, from RBAC
+	// snapshots. Their rules are still used to resolve user-created
+	// bindings (e.g. a team RoleBinding to "edit"), so only the built-in
+	// noise disappears.
+	SkipDefaultClusterRoles bool
+
+	// NetPolHashOnly makes the NetworkPolicy collectors keep only each
+	// policy's spec hash (and labels), skipping the per-field detail.
+	NetPolHashOnly bool
+
+	// NetPolStabilizeWindow, when positive, makes CollectNetPolFromCluster
+	// list NetworkPolicies twice this far apart. Policies whose spec
+	// differs between the two reads are marked Unstable so a controller
+	// rewriting them does not show up as drift.
+	NetPolStabilizeWindow time.Duration
+
+	// Namespaces, when non-nil, scopes live collection to these namespaces
+	// (see SelectNamespaces): namespaced kinds are listed in each of them
+	// instead of cluster-wide, and PSA is read from them without listing
+	// namespaces again. Cluster-scoped objects are still collected.
+	Namespaces []corev1.Namespace
+}
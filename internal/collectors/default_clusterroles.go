@@ -0,0 +1,37 @@
+package collectors
+
+import (
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// bootstrapLabel marks objects created by the API server's RBAC bootstrap.
+const bootstrapLabel = "kubernetes.io/bootstrapping"
+
+// defaultClusterRoleNames are the user-facing built-ins that do not carry
+// the system: prefix.
+var defaultClusterRoleNames = map[string]bool{
+	"cluster-admin": true,
+	"admin":         true,
+	"edit":          true,
+	"view":          true,
+}
+
+// isDefaultClusterRole reports whether a ClusterRole is a Kubernetes built-in.
+func isDefaultClusterRole(meta metav1.ObjectMeta) bool {
+	return defaultClusterRoleNames[meta.Name] || isBootstrapObject(meta)
+}
+
+// isBootstrapObject reports whether an RBAC object (role or binding) was
+// created by the API server's bootstrap rather than by a user.
+func isBootstrapObject(meta metav1.ObjectMeta) bool {
+	return strings.HasPrefix(meta.Name, "system:") || meta.Labels[bootstrapLabel] == "rbac-defaults"
+}
+
+// isBuiltinClusterRoleName reports whether name is a ClusterRole every
+// cluster has (a user-facing built-in or a system: role), which a baseline
+// may bind without declaring.
+func isBuiltinClusterRoleName(name string) bool {
+	return defaultClusterRoleNames[name] || strings.HasPrefix(name, "system:")
+}
@@ -0,0 +1,29 @@
+package collectors
+
+import (
+	"fmt"
+	"os"
+
+	yamlutil "k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// LoadGroupMap reads a user -> groups mapping from a YAML or JSON file, e.g.
+//
+//	alice@example.com: [payments-admins, oncall]
+//	bob@example.com:   [payments-readonly]
+//
+// Kubernetes does not store group membership (it comes from the
+// authenticator, e.g. OIDC), so it has to be supplied out of band.
+func LoadGroupMap(path string) (map[string][]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	userGroups := map[string][]string{}
+	if err := yamlutil.NewYAMLOrJSONDecoder(f, 4096).Decode(&userGroups); err != nil {
+		return nil, fmt.Errorf("decode group map %s: %w", path, err)
+	}
+	return userGroups, nil
+}
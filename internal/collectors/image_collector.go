@@ -0,0 +1,144 @@
+package collectors
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Hru-s/driftwatch/internal/model"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/kubernetes"
+)
+
+// CollectImageUsageFromCluster lists Pods (in cfg.Namespaces when it is
+// set) and summarizes the images they run per namespace.
+func CollectImageUsageFromCluster(ctx context.Context, client kubernetes.Interface, cfg Config) ([]model.ImageUsageDigest, error) {
+	pods, err := listNamespaced(ctx, cfg,
+		func(ns string) func(context.Context, metav1.ListOptions) (*corev1.PodList, error) {
+			return client.CoreV1().Pods(ns).List
+		},
+		func(l *corev1.PodList) []corev1.Pod { return l.Items })
+	if err != nil {
+		return nil, fmt.Errorf("listing Pods: %w", err)
+	}
+
+	images := make(map[string]map[string]struct{})
+	add := func(ns, image string) {
+		if image == "" {
+			return
+		}
+		if images[ns] == nil {
+			images[ns] = make(map[string]struct{})
+		}
+		images[ns][image] = struct{}{}
+	}
+	for _, pod := range pods {
+		for _, c := range pod.Spec.InitContainers {
+			add(pod.Namespace, c.Image)
+		}
+		for _, c := range pod.Spec.Containers {
+			add(pod.Namespace, c.Image)
+		}
+		for _, c := range pod.Spec.EphemeralContainers {
+			add(pod.Namespace, c.Image)
+		}
+	}
+
+	out := make([]model.ImageUsageDigest, 0, len(images))
+	for ns, set := range images {
+		d := model.ImageUsageDigest{Namespace: ns}
+		registries := make(map[string]struct{})
+		for image := range set {
+			d.Images = append(d.Images, image)
+			registries[model.ImageRegistry(image)] = struct{}{}
+		}
+		for r := range registries {
+			d.Registries = append(d.Registries, r)
+		}
+		sort.Strings(d.Images)
+		sort.Strings(d.Registries)
+		out = append(out, d)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Namespace < out[j].Namespace })
+	return out, nil
+}
+
+// CollectAllowedRegistriesFromBaselineDir reads the allowed-registry list
+// from the AllowedRegistriesConfigMap in a baseline directory. found is
+// false when no such ConfigMap exists.
+func CollectAllowedRegistriesFromBaselineDir(dir string, cfg Config) (allowed []string, found bool, err error) {
+	walkErr := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !isYAMLFile(path) {
+			return nil
+		}
+
+		f, err := openBaselineFile(path, cfg)
+		if err != nil {
+			return fmt.Errorf("opening %s: %w", path, err)
+		}
+		defer f.Close()
+
+		entries, ok, err := decodeAllowedRegistriesFromReader(f)
+		if err != nil {
+			return fmt.Errorf("decoding allowed registries from %s: %w", path, err)
+		}
+		if ok {
+			allowed = append(allowed, entries...)
+			found = true
+		}
+		return nil
+	})
+	if walkErr != nil {
+		return nil, false, walkErr
+	}
+	return allowed, found, nil
+}
+
+func decodeAllowedRegistriesFromReader(r io.Reader) ([]string, bool, error) {
+	dec := yaml.NewYAMLOrJSONDecoder(r, 4096)
+
+	var out []string
+	found := false
+	for {
+		var raw runtime.RawExtension
+		if err := dec.Decode(&raw); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, false, err
+		}
+		if len(raw.Raw) == 0 {
+			continue
+		}
+
+		var cm corev1.ConfigMap
+		if err := json.Unmarshal(raw.Raw, &cm); err != nil {
+			continue
+		}
+		if cm.Kind != "ConfigMap" || cm.Name != model.AllowedRegistriesConfigMap {
+			continue
+		}
+		found = true
+		for _, line := range strings.Split(cm.Data[model.AllowedRegistriesKey], "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			out = append(out, strings.TrimSuffix(line, "/"))
+		}
+	}
+	return out, found, nil
+}
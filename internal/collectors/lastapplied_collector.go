@@ -0,0 +1,133 @@
+package collectors
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/Hru-s/driftwatch/internal/model"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// CollectLastAppliedFromCluster lists the kinds driftwatch compares
+// (NetworkPolicies, RBAC objects and Namespaces) and returns every object
+// carrying a last-applied annotation, paired with that configuration.
+// Objects never applied with `kubectl apply` are skipped; an annotation
+// that does not decode is reported as a warning. With cfg.Namespaces set,
+// only those namespaces and the namespaced objects in them are compared.
+func CollectLastAppliedFromCluster(ctx context.Context, client kubernetes.Interface, cfg Config) ([]model.LastAppliedObject, []string, error) {
+	var out []model.LastAppliedObject
+	var warnings []string
+	add := func(kind string, meta metav1.ObjectMeta, obj any) error {
+		raw, ok := meta.Annotations[model.LastAppliedAnnotation]
+		if !ok {
+			return nil
+		}
+		var applied map[string]any
+		if err := json.Unmarshal([]byte(raw), &applied); err != nil {
+			warnings = append(warnings, fmt.Sprintf("%s %s: unreadable %s annotation: %v",
+				kind, metaName(meta), model.LastAppliedAnnotation, err))
+			return nil
+		}
+		b, err := json.Marshal(obj)
+		if err != nil {
+			return fmt.Errorf("marshal %s %s: %w", kind, metaName(meta), err)
+		}
+		var live map[string]any
+		if err := json.Unmarshal(b, &live); err != nil {
+			return fmt.Errorf("unmarshal %s %s: %w", kind, metaName(meta), err)
+		}
+		out = append(out, model.LastAppliedObject{
+			Kind:      kind,
+			Namespace: meta.Namespace,
+			Name:      meta.Name,
+			Applied:   applied,
+			Live:      live,
+		})
+		return nil
+	}
+
+	netpols, err := listNetPols(ctx, client, cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("listing NetworkPolicies: %w", err)
+	}
+	for i := range netpols {
+		if err := add("NetworkPolicy", netpols[i].ObjectMeta, &netpols[i]); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	roles, err := listNamespaced(ctx, cfg,
+		func(ns string) func(context.Context, metav1.ListOptions) (*rbacv1.RoleList, error) {
+			return client.RbacV1().Roles(ns).List
+		},
+		func(l *rbacv1.RoleList) []rbacv1.Role { return l.Items })
+	if err != nil {
+		return nil, nil, fmt.Errorf("listing Roles: %w", err)
+	}
+	for i := range roles {
+		if err := add("Role", roles[i].ObjectMeta, &roles[i]); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	clusterRoles, err := client.RbacV1().ClusterRoles().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("listing ClusterRoles: %w", err)
+	}
+	for i := range clusterRoles.Items {
+		if err := add("ClusterRole", clusterRoles.Items[i].ObjectMeta, &clusterRoles.Items[i]); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	roleBindings, err := listNamespaced(ctx, cfg,
+		func(ns string) func(context.Context, metav1.ListOptions) (*rbacv1.RoleBindingList, error) {
+			return client.RbacV1().RoleBindings(ns).List
+		},
+		func(l *rbacv1.RoleBindingList) []rbacv1.RoleBinding { return l.Items })
+	if err != nil {
+		return nil, nil, fmt.Errorf("listing RoleBindings: %w", err)
+	}
+	for i := range roleBindings {
+		if err := add("RoleBinding", roleBindings[i].ObjectMeta, &roleBindings[i]); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	clusterRoleBindings, err := client.RbacV1().ClusterRoleBindings().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("listing ClusterRoleBindings: %w", err)
+	}
+	for i := range clusterRoleBindings.Items {
+		if err := add("ClusterRoleBinding", clusterRoleBindings.Items[i].ObjectMeta, &clusterRoleBindings.Items[i]); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	namespaces := cfg.Namespaces
+	if namespaces == nil {
+		list, err := client.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, nil, fmt.Errorf("listing namespaces: %w", err)
+		}
+		namespaces = list.Items
+	}
+	for i := range namespaces {
+		if err := add("Namespace", namespaces[i].ObjectMeta, &namespaces[i]); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return out, warnings, nil
+}
+
+func metaName(meta metav1.ObjectMeta) string {
+	if meta.Namespace == "" {
+		return meta.Name
+	}
+	return meta.Namespace + "/" + meta.Name
+}
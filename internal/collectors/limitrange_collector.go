@@ -0,0 +1,128 @@
+package collectors
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/Hru-s/driftwatch/internal/model"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/kubernetes"
+)
+
+// CollectLimitRangeFromCluster lists LimitRanges in all namespaces, or in
+// cfg.Namespaces when it is set.
+func CollectLimitRangeFromCluster(ctx context.Context, client kubernetes.Interface, cfg Config) ([]model.LimitRangeDigest, error) {
+	ranges, err := listNamespaced(ctx, cfg,
+		func(ns string) func(context.Context, metav1.ListOptions) (*corev1.LimitRangeList, error) {
+			return client.CoreV1().LimitRanges(ns).List
+		},
+		func(l *corev1.LimitRangeList) []corev1.LimitRange { return l.Items })
+	if err != nil {
+		return nil, fmt.Errorf("listing LimitRanges: %w", err)
+	}
+
+	out := make([]model.LimitRangeDigest, 0, len(ranges))
+	for _, lr := range ranges {
+		out = append(out, limitRangeToDigest(&lr))
+	}
+	return out, nil
+}
+
+// CollectLimitRangeFromBaselineDir scans a baseline YAML directory for
+// LimitRange manifests. A manifest without a namespace is taken to be
+// in "default", where kubectl apply would create it.
+func CollectLimitRangeFromBaselineDir(dir string, cfg Config) ([]model.LimitRangeDigest, error) {
+	var out []model.LimitRangeDigest
+
+	walkErr := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !isYAMLFile(path) {
+			return nil
+		}
+
+		f, err := openBaselineFile(path, cfg)
+		if err != nil {
+			return fmt.Errorf("opening %s: %w", path, err)
+		}
+		defer f.Close()
+
+		if err := decodeLimitRangesFromReader(f, baselineFileName(dir, path), &out); err != nil {
+			return fmt.Errorf("decoding LimitRanges from %s: %w", path, err)
+		}
+		return nil
+	})
+
+	if walkErr != nil {
+		return nil, walkErr
+	}
+	return out, nil
+}
+
+func decodeLimitRangesFromReader(r io.Reader, file string, out *[]model.LimitRangeDigest) error {
+	dec := yaml.NewYAMLOrJSONDecoder(r, 4096)
+
+	for doc := 1; ; {
+		var raw runtime.RawExtension
+		if err := dec.Decode(&raw); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return err
+		}
+		if len(raw.Raw) == 0 {
+			continue
+		}
+		ref := model.SourceRef{File: file, Document: doc}
+		doc++
+
+		var tm metav1.TypeMeta
+		if err := json.Unmarshal(raw.Raw, &tm); err != nil {
+			continue
+		}
+		if tm.Kind != "LimitRange" {
+			continue
+		}
+
+		var lr corev1.LimitRange
+		if err := json.Unmarshal(raw.Raw, &lr); err != nil {
+			continue
+		}
+		if lr.Namespace == "" {
+			lr.Namespace = metav1.NamespaceDefault
+		}
+		digest := limitRangeToDigest(&lr)
+		digest.DefinedIn = &ref
+		*out = append(*out, digest)
+	}
+
+	return nil
+}
+
+func limitRangeToDigest(lr *corev1.LimitRange) model.LimitRangeDigest {
+	items := make([]model.LimitRangeItem, 0, len(lr.Spec.Limits))
+	for _, l := range lr.Spec.Limits {
+		items = append(items, model.LimitRangeItem{
+			Type:           string(l.Type),
+			Default:        quantities(l.Default),
+			DefaultRequest: quantities(l.DefaultRequest),
+			Max:            quantities(l.Max),
+			Min:            quantities(l.Min),
+		})
+	}
+	return model.LimitRangeDigest{
+		Namespace: lr.Namespace,
+		Name:      lr.Name,
+		Limits:    items,
+	}
+}
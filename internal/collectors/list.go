@@ -0,0 +1,75 @@
+package collectors
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Log receives a debug line per completed List (kind, object count, pages
+// and duration). It discards everything unless set.
+var Log = slog.New(slog.DiscardHandler)
+
+// listPageSize bounds the items per List response so large clusters are
+// read in pages instead of one response that may exceed server limits.
+const listPageSize = 500
+
+// maxListRestarts bounds how often listAll starts over after its continue
+// token expired, so a cluster changing faster than it can be paged fails
+// instead of looping.
+const maxListRestarts = 3
+
+// listAll pages through list with Limit/Continue and returns the items of
+// every page. When the continue token expires (410 Gone), the pages read so
+// far are dropped and the listing restarts from the beginning, since pages
+// from different resource versions cannot be combined consistently.
+func listAll[T any, L interface{ GetContinue() string }](
+	ctx context.Context,
+	list func(context.Context, metav1.ListOptions) (L, error),
+	items func(L) []T,
+) ([]T, error) {
+	var out []T
+	opts := metav1.ListOptions{Limit: listPageSize}
+	restarts, pages := 0, 0
+	start := time.Now()
+	for {
+		page, err := list(ctx, opts)
+		if err != nil {
+			if opts.Continue == "" || !apierrors.IsResourceExpired(err) {
+				return nil, err
+			}
+			if restarts == maxListRestarts {
+				return nil, fmt.Errorf("continue token expired %d times: %w", restarts+1, err)
+			}
+			restarts++
+			Log.Info("list continue token expired, restarting", "kind", reflect.TypeFor[T]().Name(), "restart", restarts)
+			out = nil
+			opts.Continue = ""
+			continue
+		}
+		out = append(out, items(page)...)
+		pages++
+		if opts.Continue = page.GetContinue(); opts.Continue == "" {
+			Log.Debug("listed", "kind", reflect.TypeFor[T]().Name(), "count", len(out), "pages", pages,
+				"elapsed", time.Since(start).Round(time.Millisecond))
+			return out, nil
+		}
+	}
+}
+
+// withLabelSelector wraps list so every page is requested with the given
+// label selector.
+func withLabelSelector[L any](
+	list func(context.Context, metav1.ListOptions) (L, error),
+	selector string,
+) func(context.Context, metav1.ListOptions) (L, error) {
+	return func(ctx context.Context, opts metav1.ListOptions) (L, error) {
+		opts.LabelSelector = selector
+		return list(ctx, opts)
+	}
+}
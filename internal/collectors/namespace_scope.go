@@ -0,0 +1,51 @@
+package collectors
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// SelectNamespaces lists the namespaces whose labels match selector (all
+// of them when it is empty), for Config.Namespaces. The result is never
+// nil, so a selector matching no namespace scopes collection to none.
+func SelectNamespaces(ctx context.Context, client kubernetes.Interface, selector string) ([]corev1.Namespace, error) {
+	namespaces, err := listAll(ctx, withLabelSelector(client.CoreV1().Namespaces().List, selector),
+		func(l *corev1.NamespaceList) []corev1.Namespace { return l.Items })
+	if err != nil {
+		if selector == "" {
+			return nil, fmt.Errorf("listing namespaces: %w", err)
+		}
+		return nil, fmt.Errorf("listing namespaces matching %q: %w", selector, err)
+	}
+	if namespaces == nil {
+		namespaces = []corev1.Namespace{}
+	}
+	return namespaces, nil
+}
+
+// listNamespaced lists a namespaced kind in each namespace of
+// cfg.Namespaces, or in all namespaces at once when it is nil. list
+// returns the List function of one namespace.
+func listNamespaced[T any, L interface{ GetContinue() string }](
+	ctx context.Context,
+	cfg Config,
+	list func(namespace string) func(context.Context, metav1.ListOptions) (L, error),
+	items func(L) []T,
+) ([]T, error) {
+	if cfg.Namespaces == nil {
+		return listAll(ctx, list(metav1.NamespaceAll), items)
+	}
+	var out []T
+	for _, ns := range cfg.Namespaces {
+		page, err := listAll(ctx, list(ns.Name), items)
+		if err != nil {
+			return nil, fmt.Errorf("namespace %s: %w", ns.Name, err)
+		}
+		out = append(out, page...)
+	}
+	return out, nil
+}
@@ -0,0 +1,70 @@
+package collectors
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestScopedCollectionListsSelectedNamespacesOnly(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewSimpleClientset(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "pay", Labels: map[string]string{"team": "payments"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "web"}},
+		&corev1.ResourceQuota{ObjectMeta: metav1.ObjectMeta{Namespace: "pay", Name: "q"}},
+		&corev1.ResourceQuota{ObjectMeta: metav1.ObjectMeta{Namespace: "web", Name: "q"}},
+	)
+
+	namespaces, err := SelectNamespaces(ctx, client, "team=payments")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := Config{Namespaces: namespaces}
+	client.ClearActions()
+
+	quotas, err := CollectQuotaFromCluster(ctx, client, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(quotas) != 1 || quotas[0].Namespace != "pay" {
+		t.Errorf("quotas = %v, want only pay/q", quotas)
+	}
+	psa, err := CollectPSAFromCluster(ctx, client, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(psa) != 1 || psa[0].Namespace != "pay" {
+		t.Errorf("PSA namespaces = %v, want only pay", psa)
+	}
+
+	for _, a := range client.Actions() {
+		if a.GetResource().Resource == "namespaces" {
+			t.Errorf("namespaces listed again: %v", a)
+		}
+		if l, ok := a.(k8stesting.ListAction); ok && l.GetNamespace() != "pay" {
+			t.Errorf("%s listed in namespace %q, want pay", a.GetResource().Resource, l.GetNamespace())
+		}
+	}
+}
+
+func TestSelectNamespacesMatchingNone(t *testing.T) {
+	client := fake.NewSimpleClientset(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "web"}})
+	namespaces, err := SelectNamespaces(context.Background(), client, "team=payments")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if namespaces == nil || len(namespaces) != 0 {
+		t.Fatalf("namespaces = %#v, want empty and non-nil", namespaces)
+	}
+	psa, err := CollectPSAFromCluster(context.Background(), client, Config{Namespaces: namespaces})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(psa) != 0 {
+		t.Errorf("PSA namespaces = %v, want none", psa)
+	}
+}
@@ -7,7 +7,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
-	"strings"
+	"time"
 
 	"github.com/Hru-s/driftwatch/internal/model"
 
@@ -18,33 +18,108 @@ import (
 )
 
 // CollectNetPolFromCluster builds a normalized snapshot of NetworkPolicies
-// from a live cluster.
+// from a live cluster, reading them twice when cfg.NetPolStabilizeWindow is
+// set.
 func CollectNetPolFromCluster(
 	ctx context.Context,
 	client kubernetes.Interface,
+	cfg Config,
 ) (*model.NetPolSnapshot, error) {
-	netpols, err := client.NetworkingV1().NetworkPolicies("").List(ctx, metav1.ListOptions{})
+	netpols, err := listNetPols(ctx, client, cfg)
 	if err != nil {
 		return nil, fmt.Errorf("listing NetworkPolicies: %w", err)
 	}
-	return buildNetPolSnapshot(netpols.Items)
+	snap, err := buildNetPolSnapshot(netpols, cfg.NetPolHashOnly)
+	if err != nil || cfg.NetPolStabilizeWindow <= 0 {
+		return snap, err
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, fmt.Errorf("listing NetworkPolicies: %w", ctx.Err())
+	case <-time.After(cfg.NetPolStabilizeWindow):
+	}
+	netpols, err = listNetPols(ctx, client, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("listing NetworkPolicies (second read): %w", err)
+	}
+	second, err := buildNetPolSnapshot(netpols, cfg.NetPolHashOnly)
+	if err != nil {
+		return nil, err
+	}
+	// the second read is the current state; it is only trusted for
+	// policies that did not change in between
+	for key, d := range second.Items {
+		if first, ok := snap.Items[key]; ok && first.SpecHash != d.SpecHash {
+			d.Unstable = true
+			second.Items[key] = d
+		}
+	}
+	return second, nil
 }
 
-// CollectNetPolFromBaselineDir reads NetworkPolicy YAMLs from a baseline directory.
-func CollectNetPolFromBaselineDir(dir string) (*model.NetPolSnapshot, error) {
-	netpols, err := loadNetPolYAMLFromDir(dir)
+// listNetPols lists NetworkPolicies in all namespaces, or only in
+// cfg.Namespaces when it is set.
+func listNetPols(ctx context.Context, client kubernetes.Interface, cfg Config) ([]networkingv1.NetworkPolicy, error) {
+	return listNamespaced(ctx, cfg,
+		func(ns string) func(context.Context, metav1.ListOptions) (*networkingv1.NetworkPolicyList, error) {
+			return client.NetworkingV1().NetworkPolicies(ns).List
+		},
+		func(l *networkingv1.NetworkPolicyList) []networkingv1.NetworkPolicy { return l.Items })
+}
+
+// CollectNetPolFromBaselineDir reads NetworkPolicy YAMLs from a baseline
+// directory. Policies declared more than once are reported as warnings.
+func CollectNetPolFromBaselineDir(dir string, cfg Config) (*model.NetPolSnapshot, error) {
+	netpols, sources, err := loadNetPolYAMLFromDir(dir, cfg)
 	if err != nil {
 		return nil, err
 	}
-	return buildNetPolSnapshot(netpols)
+	return buildBaselineNetPolSnapshot(netpols, sources, cfg.NetPolHashOnly)
 }
 
-func buildNetPolSnapshot(netpols []networkingv1.NetworkPolicy) (*model.NetPolSnapshot, error) {
+// CollectNetPolFromReader reads the NetworkPolicies in a single
+// multi-document YAML stream, such as a rendered Helm chart on stdin. name
+// is recorded as the file policies are defined in.
+func CollectNetPolFromReader(r io.Reader, name string, cfg Config) (*model.NetPolSnapshot, error) {
+	var netpols []networkingv1.NetworkPolicy
+	sources := baselineSources{}
+	if err := decodeNetPolsFromReader(r, name, &netpols, sources); err != nil {
+		return nil, fmt.Errorf("decode %s: %w", name, err)
+	}
+	return buildBaselineNetPolSnapshot(netpols, sources, cfg.NetPolHashOnly)
+}
+
+// buildBaselineNetPolSnapshot builds the snapshot of baseline policies,
+// locating each in the baseline and reporting duplicates as warnings.
+func buildBaselineNetPolSnapshot(netpols []networkingv1.NetworkPolicy, sources baselineSources, hashOnly bool) (*model.NetPolSnapshot, error) {
+	snap, err := buildNetPolSnapshot(netpols, hashOnly)
+	if err != nil {
+		return nil, err
+	}
+	for key, d := range snap.Items {
+		// the last declaration read is the one kept
+		if refs := sources.refs("NetworkPolicy", key); len(refs) > 0 {
+			d.DefinedIn = &refs[len(refs)-1]
+			snap.Items[key] = d
+		}
+	}
+	snap.Warnings = sources.duplicates()
+	return snap, nil
+}
+
+// buildNetPolSnapshot digests netpols, keeping only spec hashes with
+// hashOnly (Config.NetPolHashOnly).
+func buildNetPolSnapshot(netpols []networkingv1.NetworkPolicy, hashOnly bool) (*model.NetPolSnapshot, error) {
 	snap := &model.NetPolSnapshot{
 		Items: make(map[string]model.NetPolDigest),
 	}
+	newDigest := model.NewNetPolDigest
+	if hashOnly {
+		newDigest = model.NewNetPolHashDigest
+	}
 	for _, np := range netpols {
-		digest, err := model.NewNetPolDigest(&np)
+		digest, err := newDigest(&np)
 		if err != nil {
 			return nil, err
 		}
@@ -54,61 +129,69 @@ func buildNetPolSnapshot(netpols []networkingv1.NetworkPolicy) (*model.NetPolSna
 	return snap, nil
 }
 
-func loadNetPolYAMLFromDir(dir string) ([]networkingv1.NetworkPolicy, error) {
+func loadNetPolYAMLFromDir(dir string, cfg Config) ([]networkingv1.NetworkPolicy, baselineSources, error) {
 	var netpols []networkingv1.NetworkPolicy
+	sources := baselineSources{}
 
 	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
-		if info.IsDir() {
-			return nil
-		}
-		name := strings.ToLower(info.Name())
-		if !strings.HasSuffix(name, ".yaml") && !strings.HasSuffix(name, ".yml") {
+		if info.IsDir() || !isYAMLFile(path) {
 			return nil
 		}
 
-		f, err := os.Open(path)
+		f, err := openBaselineFile(path, cfg)
 		if err != nil {
 			return fmt.Errorf("open %s: %w", path, err)
 		}
 		defer f.Close()
 
-		dec := yamlutil.NewYAMLOrJSONDecoder(f, 4096)
-		for {
-			var raw map[string]interface{}
-			if err := dec.Decode(&raw); err != nil {
-				if err == io.EOF {
-					break
-				}
-				return fmt.Errorf("decode %s: %w", path, err)
-			}
-			if len(raw) == 0 {
-				continue
-			}
+		if err := decodeNetPolsFromReader(f, baselineFileName(dir, path), &netpols, sources); err != nil {
+			return fmt.Errorf("decode %s: %w", path, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
 
-			kind, _ := raw["kind"].(string)
-			if kind != "NetworkPolicy" {
-				continue
-			}
+	return netpols, sources, nil
+}
 
-			b, err := json.Marshal(raw)
-			if err != nil {
-				return fmt.Errorf("marshal %s: %w", path, err)
+// decodeNetPolsFromReader appends the NetworkPolicies in the
+// multi-document YAML (or JSON) stream r to out, recording file and the
+// document number of each in sources.
+func decodeNetPolsFromReader(r io.Reader, file string, out *[]networkingv1.NetworkPolicy, sources baselineSources) error {
+	dec := yamlutil.NewYAMLOrJSONDecoder(r, 4096)
+	for doc := 1; ; {
+		var raw map[string]interface{}
+		if err := dec.Decode(&raw); err != nil {
+			if err == io.EOF {
+				return nil
 			}
+			return err
+		}
+		if len(raw) == 0 {
+			continue
+		}
+		ref := model.SourceRef{File: file, Document: doc}
+		doc++
 
-			var np networkingv1.NetworkPolicy
-			if err := json.Unmarshal(b, &np); err == nil {
-				netpols = append(netpols, np)
-			}
+		kind, _ := raw["kind"].(string)
+		if kind != "NetworkPolicy" {
+			continue
 		}
 
-		return nil
-	})
-	if err != nil {
-		return nil, err
-	}
+		b, err := json.Marshal(raw)
+		if err != nil {
+			return fmt.Errorf("marshal document %d: %w", ref.Document, err)
+		}
 
-	return netpols, nil
+		var np networkingv1.NetworkPolicy
+		if err := json.Unmarshal(b, &np); err == nil {
+			*out = append(*out, np)
+			sources.add(ref, kind, np.Namespace+"/"+np.Name)
+		}
+	}
 }
@@ -19,15 +19,22 @@ import (
 	"k8s.io/client-go/kubernetes"
 )
 
-// CollectPSAFromCluster lists namespaces in the cluster and extracts PSA labels.
-func CollectPSAFromCluster(ctx context.Context, client *kubernetes.Clientset) ([]model.NamespacePSA, error) {
-	nsList, err := client.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
-	if err != nil {
-		return nil, fmt.Errorf("listing namespaces: %w", err)
+// CollectPSAFromCluster lists namespaces in the cluster and extracts PSA
+// labels. With cfg.Namespaces set, those namespaces are used instead of
+// listing them.
+func CollectPSAFromCluster(ctx context.Context, client kubernetes.Interface, cfg Config) ([]model.NamespacePSA, error) {
+	namespaces := cfg.Namespaces
+	if namespaces == nil {
+		var err error
+		namespaces, err = listAll(ctx, client.CoreV1().Namespaces().List,
+			func(l *corev1.NamespaceList) []corev1.Namespace { return l.Items })
+		if err != nil {
+			return nil, fmt.Errorf("listing namespaces: %w", err)
+		}
 	}
 
 	var out []model.NamespacePSA
-	for _, ns := range nsList.Items {
+	for _, ns := range namespaces {
 		out = append(out, namespaceToPSA(&ns))
 	}
 	return out, nil
@@ -35,7 +42,7 @@ func CollectPSAFromCluster(ctx context.Context, client *kubernetes.Clientset) ([
 
 // CollectPSAFromBaselineDir scans a baseline YAML directory for Namespace
 // manifests and extracts PSA labels from them.
-func CollectPSAFromBaselineDir(dir string) ([]model.NamespacePSA, error) {
+func CollectPSAFromBaselineDir(dir string, cfg Config) ([]model.NamespacePSA, error) {
 	var out []model.NamespacePSA
 
 	walkErr := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
@@ -49,13 +56,13 @@ func CollectPSAFromBaselineDir(dir string) ([]model.NamespacePSA, error) {
 			return nil
 		}
 
-		f, err := os.Open(path)
+		f, err := openBaselineFile(path, cfg)
 		if err != nil {
 			return fmt.Errorf("opening %s: %w", path, err)
 		}
 		defer f.Close()
 
-		if err := decodePSANamespacesFromReader(f, &out); err != nil {
+		if err := decodePSANamespacesFromReader(f, baselineFileName(dir, path), &out); err != nil {
 			return fmt.Errorf("decoding namespaces from %s: %w", path, err)
 		}
 		return nil
@@ -67,6 +74,17 @@ func CollectPSAFromBaselineDir(dir string) ([]model.NamespacePSA, error) {
 	return out, nil
 }
 
+// CollectPSAFromReader extracts PSA labels from the Namespace manifests in
+// a single multi-document YAML stream, such as a rendered Helm chart on
+// stdin. name is recorded as the file namespaces are defined in.
+func CollectPSAFromReader(r io.Reader, name string) ([]model.NamespacePSA, error) {
+	var out []model.NamespacePSA
+	if err := decodePSANamespacesFromReader(r, name, &out); err != nil {
+		return nil, fmt.Errorf("decoding namespaces from %s: %w", name, err)
+	}
+	return out, nil
+}
+
 // --- helpers ---------------------------------------------------------------
 
 func isYAMLFile(path string) bool {
@@ -74,10 +92,12 @@ func isYAMLFile(path string) bool {
 	return strings.HasSuffix(name, ".yaml") || strings.HasSuffix(name, ".yml")
 }
 
-func decodePSANamespacesFromReader(r io.Reader, out *[]model.NamespacePSA) error {
+// decodePSANamespacesFromReader appends the Namespaces in r to out,
+// recording file and the document number as each one's DefinedIn.
+func decodePSANamespacesFromReader(r io.Reader, file string, out *[]model.NamespacePSA) error {
 	dec := yaml.NewYAMLOrJSONDecoder(r, 4096)
 
-	for {
+	for doc := 1; ; {
 		var raw runtime.RawExtension
 		if err := dec.Decode(&raw); err != nil {
 			if errors.Is(err, io.EOF) {
@@ -88,6 +108,8 @@ func decodePSANamespacesFromReader(r io.Reader, out *[]model.NamespacePSA) error
 		if len(raw.Raw) == 0 {
 			continue
 		}
+		ref := model.SourceRef{File: file, Document: doc}
+		doc++
 
 		// Quick type check by decoding TypeMeta.
 		var tm metav1.TypeMeta
@@ -102,7 +124,9 @@ func decodePSANamespacesFromReader(r io.Reader, out *[]model.NamespacePSA) error
 		if err := json.Unmarshal(raw.Raw, &ns); err != nil {
 			continue
 		}
-		*out = append(*out, namespaceToPSA(&ns))
+		psa := namespaceToPSA(&ns)
+		psa.DefinedIn = &ref
+		*out = append(*out, psa)
 	}
 
 	return nil
@@ -118,9 +142,11 @@ func namespaceToPSA(ns *corev1.Namespace) model.NamespacePSA {
 	}
 
 	return model.NamespacePSA{
-		Namespace: ns.Name,
-		Enforce:   get("pod-security.kubernetes.io/enforce"),
-		Audit:     get("pod-security.kubernetes.io/audit"),
-		Warn:      get("pod-security.kubernetes.io/warn"),
+		Namespace:   ns.Name,
+		Enforce:     get("pod-security.kubernetes.io/enforce"),
+		Audit:       get("pod-security.kubernetes.io/audit"),
+		Warn:        get("pod-security.kubernetes.io/warn"),
+		Labels:      ns.Labels,
+		Annotations: ns.Annotations,
 	}
 }
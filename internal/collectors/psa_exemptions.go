@@ -0,0 +1,61 @@
+package collectors
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/Hru-s/driftwatch/internal/model"
+
+	yamlutil "k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// LoadPSAExemptions reads PodSecurity exemptions from the file passed to the
+// API server. Accepted shapes are a full AdmissionConfiguration (the
+// PodSecurity plugin entry is used), a bare PodSecurityConfiguration, or just
+// the exemptions object:
+//
+//	exemptions:
+//	  usernames: [system:serviceaccount:ci:deployer]
+//	  runtimeClasses: [kata]
+//	  namespaces: [legacy-apps]
+func LoadPSAExemptions(path string) (model.PSAExemptions, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return model.PSAExemptions{}, fmt.Errorf("open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	var raw struct {
+		Kind    string `json:"kind"`
+		Plugins []struct {
+			Name          string          `json:"name"`
+			Configuration json.RawMessage `json:"configuration"`
+		} `json:"plugins"`
+		Exemptions *model.PSAExemptions `json:"exemptions"`
+		model.PSAExemptions
+	}
+	if err := yamlutil.NewYAMLOrJSONDecoder(f, 4096).Decode(&raw); err != nil {
+		return model.PSAExemptions{}, fmt.Errorf("decode PSA exemptions %s: %w", path, err)
+	}
+
+	if raw.Kind == "AdmissionConfiguration" {
+		for _, p := range raw.Plugins {
+			if p.Name != "PodSecurity" || len(p.Configuration) == 0 {
+				continue
+			}
+			var cfg struct {
+				Exemptions model.PSAExemptions `json:"exemptions"`
+			}
+			if err := json.Unmarshal(p.Configuration, &cfg); err != nil {
+				return model.PSAExemptions{}, fmt.Errorf("decode PodSecurity plugin configuration in %s: %w", path, err)
+			}
+			return cfg.Exemptions, nil
+		}
+		return model.PSAExemptions{}, fmt.Errorf("%s: AdmissionConfiguration has no inline PodSecurity plugin configuration", path)
+	}
+	if raw.Exemptions != nil {
+		return *raw.Exemptions, nil
+	}
+	return raw.PSAExemptions, nil
+}
@@ -0,0 +1,131 @@
+package collectors
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/Hru-s/driftwatch/internal/model"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/kubernetes"
+)
+
+// CollectQuotaFromCluster lists ResourceQuotas in all namespaces, or in
+// cfg.Namespaces when it is set.
+func CollectQuotaFromCluster(ctx context.Context, client kubernetes.Interface, cfg Config) ([]model.ResourceQuotaDigest, error) {
+	quotas, err := listNamespaced(ctx, cfg,
+		func(ns string) func(context.Context, metav1.ListOptions) (*corev1.ResourceQuotaList, error) {
+			return client.CoreV1().ResourceQuotas(ns).List
+		},
+		func(l *corev1.ResourceQuotaList) []corev1.ResourceQuota { return l.Items })
+	if err != nil {
+		return nil, fmt.Errorf("listing ResourceQuotas: %w", err)
+	}
+
+	out := make([]model.ResourceQuotaDigest, 0, len(quotas))
+	for _, q := range quotas {
+		out = append(out, quotaToDigest(&q))
+	}
+	return out, nil
+}
+
+// CollectQuotaFromBaselineDir scans a baseline YAML directory for
+// ResourceQuota manifests. A manifest without a namespace is taken to be
+// in "default", where kubectl apply would create it.
+func CollectQuotaFromBaselineDir(dir string, cfg Config) ([]model.ResourceQuotaDigest, error) {
+	var out []model.ResourceQuotaDigest
+
+	walkErr := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !isYAMLFile(path) {
+			return nil
+		}
+
+		f, err := openBaselineFile(path, cfg)
+		if err != nil {
+			return fmt.Errorf("opening %s: %w", path, err)
+		}
+		defer f.Close()
+
+		if err := decodeQuotasFromReader(f, baselineFileName(dir, path), &out); err != nil {
+			return fmt.Errorf("decoding ResourceQuotas from %s: %w", path, err)
+		}
+		return nil
+	})
+
+	if walkErr != nil {
+		return nil, walkErr
+	}
+	return out, nil
+}
+
+func decodeQuotasFromReader(r io.Reader, file string, out *[]model.ResourceQuotaDigest) error {
+	dec := yaml.NewYAMLOrJSONDecoder(r, 4096)
+
+	for doc := 1; ; {
+		var raw runtime.RawExtension
+		if err := dec.Decode(&raw); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return err
+		}
+		if len(raw.Raw) == 0 {
+			continue
+		}
+		ref := model.SourceRef{File: file, Document: doc}
+		doc++
+
+		var tm metav1.TypeMeta
+		if err := json.Unmarshal(raw.Raw, &tm); err != nil {
+			continue
+		}
+		if tm.Kind != "ResourceQuota" {
+			continue
+		}
+
+		var q corev1.ResourceQuota
+		if err := json.Unmarshal(raw.Raw, &q); err != nil {
+			continue
+		}
+		if q.Namespace == "" {
+			q.Namespace = metav1.NamespaceDefault
+		}
+		digest := quotaToDigest(&q)
+		digest.DefinedIn = &ref
+		*out = append(*out, digest)
+	}
+
+	return nil
+}
+
+func quotaToDigest(q *corev1.ResourceQuota) model.ResourceQuotaDigest {
+	return model.ResourceQuotaDigest{
+		Namespace: q.Namespace,
+		Name:      q.Name,
+		Hard:      quantities(q.Spec.Hard),
+	}
+}
+
+// quantities renders a resource list in canonical form ("1000m" -> "1"),
+// so lists written differently compare equal. An empty list yields nil.
+func quantities(list corev1.ResourceList) map[string]string {
+	if len(list) == 0 {
+		return nil
+	}
+	out := make(map[string]string, len(list))
+	for name, qty := range list {
+		out[string(name)] = qty.String()
+	}
+	return out
+}
@@ -7,61 +7,98 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strings"
 
 	"github.com/Hru-s/driftwatch/internal/model"
 
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	yamlutil "k8s.io/apimachinery/pkg/util/yaml"
 	"k8s.io/client-go/kubernetes"
 )
 
 // CollectRBACFromCluster normalizes effective RBAC from a live cluster.
+// With cfg.Namespaces set, Roles and RoleBindings are only listed in those
+// namespaces.
 func CollectRBACFromCluster(
 	ctx context.Context,
 	client kubernetes.Interface,
+	cfg Config,
 ) (*model.RBACSnapshot, error) {
-	rolesList, err := client.RbacV1().Roles("").List(ctx, metav1.ListOptions{})
+	roles, err := listNamespaced(ctx, cfg,
+		func(ns string) func(context.Context, metav1.ListOptions) (*rbacv1.RoleList, error) {
+			return client.RbacV1().Roles(ns).List
+		},
+		func(l *rbacv1.RoleList) []rbacv1.Role { return l.Items })
 	if err != nil {
 		return nil, fmt.Errorf("listing Roles: %w", err)
 	}
-	clusterRolesList, err := client.RbacV1().ClusterRoles().List(ctx, metav1.ListOptions{})
+	clusterRoles, err := listAll(ctx, client.RbacV1().ClusterRoles().List,
+		func(l *rbacv1.ClusterRoleList) []rbacv1.ClusterRole { return l.Items })
 	if err != nil {
 		return nil, fmt.Errorf("listing ClusterRoles: %w", err)
 	}
-	roleBindingsList, err := client.RbacV1().RoleBindings("").List(ctx, metav1.ListOptions{})
+	roleBindings, err := listNamespaced(ctx, cfg,
+		func(ns string) func(context.Context, metav1.ListOptions) (*rbacv1.RoleBindingList, error) {
+			return client.RbacV1().RoleBindings(ns).List
+		},
+		func(l *rbacv1.RoleBindingList) []rbacv1.RoleBinding { return l.Items })
 	if err != nil {
 		return nil, fmt.Errorf("listing RoleBindings: %w", err)
 	}
-	clusterRoleBindingsList, err := client.RbacV1().ClusterRoleBindings().List(ctx, metav1.ListOptions{})
+	clusterRoleBindings, err := listAll(ctx, client.RbacV1().ClusterRoleBindings().List,
+		func(l *rbacv1.ClusterRoleBindingList) []rbacv1.ClusterRoleBinding { return l.Items })
 	if err != nil {
 		return nil, fmt.Errorf("listing ClusterRoleBindings: %w", err)
 	}
 
-	return buildRBACSnapshot(
-		rolesList.Items,
-		clusterRolesList.Items,
-		roleBindingsList.Items,
-		clusterRoleBindingsList.Items,
-	), nil
+	return buildRBACSnapshot(roles, clusterRoles, roleBindings, clusterRoleBindings, nil, cfg.SkipDefaultClusterRoles), nil
 }
 
 // CollectRBACFromBaselineDir reads RBAC YAML (Roles, ClusterRoles, *Bindings)
-// from a baseline directory and builds a normalized snapshot.
-func CollectRBACFromBaselineDir(dir string) (*model.RBACSnapshot, error) {
-	roles, clusterRoles, roleBindings, clusterRoleBindings, err := loadRBACYAMLFromDir(dir)
+// from a baseline directory and builds a normalized snapshot. Objects
+// declared more than once, and policy-relevant documents of kinds no
+// collector reads, are reported as snapshot warnings (errors for the
+// latter with cfg.Strict).
+func CollectRBACFromBaselineDir(dir string, cfg Config) (*model.RBACSnapshot, error) {
+	m, err := loadRBACYAMLFromDir(dir, cfg)
 	if err != nil {
 		return nil, err
 	}
-	return buildRBACSnapshot(roles, clusterRoles, roleBindings, clusterRoleBindings), nil
+	if err := m.strictErr(cfg.Strict); err != nil {
+		return nil, err
+	}
+	return m.snapshot(cfg.SkipDefaultClusterRoles), nil
+}
+
+// CollectRBACFromReader builds a baseline RBAC snapshot from a single
+// multi-document YAML stream, such as a rendered Helm chart on stdin. name
+// is recorded as the file objects are defined in.
+func CollectRBACFromReader(r io.Reader, name string, cfg Config) (*model.RBACSnapshot, error) {
+	m := &rbacManifests{sources: baselineSources{}}
+	if err := m.decode(r, name); err != nil {
+		return nil, fmt.Errorf("decode %s: %w", name, err)
+	}
+	if err := m.strictErr(cfg.Strict); err != nil {
+		return nil, err
+	}
+	return m.snapshot(cfg.SkipDefaultClusterRoles), nil
 }
 
+// buildRBACSnapshot expands the bindings into per-subject permissions.
+// definedIn, when non-nil, locates an object in the baseline directory and
+// is used to fill snapshot.DefinedIn. skipDefaults drops the built-in
+// ClusterRoles and their bootstrap bindings (Config.SkipDefaultClusterRoles).
 func buildRBACSnapshot(
 	roles []rbacv1.Role,
 	clusterRoles []rbacv1.ClusterRole,
 	roleBindings []rbacv1.RoleBinding,
 	clusterRoleBindings []rbacv1.ClusterRoleBinding,
+	definedIn func(kind, key string) []model.SourceRef,
+	skipDefaults bool,
 ) *model.RBACSnapshot {
 	snapshot := &model.RBACSnapshot{
 		Subjects: make(map[model.SubjectKey]map[model.Permission]struct{}),
@@ -73,23 +110,59 @@ func buildRBACSnapshot(
 		rolesByKey[key] = append(rolesByKey[key], r.Rules...)
 	}
 
-	clusterRolesByName := make(map[string][]rbacv1.PolicyRule)
-	for _, cr := range clusterRoles {
-		clusterRolesByName[cr.Name] = append(clusterRolesByName[cr.Name], cr.Rules...)
+	clusterRolesByName, cycles := resolveClusterRoleRules(clusterRoles)
+	snapshot.ClusterRoles = clusterRolesByName
+	if skipDefaults {
+		snapshot.ClusterRoles = make(map[string][]rbacv1.PolicyRule, len(clusterRolesByName))
+		for name, rules := range clusterRolesByName {
+			snapshot.ClusterRoles[name] = rules
+		}
+		for _, cr := range clusterRoles {
+			if isDefaultClusterRole(cr.ObjectMeta) {
+				delete(snapshot.ClusterRoles, cr.Name)
+			}
+		}
+	}
+	for _, cycle := range cycles {
+		snapshot.Warnings = append(snapshot.Warnings,
+			fmt.Sprintf("circular ClusterRole aggregation: %s (each role gets the rules of the whole cycle)", strings.Join(cycle, " -> ")))
 	}
 
 	// namespaced RoleBindings
 	for _, rb := range roleBindings {
+		if skipDefaults && isBootstrapObject(rb.ObjectMeta) {
+			continue
+		}
+		ref := model.RoleRef{Kind: rb.RoleRef.Kind, Name: rb.RoleRef.Name, Namespace: rb.Namespace}
+		for _, subj := range rb.Subjects {
+			snapshot.AddBinding(ref, model.SubjectKeyFromRBACSubject(subj, rb.Namespace))
+		}
+
 		var rules []rbacv1.PolicyRule
+		var exists bool
+		roleKey := rb.RoleRef.Name
 		switch rb.RoleRef.Kind {
 		case "Role":
-			key := rb.Namespace + "/" + rb.RoleRef.Name
-			rules = rolesByKey[key]
+			roleKey = rb.Namespace + "/" + rb.RoleRef.Name
+			rules, exists = rolesByKey[roleKey]
 		case "ClusterRole":
-			rules = clusterRolesByName[rb.RoleRef.Name]
+			rules, exists = clusterRolesByName[rb.RoleRef.Name]
+			// a baseline need not declare the built-in ClusterRoles it binds
+			exists = exists || (definedIn != nil && isBuiltinClusterRoleName(rb.RoleRef.Name))
 		default:
 			continue
 		}
+		if !exists {
+			d := model.DanglingBinding{Kind: "RoleBinding", Namespace: rb.Namespace, Name: rb.Name, Role: ref}
+			for _, subj := range rb.Subjects {
+				d.Subjects = append(d.Subjects, model.SubjectKeyFromRBACSubject(subj, rb.Namespace))
+			}
+			if definedIn != nil {
+				d.DefinedIn = definedIn("RoleBinding", rb.Namespace+"/"+rb.Name)
+			}
+			snapshot.Dangling = append(snapshot.Dangling, d)
+			continue
+		}
 		if len(rules) == 0 {
 			continue
 		}
@@ -99,15 +172,40 @@ func buildRBACSnapshot(
 			continue
 		}
 
+		var declared []model.SourceRef
+		if definedIn != nil {
+			declared = slices.Concat(definedIn(rb.RoleRef.Kind, roleKey), definedIn("RoleBinding", rb.Namespace+"/"+rb.Name))
+		}
 		for _, subj := range rb.Subjects {
 			subjKey := model.SubjectKeyFromRBACSubject(subj, rb.Namespace)
 			snapshot.AddPermissions(subjKey, perms)
+			snapshot.AddDefinedIn(subjKey, perms, declared)
 		}
 	}
 
 	// ClusterRoleBindings (cluster-scope)
 	for _, crb := range clusterRoleBindings {
-		rules := clusterRolesByName[crb.RoleRef.Name]
+		if skipDefaults && isBootstrapObject(crb.ObjectMeta) {
+			continue
+		}
+		ref := model.RoleRef{Kind: "ClusterRole", Name: crb.RoleRef.Name}
+		subjects := clusterRoleBindingSubjects(crb, snapshot)
+		for _, subj := range subjects {
+			snapshot.AddBinding(ref, model.SubjectKeyFromRBACSubject(subj, ""))
+		}
+
+		rules, exists := clusterRolesByName[crb.RoleRef.Name]
+		if !exists && !(definedIn != nil && isBuiltinClusterRoleName(crb.RoleRef.Name)) {
+			d := model.DanglingBinding{Kind: "ClusterRoleBinding", Name: crb.Name, Role: ref}
+			for _, subj := range subjects {
+				d.Subjects = append(d.Subjects, model.SubjectKeyFromRBACSubject(subj, ""))
+			}
+			if definedIn != nil {
+				d.DefinedIn = definedIn("ClusterRoleBinding", crb.Name)
+			}
+			snapshot.Dangling = append(snapshot.Dangling, d)
+			continue
+		}
 		if len(rules) == 0 {
 			continue
 		}
@@ -117,99 +215,289 @@ func buildRBACSnapshot(
 			continue
 		}
 
-		for _, subj := range crb.Subjects {
+		var declared []model.SourceRef
+		if definedIn != nil {
+			declared = slices.Concat(definedIn("ClusterRole", crb.RoleRef.Name), definedIn("ClusterRoleBinding", crb.Name))
+		}
+		for _, subj := range subjects {
 			subjKey := model.SubjectKeyFromRBACSubject(subj, "")
 			snapshot.AddPermissions(subjKey, perms)
+			snapshot.AddDefinedIn(subjKey, perms, declared)
 		}
 	}
 
 	return snapshot
 }
 
-func loadRBACYAMLFromDir(dir string) (
-	[]rbacv1.Role,
-	[]rbacv1.ClusterRole,
-	[]rbacv1.RoleBinding,
-	[]rbacv1.ClusterRoleBinding,
-	error,
-) {
-	var roles []rbacv1.Role
-	var clusterRoles []rbacv1.ClusterRole
-	var roleBindings []rbacv1.RoleBinding
-	var clusterRoleBindings []rbacv1.ClusterRoleBinding
-
-	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
-		if info.IsDir() {
-			return nil
-		}
-		name := strings.ToLower(info.Name())
-		if !strings.HasSuffix(name, ".yaml") && !strings.HasSuffix(name, ".yml") {
-			return nil
+// clusterRoleBindingSubjects returns the subjects of crb that can be keyed.
+// A ServiceAccount subject of a ClusterRoleBinding must name its namespace
+// (there is no binding namespace to default to, and the API server rejects
+// it), so such subjects are skipped with a warning instead of being
+// compared as a namespace-less ServiceAccount that never matches live.
+func clusterRoleBindingSubjects(crb rbacv1.ClusterRoleBinding, snapshot *model.RBACSnapshot) []rbacv1.Subject {
+	out := make([]rbacv1.Subject, 0, len(crb.Subjects))
+	for _, subj := range crb.Subjects {
+		if subj.Kind == rbacv1.ServiceAccountKind && subj.Namespace == "" {
+			snapshot.Warnings = append(snapshot.Warnings, fmt.Sprintf(
+				"ClusterRoleBinding %s: ServiceAccount subject %q has no namespace (invalid); subject ignored", crb.Name, subj.Name))
+			continue
 		}
+		out = append(out, subj)
+	}
+	return out
+}
 
-		f, err := os.Open(path)
-		if err != nil {
-			return fmt.Errorf("open %s: %w", path, err)
-		}
-		defer f.Close()
+// resolveClusterRoleRules returns the effective rules per ClusterRole name,
+// expanding aggregationRule selectors the way the aggregation controller
+// does (baseline YAML usually ships aggregated roles with empty rules).
+// Like the controller's fixed point, a role gets the rules of every role it
+// reaches through aggregation, so roles on a cycle all end up with the
+// rules of the whole cycle, whatever order they are visited in. Aggregation
+// cycles are returned so they can be reported.
+func resolveClusterRoleRules(clusterRoles []rbacv1.ClusterRole) (map[string][]rbacv1.PolicyRule, [][]string) {
+	own := make(map[string][]rbacv1.PolicyRule)
+	byName := make(map[string]rbacv1.ClusterRole)
+	for _, cr := range clusterRoles {
+		own[cr.Name] = append(own[cr.Name], cr.Rules...)
+		byName[cr.Name] = cr
+	}
 
-		dec := yamlutil.NewYAMLOrJSONDecoder(f, 4096)
-		for {
-			var raw map[string]interface{}
-			if err := dec.Decode(&raw); err != nil {
-				if err == io.EOF {
-					break
+	names := make([]string, 0, len(byName))
+	for name := range byName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// aggregates[name] are the roles name's selectors match, sorted
+	aggregates := make(map[string][]string, len(names))
+	for _, name := range names {
+		agg := byName[name].AggregationRule
+		if agg == nil {
+			continue
+		}
+		matched := map[string]bool{}
+		for _, sel := range agg.ClusterRoleSelectors {
+			selector, err := metav1.LabelSelectorAsSelector(&sel)
+			if err != nil {
+				continue
+			}
+			for _, other := range names {
+				// the aggregation controller never aggregates a role into itself
+				if other != name && selector.Matches(labels.Set(byName[other].Labels)) {
+					matched[other] = true
 				}
-				return fmt.Errorf("decode %s: %w", path, err)
 			}
-			if len(raw) == 0 {
-				continue
+		}
+		for _, other := range names {
+			if matched[other] {
+				aggregates[name] = append(aggregates[name], other)
 			}
+		}
+	}
 
-			kind, _ := raw["kind"].(string)
-			if kind == "" {
+	resolved := make(map[string][]rbacv1.PolicyRule, len(names))
+	for _, name := range names {
+		rules := append([]rbacv1.PolicyRule(nil), own[name]...)
+		seen := map[string]bool{name: true}
+		queue := append([]string(nil), aggregates[name]...)
+		for len(queue) > 0 {
+			other := queue[0]
+			queue = queue[1:]
+			if seen[other] {
 				continue
 			}
+			seen[other] = true
+			rules = append(rules, own[other]...)
+			queue = append(queue, aggregates[other]...)
+		}
+		resolved[name] = rules
+	}
+	return resolved, aggregationCycles(names, aggregates)
+}
 
-			b, err := json.Marshal(raw)
-			if err != nil {
-				return fmt.Errorf("marshal %s: %w", path, err)
-			}
+// aggregationCycles returns the cycles of the aggregation graph, each as
+// the path from its first role back to that role, found in name order.
+func aggregationCycles(names []string, aggregates map[string][]string) [][]string {
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[string]int, len(names))
+	var cycles [][]string
 
-			switch kind {
-			case "Role":
-				var r rbacv1.Role
-				if err := json.Unmarshal(b, &r); err == nil {
-					roles = append(roles, r)
-				}
-			case "ClusterRole":
-				var cr rbacv1.ClusterRole
-				if err := json.Unmarshal(b, &cr); err == nil {
-					clusterRoles = append(clusterRoles, cr)
-				}
-			case "RoleBinding":
-				var rb rbacv1.RoleBinding
-				if err := json.Unmarshal(b, &rb); err == nil {
-					roleBindings = append(roleBindings, rb)
-				}
-			case "ClusterRoleBinding":
-				var crb rbacv1.ClusterRoleBinding
-				if err := json.Unmarshal(b, &crb); err == nil {
-					clusterRoleBindings = append(clusterRoleBindings, crb)
+	var visit func(name string, path []string)
+	visit = func(name string, path []string) {
+		switch state[name] {
+		case done:
+			return
+		case visiting:
+			for i, n := range path {
+				if n == name {
+					cycles = append(cycles, append(append([]string(nil), path[i:]...), name))
+					break
 				}
-			default:
-				// ignore other Kinds
 			}
+			return
+		}
+		state[name] = visiting
+		for _, other := range aggregates[name] {
+			visit(other, append(path, name))
+		}
+		state[name] = done
+	}
+	for _, name := range names {
+		if state[name] == unvisited {
+			visit(name, nil)
+		}
+	}
+	return cycles
+}
+
+// rbacManifests accumulates the RBAC objects decoded from baseline YAML.
+type rbacManifests struct {
+	roles               []rbacv1.Role
+	clusterRoles        []rbacv1.ClusterRole
+	roleBindings        []rbacv1.RoleBinding
+	clusterRoleBindings []rbacv1.ClusterRoleBinding
+	sources             baselineSources
+	// skipped are the policy-relevant documents of unrecognized kinds.
+	skipped []skippedDocument
+}
+
+// skippedDocument is a baseline document the collectors did not load.
+type skippedDocument struct {
+	Ref        model.SourceRef
+	APIVersion string
+	Kind       string
+}
+
+// snapshot builds the baseline snapshot, reporting skipped documents and
+// objects declared more than once as warnings.
+func (m *rbacManifests) snapshot(skipDefaults bool) *model.RBACSnapshot {
+	snapshot := buildRBACSnapshot(m.roles, m.clusterRoles, m.roleBindings, m.clusterRoleBindings, m.sources.refs, skipDefaults)
+	warnings := append(m.skippedWarnings(), m.sources.duplicates()...)
+	snapshot.Warnings = append(warnings, snapshot.Warnings...)
+	return snapshot
+}
+
+// skippedWarnings returns one warning per skipped document, in reading
+// order, suggesting the kind a misspelled one was probably meant to be.
+func (m *rbacManifests) skippedWarnings() []string {
+	out := make([]string, 0, len(m.skipped))
+	for _, d := range m.skipped {
+		kind := d.Kind
+		if d.APIVersion != "" {
+			kind += " (" + d.APIVersion + ")"
+		}
+		w := fmt.Sprintf("%s: kind %s is not recognized and was not loaded", d.Ref, kind)
+		if known := knownBaselineKind(d.Kind); known != "" {
+			w += fmt.Sprintf("; did you mean %s?", known)
+		}
+		out = append(out, w)
+	}
+	return out
+}
+
+// strictErr fails a strict (Config.Strict) load that skipped documents.
+func (m *rbacManifests) strictErr(strict bool) error {
+	if !strict || len(m.skipped) == 0 {
+		return nil
+	}
+	return fmt.Errorf("baseline has %d document(s) of unrecognized kinds: %s",
+		len(m.skipped), strings.Join(m.skippedWarnings(), "; "))
+}
+
+func loadRBACYAMLFromDir(dir string, cfg Config) (*rbacManifests, error) {
+	m := &rbacManifests{sources: baselineSources{}}
+
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
 		}
+		if info.IsDir() || !isYAMLFile(path) {
+			return nil
+		}
+
+		f, err := openBaselineFile(path, cfg)
+		if err != nil {
+			return fmt.Errorf("open %s: %w", path, err)
+		}
+		defer f.Close()
 
+		if err := m.decode(f, baselineFileName(dir, path)); err != nil {
+			return fmt.Errorf("decode %s: %w", path, err)
+		}
 		return nil
 	})
 	if err != nil {
-		return nil, nil, nil, nil, err
+		return nil, err
 	}
+	return m, nil
+}
+
+// decode appends the Roles, ClusterRoles and bindings in the
+// multi-document YAML (or JSON) stream r, recording file and the document
+// number as where each was declared. Other kinds are skipped; policy-relevant
+// ones are recorded in m.skipped.
+func (m *rbacManifests) decode(r io.Reader, file string) error {
+	dec := yamlutil.NewYAMLOrJSONDecoder(r, 4096)
+	for doc := 1; ; {
+		var raw map[string]interface{}
+		if err := dec.Decode(&raw); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		if len(raw) == 0 {
+			continue
+		}
+		ref := model.SourceRef{File: file, Document: doc}
+		doc++
 
-	return roles, clusterRoles, roleBindings, clusterRoleBindings, nil
+		kind, _ := raw["kind"].(string)
+		if kind == "" {
+			continue
+		}
+
+		b, err := json.Marshal(raw)
+		if err != nil {
+			return fmt.Errorf("marshal document %d: %w", ref.Document, err)
+		}
+
+		switch kind {
+		case "Role":
+			var r rbacv1.Role
+			if err := json.Unmarshal(b, &r); err == nil {
+				m.roles = append(m.roles, r)
+				m.sources.add(ref, kind, r.Namespace+"/"+r.Name)
+			}
+		case "ClusterRole":
+			var cr rbacv1.ClusterRole
+			if err := json.Unmarshal(b, &cr); err == nil {
+				m.clusterRoles = append(m.clusterRoles, cr)
+				m.sources.add(ref, kind, cr.Name)
+			}
+		case "RoleBinding":
+			var rb rbacv1.RoleBinding
+			if err := json.Unmarshal(b, &rb); err == nil {
+				m.roleBindings = append(m.roleBindings, rb)
+				m.sources.add(ref, kind, rb.Namespace+"/"+rb.Name)
+			}
+		case "ClusterRoleBinding":
+			var crb rbacv1.ClusterRoleBinding
+			if err := json.Unmarshal(b, &crb); err == nil {
+				m.clusterRoleBindings = append(m.clusterRoleBindings, crb)
+				m.sources.add(ref, kind, crb.Name)
+			}
+		default:
+			// other kinds are left to the other collectors, or are not
+			// policy at all
+			apiVersion, _ := raw["apiVersion"].(string)
+			if unrecognizedPolicyKind(apiVersion, kind) {
+				m.skipped = append(m.skipped, skippedDocument{Ref: ref, APIVersion: apiVersion, Kind: kind})
+			}
+		}
+	}
 }
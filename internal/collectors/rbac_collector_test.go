@@ -0,0 +1,87 @@
+package collectors
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/Hru-s/driftwatch/internal/model"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+const circularFixture = "../../test/aggregation/circular-clusterroles.yaml"
+
+// Every role on an aggregation cycle resolves to the rules of the whole
+// cycle, no matter which role the walk starts from.
+func TestResolveClusterRoleRulesCircular(t *testing.T) {
+	m, err := loadRBACYAMLFromDir(circularFixture, Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(m.clusterRoles) != 3 {
+		t.Fatalf("loaded %d ClusterRoles, want 3", len(m.clusterRoles))
+	}
+
+	want, cycles := resolveClusterRoleRules(m.clusterRoles)
+	if len(cycles) != 1 {
+		t.Errorf("cycles = %v, want one", cycles)
+	}
+	for _, name := range []string{"agg-a", "agg-b", "agg-c"} {
+		if got := ruleVerbs(want[name]); got != "get,list,watch" {
+			t.Errorf("%s resolves to verbs %s, want get,list,watch", name, got)
+		}
+	}
+
+	for _, order := range [][]int{{1, 2, 0}, {2, 1, 0}, {2, 0, 1}} {
+		roles := make([]rbacv1.ClusterRole, 0, len(order))
+		for _, i := range order {
+			roles = append(roles, m.clusterRoles[i])
+		}
+		got, _ := resolveClusterRoleRules(roles)
+		for name := range want {
+			if ruleVerbs(got[name]) != ruleVerbs(want[name]) {
+				t.Errorf("order %v: %s resolves to %s, want %s", order, name, ruleVerbs(got[name]), ruleVerbs(want[name]))
+			}
+		}
+	}
+}
+
+func TestCollectRBACCircularAggregation(t *testing.T) {
+	snap, err := CollectRBACFromBaselineDir(circularFixture, Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	user := model.SubjectKey{Kind: "User", Name: "ring-user"}
+	var got []string
+	for p := range snap.Subjects[user] {
+		got = append(got, p.Verb+" "+p.Resource)
+	}
+	want := []string{"get configmaps", "list pods", "watch services"}
+	if !sameStrings(got, want) {
+		t.Errorf("ring-user permissions = %v, want %v", got, want)
+	}
+	if len(snap.Warnings) != 1 || !strings.Contains(snap.Warnings[0], "circular ClusterRole aggregation") {
+		t.Errorf("warnings = %v, want one circular aggregation warning", snap.Warnings)
+	}
+}
+
+// ruleVerbs returns the sorted, comma-joined verbs of rules.
+func ruleVerbs(rules []rbacv1.PolicyRule) string {
+	var verbs []string
+	for _, r := range rules {
+		verbs = append(verbs, r.Verbs...)
+	}
+	return strings.Join(sortedCopy(verbs), ",")
+}
+
+func sameStrings(a, b []string) bool {
+	return reflect.DeepEqual(sortedCopy(a), sortedCopy(b))
+}
+
+func sortedCopy(s []string) []string {
+	out := append([]string(nil), s...)
+	sort.Strings(out)
+	return out
+}
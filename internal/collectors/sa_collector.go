@@ -0,0 +1,136 @@
+package collectors
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/Hru-s/driftwatch/internal/model"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/kubernetes"
+)
+
+// CollectServiceAccountsFromCluster lists ServiceAccounts in all
+// namespaces, or in cfg.Namespaces when it is set.
+func CollectServiceAccountsFromCluster(
+	ctx context.Context,
+	client kubernetes.Interface,
+	cfg Config,
+) ([]model.ServiceAccountDigest, error) {
+	sas, err := listNamespaced(ctx, cfg,
+		func(ns string) func(context.Context, metav1.ListOptions) (*corev1.ServiceAccountList, error) {
+			return client.CoreV1().ServiceAccounts(ns).List
+		},
+		func(l *corev1.ServiceAccountList) []corev1.ServiceAccount { return l.Items })
+	if err != nil {
+		return nil, fmt.Errorf("listing ServiceAccounts: %w", err)
+	}
+
+	out := make([]model.ServiceAccountDigest, 0, len(sas))
+	for _, sa := range sas {
+		out = append(out, serviceAccountToDigest(&sa))
+	}
+	return out, nil
+}
+
+// CollectServiceAccountsFromBaselineDir scans a baseline YAML directory
+// for ServiceAccount manifests. A manifest without a namespace is taken to
+// be in "default", where kubectl apply would create it.
+func CollectServiceAccountsFromBaselineDir(dir string, cfg Config) ([]model.ServiceAccountDigest, error) {
+	var out []model.ServiceAccountDigest
+
+	walkErr := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !isYAMLFile(path) {
+			return nil
+		}
+
+		f, err := openBaselineFile(path, cfg)
+		if err != nil {
+			return fmt.Errorf("opening %s: %w", path, err)
+		}
+		defer f.Close()
+
+		if err := decodeServiceAccountsFromReader(f, baselineFileName(dir, path), &out); err != nil {
+			return fmt.Errorf("decoding ServiceAccounts from %s: %w", path, err)
+		}
+		return nil
+	})
+
+	if walkErr != nil {
+		return nil, walkErr
+	}
+	return out, nil
+}
+
+func decodeServiceAccountsFromReader(r io.Reader, file string, out *[]model.ServiceAccountDigest) error {
+	dec := yaml.NewYAMLOrJSONDecoder(r, 4096)
+
+	for doc := 1; ; {
+		var raw runtime.RawExtension
+		if err := dec.Decode(&raw); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return err
+		}
+		if len(raw.Raw) == 0 {
+			continue
+		}
+		ref := model.SourceRef{File: file, Document: doc}
+		doc++
+
+		var tm metav1.TypeMeta
+		if err := json.Unmarshal(raw.Raw, &tm); err != nil {
+			continue
+		}
+		if tm.Kind != "ServiceAccount" {
+			continue
+		}
+
+		var sa corev1.ServiceAccount
+		if err := json.Unmarshal(raw.Raw, &sa); err != nil {
+			continue
+		}
+		if sa.Namespace == "" {
+			sa.Namespace = metav1.NamespaceDefault
+		}
+		digest := serviceAccountToDigest(&sa)
+		digest.DefinedIn = &ref
+		*out = append(*out, digest)
+	}
+
+	return nil
+}
+
+func serviceAccountToDigest(sa *corev1.ServiceAccount) model.ServiceAccountDigest {
+	var pullSecrets, secrets []string
+	for _, ref := range sa.ImagePullSecrets {
+		pullSecrets = append(pullSecrets, ref.Name)
+	}
+	for _, ref := range sa.Secrets {
+		secrets = append(secrets, ref.Name)
+	}
+	sort.Strings(pullSecrets)
+	sort.Strings(secrets)
+
+	return model.ServiceAccountDigest{
+		Namespace:        sa.Namespace,
+		Name:             sa.Name,
+		Annotations:      sa.Annotations,
+		AutomountToken:   sa.AutomountServiceAccountToken,
+		ImagePullSecrets: pullSecrets,
+		Secrets:          secrets,
+	}
+}
@@ -0,0 +1,299 @@
+package collectors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/kube-openapi/pkg/spec3"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+	"k8s.io/kube-openapi/pkg/validation/strfmt"
+	"k8s.io/kube-openapi/pkg/validation/validate"
+)
+
+// SchemaSource serves the OpenAPI v3 document for one group/version, e.g. a
+// live cluster's discovery (openapi3.Root) or recorded schema files.
+type SchemaSource interface {
+	GVSpec(gv schema.GroupVersion) (*spec3.OpenAPI, error)
+}
+
+// ValidateBaselineSchema validates every object in the baseline directory
+// against the OpenAPI schema for its apiVersion/kind and returns one problem
+// per invalid object (or unknown kind), prefixed with the file path
+// relative to dir.
+func ValidateBaselineSchema(dir string, specs SchemaSource, cfg Config) ([]string, error) {
+	v := &schemaValidator{
+		specs:    specs,
+		byGVK:    map[schema.GroupVersionKind]*validate.SchemaValidator{},
+		gvErrs:   map[schema.GroupVersion]error{},
+		reported: map[string]bool{},
+	}
+	var problems []string
+
+	walkErr := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !isYAMLFile(path) {
+			return nil
+		}
+
+		f, err := openBaselineFile(path, cfg)
+		if err != nil {
+			return fmt.Errorf("opening %s: %w", path, err)
+		}
+		defer f.Close()
+
+		found, err := v.validateReader(f)
+		if err != nil {
+			return fmt.Errorf("decoding %s: %w", path, err)
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			rel = path
+		}
+		for _, p := range found {
+			problems = append(problems, rel+": "+p)
+		}
+		return nil
+	})
+
+	if walkErr != nil {
+		return nil, walkErr
+	}
+	return problems, nil
+}
+
+type schemaValidator struct {
+	specs SchemaSource
+	// byGVK caches validators; a nil entry means no schema is available.
+	byGVK map[schema.GroupVersionKind]*validate.SchemaValidator
+	// gvErrs remembers group/versions whose document could not be fetched.
+	gvErrs map[schema.GroupVersion]error
+	// reported holds schema lookup errors already returned once.
+	reported map[string]bool
+}
+
+func (v *schemaValidator) validateReader(r io.Reader) ([]string, error) {
+	dec := yaml.NewYAMLOrJSONDecoder(r, 4096)
+	var problems []string
+
+	for {
+		var raw runtime.RawExtension
+		if err := dec.Decode(&raw); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+		if len(raw.Raw) == 0 {
+			continue
+		}
+
+		var obj map[string]interface{}
+		if err := json.Unmarshal(raw.Raw, &obj); err != nil || obj == nil {
+			continue
+		}
+		apiVersion, _ := obj["apiVersion"].(string)
+		kind, _ := obj["kind"].(string)
+		if apiVersion == "" || kind == "" {
+			continue
+		}
+		gv, err := schema.ParseGroupVersion(apiVersion)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: invalid apiVersion %q", kind, apiVersion))
+			continue
+		}
+		gvk := gv.WithKind(kind)
+		label := kind + " " + objectName(obj)
+
+		sv, err := v.validatorFor(gvk)
+		if err != nil {
+			// Missing schemas are reported once, not for every object.
+			if !v.reported[err.Error()] {
+				v.reported[err.Error()] = true
+				problems = append(problems, fmt.Sprintf("%s: %v", label, err))
+			}
+			continue
+		}
+
+		// The API server drops explicit nulls (e.g. creationTimestamp: null)
+		// on decode, so they are not schema violations.
+		res := sv.Validate(dropNulls(obj))
+		for _, e := range res.Errors {
+			problems = append(problems, fmt.Sprintf("%s: %v", label, e))
+		}
+	}
+	return problems, nil
+}
+
+func (v *schemaValidator) validatorFor(gvk schema.GroupVersionKind) (*validate.SchemaValidator, error) {
+	if sv, ok := v.byGVK[gvk]; ok {
+		if sv == nil {
+			return nil, fmt.Errorf("no OpenAPI schema for %s", gvk)
+		}
+		return sv, nil
+	}
+
+	gv := gvk.GroupVersion()
+	if err, ok := v.gvErrs[gv]; ok {
+		return nil, err
+	}
+	doc, err := v.specs.GVSpec(gv)
+	if err != nil {
+		err = fmt.Errorf("fetching OpenAPI schema for %s: %w", gv, err)
+		v.gvErrs[gv] = err
+		return nil, err
+	}
+
+	// Index every kind in the document so later objects hit the cache.
+	if doc.Components != nil {
+		for name, s := range doc.Components.Schemas {
+			for _, k := range schemaGVKs(s) {
+				if k.GroupVersion() != gv {
+					continue
+				}
+				expanded := expandSchemaRefs(doc.Components.Schemas[name], doc.Components.Schemas, map[string]bool{name: true})
+				v.byGVK[k] = validate.NewSchemaValidator(expanded, nil, "", strfmt.Default)
+			}
+		}
+	}
+	if _, ok := v.byGVK[gvk]; !ok {
+		v.byGVK[gvk] = nil
+	}
+	return v.validatorFor(gvk)
+}
+
+// schemaGVKs reads the x-kubernetes-group-version-kind extension.
+func schemaGVKs(s *spec.Schema) []schema.GroupVersionKind {
+	if s == nil {
+		return nil
+	}
+	raw, ok := s.Extensions["x-kubernetes-group-version-kind"]
+	if !ok {
+		return nil
+	}
+	b, err := json.Marshal(raw)
+	if err != nil {
+		return nil
+	}
+	var gvks []schema.GroupVersionKind
+	if err := json.Unmarshal(b, &gvks); err != nil {
+		return nil
+	}
+	return gvks
+}
+
+const componentRefPrefix = "#/components/schemas/"
+
+// expandSchemaRefs returns a copy of s with every $ref inlined, since the
+// validator does not resolve references itself. Recursive types are cut
+// off (accepting anything) at the first repeat.
+func expandSchemaRefs(s *spec.Schema, components map[string]*spec.Schema, seen map[string]bool) *spec.Schema {
+	if s == nil {
+		return nil
+	}
+	if ref := s.Ref.String(); ref != "" {
+		name := strings.TrimPrefix(ref, componentRefPrefix)
+		target, ok := components[name]
+		if !ok || seen[name] {
+			return &spec.Schema{}
+		}
+		next := make(map[string]bool, len(seen)+1)
+		for k := range seen {
+			next[k] = true
+		}
+		next[name] = true
+		return expandSchemaRefs(target, components, next)
+	}
+
+	out := *s
+	// int-or-string is expressed with oneOf; the validator would otherwise
+	// treat the format as a type and reject both alternatives.
+	if out.Format == "int-or-string" {
+		out.Type = nil
+		out.Format = ""
+	}
+	if len(s.Properties) > 0 {
+		out.Properties = make(map[string]spec.Schema, len(s.Properties))
+		for k, p := range s.Properties {
+			out.Properties[k] = *expandSchemaRefs(&p, components, seen)
+		}
+	}
+	if s.Items != nil {
+		items := *s.Items
+		items.Schema = expandSchemaRefs(s.Items.Schema, components, seen)
+		if len(s.Items.Schemas) > 0 {
+			items.Schemas = expandSchemaList(s.Items.Schemas, components, seen)
+		}
+		out.Items = &items
+	}
+	if s.AdditionalProperties != nil && s.AdditionalProperties.Schema != nil {
+		ap := *s.AdditionalProperties
+		ap.Schema = expandSchemaRefs(ap.Schema, components, seen)
+		out.AdditionalProperties = &ap
+	}
+	out.AllOf = expandSchemaList(s.AllOf, components, seen)
+	out.OneOf = expandSchemaList(s.OneOf, components, seen)
+	out.AnyOf = expandSchemaList(s.AnyOf, components, seen)
+
+	// Kubernetes wraps typed fields as allOf: [$ref] to attach a description;
+	// unwrap them so errors name the field instead of an allOf mismatch.
+	if len(out.AllOf) == 1 && len(out.Type) == 0 && len(out.Properties) == 0 && out.Items == nil {
+		inner := out.AllOf[0]
+		if inner.Default == nil {
+			inner.Default = out.Default
+		}
+		return &inner
+	}
+	return &out
+}
+
+func expandSchemaList(list []spec.Schema, components map[string]*spec.Schema, seen map[string]bool) []spec.Schema {
+	if len(list) == 0 {
+		return list
+	}
+	out := make([]spec.Schema, len(list))
+	for i := range list {
+		out[i] = *expandSchemaRefs(&list[i], components, seen)
+	}
+	return out
+}
+
+func dropNulls(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(t))
+		for k, val := range t {
+			if val == nil {
+				continue
+			}
+			out[k] = dropNulls(val)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(t))
+		for i, val := range t {
+			out[i] = dropNulls(val)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+func objectName(obj map[string]interface{}) string {
+	meta, _ := obj["metadata"].(map[string]interface{})
+	name, _ := meta["name"].(string)
+	if ns, _ := meta["namespace"].(string); ns != "" {
+		return ns + "/" + name
+	}
+	return name
+}
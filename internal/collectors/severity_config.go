@@ -0,0 +1,39 @@
+package collectors
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/Hru-s/driftwatch/internal/model"
+
+	yamlutil "k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// LoadSeverityConfig reads severity tuning from a YAML or JSON file, e.g.
+//
+//	subjectKindBoost:
+//	  User: 1
+//	  Group: 1
+//	  ServiceAccount: 0
+//
+// Boosts are severity levels; negative values lower the severity.
+func LoadSeverityConfig(path string) (model.SeverityConfig, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return model.SeverityConfig{}, fmt.Errorf("open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	var cfg model.SeverityConfig
+	if err := yamlutil.NewYAMLOrJSONDecoder(f, 4096).Decode(&cfg); err != nil {
+		return model.SeverityConfig{}, fmt.Errorf("decode severity config %s: %w", path, err)
+	}
+	for kind := range cfg.SubjectKindBoost {
+		switch kind {
+		case "User", "Group", "ServiceAccount":
+		default:
+			return model.SeverityConfig{}, fmt.Errorf("severity config %s: unknown subject kind %q in subjectKindBoost (supported: User, Group, ServiceAccount)", path, kind)
+		}
+	}
+	return cfg, nil
+}
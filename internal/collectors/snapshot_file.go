@@ -0,0 +1,254 @@
+package collectors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Hru-s/driftwatch/internal/model"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	sigsyaml "sigs.k8s.io/yaml"
+)
+
+// SnapshotKind is the kind of a snapshot file written by WriteSnapshotFile;
+// a baseline whose first document has this kind is read as a snapshot
+// instead of as manifests.
+const SnapshotKind = "DriftwatchSnapshot"
+
+const snapshotAPIVersion = "driftwatch/v1"
+
+// Snapshot is the collected RBAC, NetworkPolicy and PSA state of a cluster
+// as stored in a snapshot file.
+type Snapshot struct {
+	CapturedAt time.Time
+	RBAC       *model.RBACSnapshot
+	NetPol     *model.NetPolSnapshot
+	PSA        []model.NamespacePSA
+}
+
+// snapshotFile is the serialized form of a Snapshot. The snapshot maps are
+// stored as lists sorted by key, so two snapshots of the same state are
+// identical files.
+type snapshotFile struct {
+	APIVersion      string               `json:"apiVersion"`
+	Kind            string               `json:"kind"`
+	CapturedAt      time.Time            `json:"capturedAt"`
+	RBAC            snapshotRBAC         `json:"rbac"`
+	NetworkPolicies []snapshotNetPol     `json:"networkPolicies"`
+	Namespaces      []model.NamespacePSA `json:"namespaces"`
+}
+
+type snapshotRBAC struct {
+	Subjects     []snapshotSubject              `json:"subjects"`
+	ClusterRoles map[string][]rbacv1.PolicyRule `json:"clusterRoles,omitempty"`
+	Bindings     []snapshotBinding              `json:"bindings,omitempty"`
+	Dangling     []model.DanglingBinding        `json:"dangling,omitempty"`
+}
+
+type snapshotSubject struct {
+	Subject     model.SubjectKey   `json:"subject"`
+	Permissions []model.Permission `json:"permissions"`
+}
+
+type snapshotBinding struct {
+	Role     model.RoleRef      `json:"role"`
+	Subjects []model.SubjectKey `json:"subjects"`
+}
+
+// snapshotNetPol keeps the decoded spec, which the digest does not
+// serialize, so changed policies are still described rule by rule.
+type snapshotNetPol struct {
+	model.NetPolDigest
+	Spec *networkingv1.NetworkPolicySpec `json:"spec,omitempty"`
+}
+
+// WriteSnapshotFile writes s to path, as JSON when path ends in .json and
+// as YAML otherwise. Warnings and baseline locations are not stored.
+func WriteSnapshotFile(path string, s Snapshot) error {
+	f := snapshotFile{
+		APIVersion: snapshotAPIVersion,
+		Kind:       SnapshotKind,
+		CapturedAt: s.CapturedAt.UTC(),
+		Namespaces: make([]model.NamespacePSA, 0, len(s.PSA)),
+	}
+
+	if s.RBAC != nil {
+		for subj, perms := range s.RBAC.Subjects {
+			entry := snapshotSubject{Subject: subj, Permissions: make([]model.Permission, 0, len(perms))}
+			for p := range perms {
+				entry.Permissions = append(entry.Permissions, p)
+			}
+			sort.Slice(entry.Permissions, func(i, j int) bool {
+				return entry.Permissions[i].String() < entry.Permissions[j].String()
+			})
+			f.RBAC.Subjects = append(f.RBAC.Subjects, entry)
+		}
+		sort.Slice(f.RBAC.Subjects, func(i, j int) bool {
+			return f.RBAC.Subjects[i].Subject.String() < f.RBAC.Subjects[j].Subject.String()
+		})
+		f.RBAC.ClusterRoles = s.RBAC.ClusterRoles
+		for role, subjects := range s.RBAC.Bindings {
+			entry := snapshotBinding{Role: role, Subjects: make([]model.SubjectKey, 0, len(subjects))}
+			for subj := range subjects {
+				entry.Subjects = append(entry.Subjects, subj)
+			}
+			sort.Slice(entry.Subjects, func(i, j int) bool {
+				return entry.Subjects[i].String() < entry.Subjects[j].String()
+			})
+			f.RBAC.Bindings = append(f.RBAC.Bindings, entry)
+		}
+		sort.Slice(f.RBAC.Bindings, func(i, j int) bool {
+			return f.RBAC.Bindings[i].Role.String() < f.RBAC.Bindings[j].Role.String()
+		})
+		for _, d := range s.RBAC.Dangling {
+			d.DefinedIn = nil
+			f.RBAC.Dangling = append(f.RBAC.Dangling, d)
+		}
+	}
+
+	if s.NetPol != nil {
+		keys := make([]string, 0, len(s.NetPol.Items))
+		for key := range s.NetPol.Items {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			d := s.NetPol.Items[key]
+			d.DefinedIn = nil
+			d.Unstable = false
+			f.NetworkPolicies = append(f.NetworkPolicies, snapshotNetPol{NetPolDigest: d, Spec: d.Spec})
+		}
+	}
+
+	for _, ns := range s.PSA {
+		ns.DefinedIn = nil
+		f.Namespaces = append(f.Namespaces, ns)
+	}
+	sort.Slice(f.Namespaces, func(i, j int) bool { return f.Namespaces[i].Namespace < f.Namespaces[j].Namespace })
+
+	var (
+		out []byte
+		err error
+	)
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		out, err = json.MarshalIndent(f, "", "  ")
+		out = append(out, '\n')
+	} else {
+		out, err = sigsyaml.Marshal(f)
+	}
+	if err != nil {
+		return fmt.Errorf("encoding snapshot: %w", err)
+	}
+	return os.WriteFile(path, out, 0o644)
+}
+
+// LoadSnapshotFile reads baseline as a snapshot file. baseline is a file,
+// or a directory holding a single YAML file (a baseline read from stdin or
+// a URL). ok is false, with a nil error, when baseline is not a snapshot and
+// should be read as manifests.
+func LoadSnapshotFile(baseline string, cfg Config) (snap Snapshot, ok bool, err error) {
+	path, err := singleBaselineFile(baseline)
+	if err != nil || path == "" {
+		return Snapshot{}, false, err
+	}
+
+	r, err := openBaselineFile(path, cfg)
+	if err != nil {
+		return Snapshot{}, false, fmt.Errorf("opening %s: %w", path, err)
+	}
+	defer r.Close()
+
+	var raw runtime.RawExtension
+	if err := yaml.NewYAMLOrJSONDecoder(r, 4096).Decode(&raw); err != nil && !errors.Is(err, io.EOF) {
+		if isYAMLFile(path) {
+			// not ours; the manifest collectors report the error
+			return Snapshot{}, false, nil
+		}
+		return Snapshot{}, false, fmt.Errorf("decoding %s: %w", path, err)
+	}
+	var tm metav1.TypeMeta
+	if len(raw.Raw) > 0 {
+		if err := json.Unmarshal(raw.Raw, &tm); err != nil {
+			return Snapshot{}, false, fmt.Errorf("decoding %s: %w", path, err)
+		}
+	}
+	if tm.Kind != SnapshotKind {
+		if !isYAMLFile(path) {
+			return Snapshot{}, false, fmt.Errorf("%s is not a %s; manifest baselines must be .yaml or .yml files", path, SnapshotKind)
+		}
+		return Snapshot{}, false, nil
+	}
+	if tm.APIVersion != snapshotAPIVersion {
+		return Snapshot{}, false, fmt.Errorf("%s: unsupported snapshot apiVersion %q (want %s)", path, tm.APIVersion, snapshotAPIVersion)
+	}
+
+	var f snapshotFile
+	if err := json.Unmarshal(raw.Raw, &f); err != nil {
+		return Snapshot{}, false, fmt.Errorf("decoding snapshot %s: %w", path, err)
+	}
+
+	snap = Snapshot{
+		CapturedAt: f.CapturedAt,
+		RBAC: &model.RBACSnapshot{
+			Subjects:     make(map[model.SubjectKey]map[model.Permission]struct{}, len(f.RBAC.Subjects)),
+			ClusterRoles: f.RBAC.ClusterRoles,
+			Dangling:     f.RBAC.Dangling,
+		},
+		NetPol: &model.NetPolSnapshot{Items: make(map[string]model.NetPolDigest, len(f.NetworkPolicies))},
+		PSA:    f.Namespaces,
+	}
+	for _, s := range f.RBAC.Subjects {
+		snap.RBAC.AddPermissions(s.Subject, s.Permissions)
+	}
+	for _, b := range f.RBAC.Bindings {
+		for _, subj := range b.Subjects {
+			snap.RBAC.AddBinding(b.Role, subj)
+		}
+	}
+	for _, np := range f.NetworkPolicies {
+		d := np.NetPolDigest
+		if np.Spec != nil {
+			// snapshots written before selectors were normalized carry
+			// hashes of the raw spec
+			spec := model.NormalizeNetPolSpec(*np.Spec)
+			if d.SpecHash, err = model.NetPolSpecHash(spec); err != nil {
+				return Snapshot{}, false, fmt.Errorf("decoding snapshot %s: %w", path, err)
+			}
+			np.Spec = &spec
+		}
+		d.Spec = np.Spec
+		snap.NetPol.Items[d.Namespace+"/"+d.Name] = d
+	}
+	return snap, true, nil
+}
+
+// singleBaselineFile returns baseline if it is a file, the only file in it
+// if it is a directory holding exactly one YAML file, and "" otherwise.
+func singleBaselineFile(baseline string) (string, error) {
+	info, err := os.Stat(baseline)
+	if err != nil {
+		return "", err
+	}
+	if !info.IsDir() {
+		return baseline, nil
+	}
+	entries, err := os.ReadDir(baseline)
+	if err != nil {
+		return "", err
+	}
+	if len(entries) != 1 || !entries[0].Type().IsRegular() || !isYAMLFile(entries[0].Name()) {
+		return "", nil
+	}
+	return filepath.Join(baseline, entries[0].Name()), nil
+}
@@ -0,0 +1,116 @@
+package collectors
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/Hru-s/driftwatch/internal/model"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/kubernetes"
+)
+
+// CollectStorageClassFromCluster lists StorageClasses in the cluster.
+func CollectStorageClassFromCluster(ctx context.Context, client kubernetes.Interface) ([]model.StorageClassDigest, error) {
+	scList, err := client.StorageV1().StorageClasses().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("listing StorageClasses: %w", err)
+	}
+
+	out := make([]model.StorageClassDigest, 0, len(scList.Items))
+	for _, sc := range scList.Items {
+		out = append(out, storageClassToDigest(&sc))
+	}
+	return out, nil
+}
+
+// CollectStorageClassFromBaselineDir scans a baseline YAML directory for
+// StorageClass manifests.
+func CollectStorageClassFromBaselineDir(dir string, cfg Config) ([]model.StorageClassDigest, error) {
+	var out []model.StorageClassDigest
+
+	walkErr := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !isYAMLFile(path) {
+			return nil
+		}
+
+		f, err := openBaselineFile(path, cfg)
+		if err != nil {
+			return fmt.Errorf("opening %s: %w", path, err)
+		}
+		defer f.Close()
+
+		if err := decodeStorageClassesFromReader(f, &out); err != nil {
+			return fmt.Errorf("decoding StorageClasses from %s: %w", path, err)
+		}
+		return nil
+	})
+
+	if walkErr != nil {
+		return nil, walkErr
+	}
+	return out, nil
+}
+
+func decodeStorageClassesFromReader(r io.Reader, out *[]model.StorageClassDigest) error {
+	dec := yaml.NewYAMLOrJSONDecoder(r, 4096)
+
+	for {
+		var raw runtime.RawExtension
+		if err := dec.Decode(&raw); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return err
+		}
+		if len(raw.Raw) == 0 {
+			continue
+		}
+
+		var tm metav1.TypeMeta
+		if err := json.Unmarshal(raw.Raw, &tm); err != nil {
+			continue
+		}
+		if tm.Kind != "StorageClass" {
+			continue
+		}
+
+		var sc storagev1.StorageClass
+		if err := json.Unmarshal(raw.Raw, &sc); err != nil {
+			continue
+		}
+		*out = append(*out, storageClassToDigest(&sc))
+	}
+
+	return nil
+}
+
+func storageClassToDigest(sc *storagev1.StorageClass) model.StorageClassDigest {
+	// The API server defaults an unset reclaimPolicy to Delete.
+	reclaim := string(corev1.PersistentVolumeReclaimDelete)
+	if sc.ReclaimPolicy != nil {
+		reclaim = string(*sc.ReclaimPolicy)
+	}
+
+	isDefault := sc.Annotations[model.DefaultStorageClassAnnotation] == "true" ||
+		sc.Annotations[model.DefaultStorageClassAnnotationBeta] == "true"
+
+	return model.StorageClassDigest{
+		Name:          sc.Name,
+		Provisioner:   sc.Provisioner,
+		ReclaimPolicy: reclaim,
+		IsDefault:     isDefault,
+	}
+}
@@ -0,0 +1,146 @@
+package collectors
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/Hru-s/driftwatch/internal/model"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/kubernetes"
+)
+
+// CollectWebhooksFromCluster lists the webhooks of every
+// ValidatingWebhookConfiguration and MutatingWebhookConfiguration.
+func CollectWebhooksFromCluster(ctx context.Context, client kubernetes.Interface) ([]model.WebhookDigest, error) {
+	api := client.AdmissionregistrationV1()
+
+	validating, err := listAll(ctx, api.ValidatingWebhookConfigurations().List,
+		func(l *admissionregistrationv1.ValidatingWebhookConfigurationList) []admissionregistrationv1.ValidatingWebhookConfiguration {
+			return l.Items
+		})
+	if err != nil {
+		return nil, fmt.Errorf("listing ValidatingWebhookConfigurations: %w", err)
+	}
+	mutating, err := listAll(ctx, api.MutatingWebhookConfigurations().List,
+		func(l *admissionregistrationv1.MutatingWebhookConfigurationList) []admissionregistrationv1.MutatingWebhookConfiguration {
+			return l.Items
+		})
+	if err != nil {
+		return nil, fmt.Errorf("listing MutatingWebhookConfigurations: %w", err)
+	}
+
+	var out []model.WebhookDigest
+	for _, c := range validating {
+		out = append(out, validatingWebhookDigests(&c)...)
+	}
+	for _, c := range mutating {
+		out = append(out, mutatingWebhookDigests(&c)...)
+	}
+	return out, nil
+}
+
+// CollectWebhooksFromBaselineDir scans a baseline YAML directory for
+// ValidatingWebhookConfiguration and MutatingWebhookConfiguration manifests.
+func CollectWebhooksFromBaselineDir(dir string, cfg Config) ([]model.WebhookDigest, error) {
+	var out []model.WebhookDigest
+
+	walkErr := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !isYAMLFile(path) {
+			return nil
+		}
+
+		f, err := openBaselineFile(path, cfg)
+		if err != nil {
+			return fmt.Errorf("opening %s: %w", path, err)
+		}
+		defer f.Close()
+
+		if err := decodeWebhooksFromReader(f, baselineFileName(dir, path), &out); err != nil {
+			return fmt.Errorf("decoding webhook configurations from %s: %w", path, err)
+		}
+		return nil
+	})
+
+	if walkErr != nil {
+		return nil, walkErr
+	}
+	return out, nil
+}
+
+func decodeWebhooksFromReader(r io.Reader, file string, out *[]model.WebhookDigest) error {
+	dec := yaml.NewYAMLOrJSONDecoder(r, 4096)
+
+	for doc := 1; ; {
+		var raw runtime.RawExtension
+		if err := dec.Decode(&raw); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return err
+		}
+		if len(raw.Raw) == 0 {
+			continue
+		}
+		ref := model.SourceRef{File: file, Document: doc}
+		doc++
+
+		var tm metav1.TypeMeta
+		if err := json.Unmarshal(raw.Raw, &tm); err != nil {
+			continue
+		}
+
+		var digests []model.WebhookDigest
+		switch tm.Kind {
+		case model.ValidatingWebhookConfigurationKind:
+			var c admissionregistrationv1.ValidatingWebhookConfiguration
+			if err := json.Unmarshal(raw.Raw, &c); err != nil {
+				continue
+			}
+			digests = validatingWebhookDigests(&c)
+		case model.MutatingWebhookConfigurationKind:
+			var c admissionregistrationv1.MutatingWebhookConfiguration
+			if err := json.Unmarshal(raw.Raw, &c); err != nil {
+				continue
+			}
+			digests = mutatingWebhookDigests(&c)
+		default:
+			continue
+		}
+		for _, d := range digests {
+			d.DefinedIn = &ref
+			*out = append(*out, d)
+		}
+	}
+
+	return nil
+}
+
+func validatingWebhookDigests(c *admissionregistrationv1.ValidatingWebhookConfiguration) []model.WebhookDigest {
+	out := make([]model.WebhookDigest, 0, len(c.Webhooks))
+	for _, w := range c.Webhooks {
+		out = append(out, model.NewWebhookDigest(model.ValidatingWebhookConfigurationKind, c.Name, w.Name,
+			w.Rules, w.FailurePolicy, w.NamespaceSelector))
+	}
+	return out
+}
+
+func mutatingWebhookDigests(c *admissionregistrationv1.MutatingWebhookConfiguration) []model.WebhookDigest {
+	out := make([]model.WebhookDigest, 0, len(c.Webhooks))
+	for _, w := range c.Webhooks {
+		out = append(out, model.NewWebhookDigest(model.MutatingWebhookConfigurationKind, c.Name, w.Name,
+			w.Rules, w.FailurePolicy, w.NamespaceSelector))
+	}
+	return out
+}
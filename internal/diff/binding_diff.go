@@ -0,0 +1,51 @@
+package diff
+
+import (
+	"sort"
+
+	"github.com/Hru-s/driftwatch/internal/model"
+)
+
+// DiffBindings compares, per role reference, the set of bound subjects
+// between baseline and live. Unlike DiffRBAC it answers "who is bound to
+// this role" independently of what the role grants.
+func DiffBindings(baseline, live *model.RBACSnapshot) []model.BindingChange {
+	roles := map[model.RoleRef]struct{}{}
+	for r := range baseline.Bindings {
+		roles[r] = struct{}{}
+	}
+	for r := range live.Bindings {
+		roles[r] = struct{}{}
+	}
+
+	var out []model.BindingChange
+	for role := range roles {
+		base := baseline.Bindings[role]
+		liveSubjects := live.Bindings[role]
+
+		ch := model.BindingChange{Role: role}
+		for s := range liveSubjects {
+			if _, ok := base[s]; !ok {
+				ch.Added = append(ch.Added, s)
+			}
+		}
+		for s := range base {
+			if _, ok := liveSubjects[s]; !ok {
+				ch.Removed = append(ch.Removed, s)
+			}
+		}
+		if len(ch.Added) == 0 && len(ch.Removed) == 0 {
+			continue
+		}
+		sortSubjects(ch.Added)
+		sortSubjects(ch.Removed)
+		out = append(out, ch)
+	}
+
+	sort.Slice(out, func(i, j int) bool { return out[i].Role.String() < out[j].Role.String() })
+	return out
+}
+
+func sortSubjects(subjects []model.SubjectKey) {
+	sort.Slice(subjects, func(i, j int) bool { return subjects[i].String() < subjects[j].String() })
+}
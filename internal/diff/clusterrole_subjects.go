@@ -0,0 +1,80 @@
+package diff
+
+import (
+	"sort"
+
+	"github.com/Hru-s/driftwatch/internal/model"
+)
+
+// ClusterRoleSubjects aggregates the bindings of every ClusterRole across
+// RoleBindings and ClusterRoleBindings, so each role lists who holds it in
+// baseline and live and whether that set drifted. Roles bound on neither
+// side are omitted.
+func ClusterRoleSubjects(baseline, live *model.RBACSnapshot) []model.ClusterRoleSubjects {
+	baseGrants := clusterRoleGrants(baseline)
+	liveGrants := clusterRoleGrants(live)
+
+	roles := map[string]struct{}{}
+	for r := range baseGrants {
+		roles[r] = struct{}{}
+	}
+	for r := range liveGrants {
+		roles[r] = struct{}{}
+	}
+
+	out := make([]model.ClusterRoleSubjects, 0, len(roles))
+	for role := range roles {
+		base, cur := baseGrants[role], liveGrants[role]
+		v := model.ClusterRoleSubjects{
+			Role:     role,
+			Baseline: sortedGrants(base),
+			Live:     sortedGrants(cur),
+		}
+		for g := range cur {
+			if _, ok := base[g]; !ok {
+				v.Added = append(v.Added, g)
+			}
+		}
+		for g := range base {
+			if _, ok := cur[g]; !ok {
+				v.Removed = append(v.Removed, g)
+			}
+		}
+		sortGrants(v.Added)
+		sortGrants(v.Removed)
+		v.Drifted = len(v.Added) > 0 || len(v.Removed) > 0
+		out = append(out, v)
+	}
+
+	sort.Slice(out, func(i, j int) bool { return out[i].Role < out[j].Role })
+	return out
+}
+
+func clusterRoleGrants(snap *model.RBACSnapshot) map[string]map[model.ClusterRoleGrant]struct{} {
+	out := map[string]map[model.ClusterRoleGrant]struct{}{}
+	for ref, subjects := range snap.Bindings {
+		if ref.Kind != "ClusterRole" {
+			continue
+		}
+		if out[ref.Name] == nil {
+			out[ref.Name] = map[model.ClusterRoleGrant]struct{}{}
+		}
+		for s := range subjects {
+			out[ref.Name][model.ClusterRoleGrant{Subject: s, Namespace: ref.Namespace}] = struct{}{}
+		}
+	}
+	return out
+}
+
+func sortedGrants(set map[model.ClusterRoleGrant]struct{}) []model.ClusterRoleGrant {
+	out := make([]model.ClusterRoleGrant, 0, len(set))
+	for g := range set {
+		out = append(out, g)
+	}
+	sortGrants(out)
+	return out
+}
+
+func sortGrants(grants []model.ClusterRoleGrant) {
+	sort.Slice(grants, func(i, j int) bool { return grants[i].String() < grants[j].String() })
+}
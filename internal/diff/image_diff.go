@@ -0,0 +1,45 @@
+package diff
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/Hru-s/driftwatch/internal/model"
+)
+
+// DiffImageRegistries returns every image in usage whose repository is not
+// covered by an allowed entry. An entry matches a registry host exactly
+// ("ghcr.io") or a repository path prefix ("ghcr.io/acme").
+func DiffImageRegistries(usage []model.ImageUsageDigest, allowed []string) []model.ImageViolation {
+	var out []model.ImageViolation
+	for _, u := range usage {
+		for _, image := range u.Images {
+			repo := model.ImageRepository(image)
+			if imageAllowed(repo, allowed) {
+				continue
+			}
+			out = append(out, model.ImageViolation{
+				Namespace: u.Namespace,
+				Image:     image,
+				Registry:  model.ImageRegistry(image),
+			})
+		}
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Namespace != out[j].Namespace {
+			return out[i].Namespace < out[j].Namespace
+		}
+		return out[i].Image < out[j].Image
+	})
+	return out
+}
+
+func imageAllowed(repo string, allowed []string) bool {
+	for _, a := range allowed {
+		if repo == a || strings.HasPrefix(repo, a+"/") {
+			return true
+		}
+	}
+	return false
+}
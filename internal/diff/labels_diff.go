@@ -0,0 +1,84 @@
+package diff
+
+import (
+	"sort"
+
+	"github.com/Hru-s/driftwatch/internal/model"
+)
+
+// DiffNetPolLabels compares the given label keys on NetworkPolicies present
+// in both snapshots. Only the selected keys are compared so that
+// controller-managed labels don't create noise.
+func DiffNetPolLabels(baseline, live *model.NetPolSnapshot, keys []string) []model.LabelChange {
+	var out []model.LabelChange
+	if len(keys) == 0 {
+		return out
+	}
+
+	for k, base := range baseline.Items {
+		liveItem, ok := live.Items[k]
+		if !ok {
+			continue
+		}
+		out = append(out, compareLabels("NetworkPolicy", base.Namespace, base.Name, base.Labels, liveItem.Labels, keys)...)
+	}
+
+	sortLabelChanges(out)
+	return out
+}
+
+// DiffNamespaceLabels compares the given label keys on namespaces present in
+// both baseline and live.
+func DiffNamespaceLabels(baseline, live []model.NamespacePSA, keys []string) []model.LabelChange {
+	var out []model.LabelChange
+	if len(keys) == 0 {
+		return out
+	}
+
+	lMap := make(map[string]model.NamespacePSA, len(live))
+	for _, l := range live {
+		lMap[l.Namespace] = l
+	}
+
+	for _, b := range baseline {
+		l, ok := lMap[b.Namespace]
+		if !ok {
+			continue
+		}
+		out = append(out, compareLabels("Namespace", b.Namespace, "", b.Labels, l.Labels, keys)...)
+	}
+
+	sortLabelChanges(out)
+	return out
+}
+
+func compareLabels(kind, namespace, name string, base, live map[string]string, keys []string) []model.LabelChange {
+	var out []model.LabelChange
+	for _, key := range keys {
+		if base[key] == live[key] {
+			continue
+		}
+		out = append(out, model.LabelChange{
+			Kind:      kind,
+			Namespace: namespace,
+			Name:      name,
+			Key:       key,
+			Baseline:  base[key],
+			Live:      live[key],
+		})
+	}
+	return out
+}
+
+func sortLabelChanges(changes []model.LabelChange) {
+	sort.Slice(changes, func(i, j int) bool {
+		a, b := changes[i], changes[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.Key < b.Key
+	})
+}
@@ -0,0 +1,149 @@
+package diff
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Hru-s/driftwatch/internal/model"
+)
+
+// lastAppliedFields are the top-level fields compared per kind: the ones
+// that carry policy, not status or server-managed metadata.
+var lastAppliedFields = map[string][]string{
+	"NetworkPolicy":      {"spec"},
+	"Role":               {"rules"},
+	"ClusterRole":        {"rules", "aggregationRule"},
+	"RoleBinding":        {"roleRef", "subjects"},
+	"ClusterRoleBinding": {"roleRef", "subjects"},
+	"Namespace":          {"metadata.labels"},
+}
+
+// lastAppliedDefaulted are paths (list indexes written as []) the API
+// server fills in when the applied configuration leaves them out; they
+// only count as drift when the applied configuration sets them.
+var lastAppliedDefaulted = map[string]bool{
+	"spec.policyTypes":                            true,
+	"spec.ingress[].ports[].protocol":             true,
+	"spec.egress[].ports[].protocol":              true,
+	"metadata.labels.kubernetes.io/metadata.name": true,
+}
+
+var listIndex = regexp.MustCompile(`\[\d+\]`)
+
+// DiffLastApplied compares each object's live state with the configuration
+// of its last `kubectl apply` and returns the objects that diverge, with the
+// differing JSON paths. Lists are compared by position. The rules of an
+// aggregated ClusterRole are filled in by the aggregation controller and
+// are not compared.
+func DiffLastApplied(objs []model.LastAppliedObject, source string) []model.LastAppliedDrift {
+	var out []model.LastAppliedDrift
+	for _, obj := range objs {
+		var fields []string
+		for _, field := range lastAppliedFields[obj.Kind] {
+			if obj.Kind == "ClusterRole" && field == "rules" && jsonPath(obj.Applied, "aggregationRule") != nil {
+				continue
+			}
+			diffJSON(field, jsonPath(obj.Applied, field), jsonPath(obj.Live, field), &fields)
+		}
+		if len(fields) == 0 {
+			continue
+		}
+		out = append(out, model.LastAppliedDrift{
+			Source:    source,
+			Kind:      obj.Kind,
+			Namespace: obj.Namespace,
+			Name:      obj.Name,
+			Fields:    fields,
+		})
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+	return out
+}
+
+// jsonPath looks up a dotted path of object keys in obj.
+func jsonPath(obj map[string]any, path string) any {
+	var cur any = obj
+	for _, key := range strings.Split(path, ".") {
+		m, ok := cur.(map[string]any)
+		if !ok {
+			return nil
+		}
+		cur = m[key]
+	}
+	return cur
+}
+
+// diffJSON appends to out the paths under path where live differs from
+// applied. Absent, null and empty values are equivalent, since typed
+// objects and hand-written manifests disagree on which to use.
+func diffJSON(path string, applied, live any, out *[]string) {
+	if isEmptyJSON(applied) && isEmptyJSON(live) {
+		return
+	}
+	if applied == nil && lastAppliedDefaulted[listIndex.ReplaceAllString(path, "[]")] {
+		return
+	}
+
+	switch a := applied.(type) {
+	case map[string]any:
+		l, ok := live.(map[string]any)
+		if !ok {
+			break
+		}
+		keys := make(map[string]struct{}, len(a)+len(l))
+		for k := range a {
+			keys[k] = struct{}{}
+		}
+		for k := range l {
+			keys[k] = struct{}{}
+		}
+		sorted := make([]string, 0, len(keys))
+		for k := range keys {
+			sorted = append(sorted, k)
+		}
+		sort.Strings(sorted)
+		for _, k := range sorted {
+			diffJSON(path+"."+k, a[k], l[k], out)
+		}
+		return
+	case []any:
+		l, ok := live.([]any)
+		if !ok || len(a) != len(l) {
+			break
+		}
+		for i := range a {
+			diffJSON(fmt.Sprintf("%s[%d]", path, i), a[i], l[i], out)
+		}
+		return
+	}
+
+	if !reflect.DeepEqual(applied, live) {
+		*out = append(*out, path)
+	}
+}
+
+func isEmptyJSON(v any) bool {
+	switch t := v.(type) {
+	case nil:
+		return true
+	case map[string]any:
+		return len(t) == 0
+	case []any:
+		return len(t) == 0
+	default:
+		return false
+	}
+}
@@ -0,0 +1,116 @@
+package diff
+
+import (
+	"sort"
+
+	"github.com/Hru-s/driftwatch/internal/model"
+)
+
+// LimitRangeDrift is the LimitRange comparison result.
+type LimitRangeDrift struct {
+	Missing []model.LimitRangeDigest `json:"missing"`
+	Extra   []model.LimitRangeDigest `json:"extra"`
+	Changed []model.LimitRangeChange `json:"changed"`
+}
+
+// DiffLimitRanges compares LimitRanges by namespace/name. A LimitRange on
+// both sides is changed when any default, defaultRequest, max or min of a
+// limit type is added, removed or set to a different quantity.
+func DiffLimitRanges(baseline, live []model.LimitRangeDigest) LimitRangeDrift {
+	bMap := make(map[string]model.LimitRangeDigest, len(baseline))
+	lMap := make(map[string]model.LimitRangeDigest, len(live))
+	for _, b := range baseline {
+		bMap[b.String()] = b
+	}
+	for _, l := range live {
+		lMap[l.String()] = l
+	}
+
+	var result LimitRangeDrift
+	for key, b := range bMap {
+		l, ok := lMap[key]
+		if !ok {
+			result.Missing = append(result.Missing, b)
+			continue
+		}
+		if fields := limitRangeFieldChanges(b, l); len(fields) > 0 {
+			result.Changed = append(result.Changed, model.LimitRangeChange{
+				Namespace: b.Namespace,
+				Name:      b.Name,
+				Fields:    fields,
+				DefinedIn: b.DefinedIn,
+			})
+		}
+	}
+	for key, l := range lMap {
+		if _, ok := bMap[key]; !ok {
+			result.Extra = append(result.Extra, l)
+		}
+	}
+
+	sortRanges := func(s []model.LimitRangeDigest) {
+		sort.Slice(s, func(i, j int) bool { return s[i].String() < s[j].String() })
+	}
+	sortRanges(result.Missing)
+	sortRanges(result.Extra)
+	sort.Slice(result.Changed, func(i, j int) bool {
+		a, b := result.Changed[i], result.Changed[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+
+	return result
+}
+
+// limitRangeField identifies one constraint: a limit type plus a
+// "default.cpu"-style field path.
+type limitRangeField struct {
+	typ, field string
+}
+
+// limitRangeFields flattens a LimitRange into its constraints. Items of the
+// same type are merged, later ones winning, as the admission plugin applies
+// them all.
+func limitRangeFields(d model.LimitRangeDigest) map[limitRangeField]string {
+	out := map[limitRangeField]string{}
+	for _, item := range d.Limits {
+		for _, f := range []struct {
+			name string
+			list map[string]string
+		}{
+			{"default", item.Default},
+			{"defaultRequest", item.DefaultRequest},
+			{"max", item.Max},
+			{"min", item.Min},
+		} {
+			for res, qty := range f.list {
+				out[limitRangeField{item.Type, f.name + "." + res}] = qty
+			}
+		}
+	}
+	return out
+}
+
+func limitRangeFieldChanges(base, live model.LimitRangeDigest) []model.LimitRangeFieldChange {
+	b, l := limitRangeFields(base), limitRangeFields(live)
+	var out []model.LimitRangeFieldChange
+	for k, bv := range b {
+		if lv := l[k]; lv != bv {
+			out = append(out, model.LimitRangeFieldChange{Type: k.typ, Field: k.field, Baseline: bv, Live: lv})
+		}
+	}
+	for k, lv := range l {
+		if _, ok := b[k]; !ok {
+			out = append(out, model.LimitRangeFieldChange{Type: k.typ, Field: k.field, Live: lv})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Type != out[j].Type {
+			return out[i].Type < out[j].Type
+		}
+		return out[i].Field < out[j].Field
+	})
+	return out
+}
@@ -0,0 +1,78 @@
+package diff
+
+import (
+	"sort"
+
+	"github.com/Hru-s/driftwatch/internal/model"
+)
+
+// defaultDeny lists, per namespace and direction, the policies that deny
+// all traffic of that direction to every pod of the namespace.
+type defaultDeny map[string]map[string][]model.NetPolDigest
+
+// defaultDenyPolicies indexes the default-deny policies of snap: an empty
+// podSelector (every pod) and a direction the policy governs with no
+// rules. Policies without a decoded spec (-fast) are skipped.
+func defaultDenyPolicies(snap *model.NetPolSnapshot) defaultDeny {
+	out := defaultDeny{}
+	if snap == nil {
+		return out
+	}
+	for _, d := range snap.Items {
+		if d.Spec == nil {
+			continue
+		}
+		sel := d.Spec.PodSelector
+		if len(sel.MatchLabels) > 0 || len(sel.MatchExpressions) > 0 {
+			continue
+		}
+		ingress, egress := ClassifyNetPolOpenness(d.Spec)
+		add := func(direction string, openness model.NetPolOpenness, rules int) {
+			if openness != model.NetPolRestricted || rules > 0 {
+				return
+			}
+			if out[d.Namespace] == nil {
+				out[d.Namespace] = map[string][]model.NetPolDigest{}
+			}
+			out[d.Namespace][direction] = append(out[d.Namespace][direction], d)
+		}
+		add("ingress", ingress, len(d.Spec.Ingress))
+		add("egress", egress, len(d.Spec.Egress))
+	}
+	return out
+}
+
+// DiffDefaultDeny reports, for each live namespace, the directions without
+// a default-deny NetworkPolicy in live: "lost" when the baseline has one
+// for that namespace, "absent" otherwise.
+func DiffDefaultDeny(baseline, live *model.NetPolSnapshot, namespaces []model.NamespacePSA) []model.DefaultDenyGap {
+	var out []model.DefaultDenyGap
+	base := defaultDenyPolicies(baseline)
+	now := defaultDenyPolicies(live)
+
+	for _, ns := range namespaces {
+		for _, direction := range []string{"ingress", "egress"} {
+			if len(now[ns.Namespace][direction]) > 0 {
+				continue
+			}
+			gap := model.DefaultDenyGap{Namespace: ns.Namespace, Direction: direction, DriftType: "absent"}
+			if policies := base[ns.Namespace][direction]; len(policies) > 0 {
+				sort.Slice(policies, func(i, j int) bool { return policies[i].Name < policies[j].Name })
+				gap.DriftType = "lost"
+				gap.DefinedIn = policies[0].DefinedIn
+				for _, p := range policies {
+					gap.BaselinePolicies = append(gap.BaselinePolicies, p.Name)
+				}
+			}
+			out = append(out, gap)
+		}
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Namespace != out[j].Namespace {
+			return out[i].Namespace < out[j].Namespace
+		}
+		return out[i].Direction < out[j].Direction
+	})
+	return out
+}
@@ -12,6 +12,8 @@ type NetPolDrift struct {
 	Changed []model.NetPolChange `json:"changed"`
 }
 
+// DiffNetworkPolicies buckets policies by key. The spec hash is the fast
+// path: only policies whose hashes differ are compared rule by rule.
 func DiffNetworkPolicies(baseline, live *model.NetPolSnapshot) NetPolDrift {
 	result := NetPolDrift{}
 
@@ -32,6 +34,7 @@ func DiffNetworkPolicies(baseline, live *model.NetPolSnapshot) NetPolDrift {
 			result.Missing = append(result.Missing, model.NetPolRef{
 				Namespace: base.Namespace,
 				Name:      base.Name,
+				DefinedIn: base.DefinedIn,
 			})
 		case !okBase && okLive:
 			result.Extra = append(result.Extra, model.NetPolRef{
@@ -39,12 +42,15 @@ func DiffNetworkPolicies(baseline, live *model.NetPolSnapshot) NetPolDrift {
 				Name:      liveItem.Name,
 			})
 		case okBase && okLive:
-			if base.SpecHash != liveItem.SpecHash {
+			// a policy rewritten while it was being sampled is not a
+			// stable change
+			if base.SpecHash != liveItem.SpecHash && !base.Unstable && !liveItem.Unstable {
 				result.Changed = append(result.Changed, model.NetPolChange{
 					Namespace: base.Namespace,
 					Name:      base.Name,
 					Baseline:  base,
 					Live:      liveItem,
+					Rules:     DiffNetPolRules(base.Spec, liveItem.Spec),
 				})
 			}
 		}
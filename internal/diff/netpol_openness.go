@@ -0,0 +1,133 @@
+package diff
+
+import (
+	"sort"
+
+	"github.com/Hru-s/driftwatch/internal/model"
+
+	networkingv1 "k8s.io/api/networking/v1"
+)
+
+// ClassifyNetPolOpenness returns the openness of each direction of spec, or
+// "" for a direction the policy does not govern. A rule with no from/to
+// peers admits every source or destination; it is open unless it lists
+// ports. A governed direction without rules denies all traffic, so a
+// podSelector: {} policy with no rules is restricted, not open.
+func ClassifyNetPolOpenness(spec *networkingv1.NetworkPolicySpec) (ingress, egress model.NetPolOpenness) {
+	if spec == nil {
+		return "", ""
+	}
+
+	// the API server defaults policyTypes the same way; baseline manifests
+	// often leave them out
+	governsIngress, governsEgress := true, len(spec.Egress) > 0
+	if len(spec.PolicyTypes) > 0 {
+		governsIngress, governsEgress = false, false
+		for _, t := range spec.PolicyTypes {
+			switch t {
+			case networkingv1.PolicyTypeIngress:
+				governsIngress = true
+			case networkingv1.PolicyTypeEgress:
+				governsEgress = true
+			}
+		}
+	}
+
+	if governsIngress {
+		ingress = model.NetPolRestricted
+		for _, rule := range spec.Ingress {
+			ingress = moreOpen(ingress, ruleOpenness(len(rule.From), len(rule.Ports)))
+		}
+	}
+	if governsEgress {
+		egress = model.NetPolRestricted
+		for _, rule := range spec.Egress {
+			egress = moreOpen(egress, ruleOpenness(len(rule.To), len(rule.Ports)))
+		}
+	}
+	return ingress, egress
+}
+
+func ruleOpenness(peers, ports int) model.NetPolOpenness {
+	switch {
+	case peers > 0:
+		return model.NetPolRestricted
+	case ports > 0:
+		return model.NetPolAllPeers
+	default:
+		return model.NetPolOpen
+	}
+}
+
+func moreOpen(a, b model.NetPolOpenness) model.NetPolOpenness {
+	if b.Rank() > a.Rank() {
+		return b
+	}
+	return a
+}
+
+// DiffNetPolOpenness reports the policy directions that admit more traffic
+// in live than in the baseline, and the live-only policies that are not
+// restricted. Policies without a decoded spec (-fast) and live policies
+// rewritten while they were sampled are skipped, as is a direction the
+// live policy no longer governs.
+func DiffNetPolOpenness(baseline, live *model.NetPolSnapshot) []model.NetPolOpennessChange {
+	var out []model.NetPolOpennessChange
+	if live == nil {
+		return out
+	}
+
+	for key, l := range live.Items {
+		if l.Spec == nil || l.Unstable {
+			continue
+		}
+		liveIn, liveEg := ClassifyNetPolOpenness(l.Spec)
+
+		var baseIn, baseEg model.NetPolOpenness
+		var definedIn *model.SourceRef
+		b, inBaseline := model.NetPolDigest{}, false
+		if baseline != nil {
+			b, inBaseline = baseline.Items[key]
+		}
+		if inBaseline {
+			if b.Spec == nil {
+				continue
+			}
+			baseIn, baseEg = ClassifyNetPolOpenness(b.Spec)
+			definedIn = b.DefinedIn
+		}
+
+		check := func(direction string, base, now model.NetPolOpenness) {
+			c := model.NetPolOpennessChange{
+				Namespace: l.Namespace,
+				Name:      l.Name,
+				Direction: direction,
+				Baseline:  base,
+				Live:      now,
+				DefinedIn: definedIn,
+			}
+			switch {
+			case !inBaseline && now.Rank() > model.NetPolRestricted.Rank():
+				c.DriftType = "new"
+			case inBaseline && base != "" && now.Rank() > base.Rank():
+				c.DriftType = "weaker"
+			default:
+				return
+			}
+			out = append(out, c)
+		}
+		check("ingress", baseIn, liveIn)
+		check("egress", baseEg, liveEg)
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Namespace != out[j].Namespace {
+			return out[i].Namespace < out[j].Namespace
+		}
+		if out[i].Name != out[j].Name {
+			return out[i].Name < out[j].Name
+		}
+		return out[i].Direction < out[j].Direction
+	})
+	return out
+}
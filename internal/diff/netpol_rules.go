@@ -0,0 +1,197 @@
+package diff
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Hru-s/driftwatch/internal/model"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DiffNetPolRules compares two NetworkPolicy specs field by field. It
+// returns nil when either spec was not kept (-fast) or when nothing
+// describable differs.
+func DiffNetPolRules(base, live *networkingv1.NetworkPolicySpec) *model.NetPolRuleDiff {
+	if base == nil || live == nil {
+		return nil
+	}
+
+	d := &model.NetPolRuleDiff{
+		PodSelector: selectorChanges(base.PodSelector, live.PodSelector),
+	}
+
+	var baseIngress, liveIngress, baseEgress, liveEgress []netpolRule
+	for _, r := range base.Ingress {
+		baseIngress = append(baseIngress, newNetPolRule("from", r.From, r.Ports))
+	}
+	for _, r := range live.Ingress {
+		liveIngress = append(liveIngress, newNetPolRule("from", r.From, r.Ports))
+	}
+	for _, r := range base.Egress {
+		baseEgress = append(baseEgress, newNetPolRule("to", r.To, r.Ports))
+	}
+	for _, r := range live.Egress {
+		liveEgress = append(liveEgress, newNetPolRule("to", r.To, r.Ports))
+	}
+	d.IngressAdded, d.IngressRemoved = diffRules("ingress", baseIngress, liveIngress, &d.PortChanges)
+	d.EgressAdded, d.EgressRemoved = diffRules("egress", baseEgress, liveEgress, &d.PortChanges)
+
+	if d.Empty() {
+		return nil
+	}
+	return d
+}
+
+// netpolRule is one ingress or egress rule rendered for comparison.
+type netpolRule struct {
+	peers string // "from [...]" / "to [...]"
+	ports string // "[80/TCP]"
+}
+
+func (r netpolRule) String() string {
+	return r.peers + " on " + r.ports
+}
+
+func newNetPolRule(dir string, peers []networkingv1.NetworkPolicyPeer, ports []networkingv1.NetworkPolicyPort) netpolRule {
+	p := make([]string, 0, len(peers))
+	for _, peer := range peers {
+		p = append(p, peerString(peer))
+	}
+	sort.Strings(p)
+	peerList := "[anywhere]"
+	if len(p) > 0 {
+		peerList = "[" + strings.Join(p, ", ") + "]"
+	}
+	return netpolRule{peers: dir + " " + peerList, ports: portsString(ports)}
+}
+
+func peerString(peer networkingv1.NetworkPolicyPeer) string {
+	if b := peer.IPBlock; b != nil {
+		if len(b.Except) == 0 {
+			return "ipBlock " + b.CIDR
+		}
+		return fmt.Sprintf("ipBlock %s except %v", b.CIDR, b.Except)
+	}
+	var parts []string
+	if peer.NamespaceSelector != nil {
+		parts = append(parts, "namespaceSelector{"+selectorString(*peer.NamespaceSelector)+"}")
+	}
+	if peer.PodSelector != nil {
+		parts = append(parts, "podSelector{"+selectorString(*peer.PodSelector)+"}")
+	}
+	return strings.Join(parts, " ")
+}
+
+func selectorString(s metav1.LabelSelector) string {
+	if f := metav1.FormatLabelSelector(&s); f != "<none>" {
+		return f
+	}
+	return ""
+}
+
+func portsString(ports []networkingv1.NetworkPolicyPort) string {
+	if len(ports) == 0 {
+		return "[all ports]"
+	}
+	out := make([]string, 0, len(ports))
+	for _, p := range ports {
+		proto := corev1.ProtocolTCP
+		if p.Protocol != nil {
+			proto = *p.Protocol
+		}
+		port := "*"
+		if p.Port != nil {
+			port = p.Port.String()
+		}
+		if p.EndPort != nil {
+			port = fmt.Sprintf("%s-%d", port, *p.EndPort)
+		}
+		out = append(out, port+"/"+string(proto))
+	}
+	sort.Strings(out)
+	return "[" + strings.Join(out, ", ") + "]"
+}
+
+// diffRules compares rules as sets. An added and a removed rule with the
+// same peers are reported as one port change instead.
+func diffRules(direction string, base, live []netpolRule, ports *[]model.NetPolPortChange) (added, removed []string) {
+	inBase := map[netpolRule]bool{}
+	for _, r := range base {
+		inBase[r] = true
+	}
+	inLive := map[netpolRule]bool{}
+	for _, r := range live {
+		inLive[r] = true
+	}
+
+	removedByPeers := map[string][]netpolRule{}
+	var removedRules []netpolRule
+	for _, r := range base {
+		if !inLive[r] {
+			removedByPeers[r.peers] = append(removedByPeers[r.peers], r)
+			removedRules = append(removedRules, r)
+		}
+	}
+	paired := map[netpolRule]bool{}
+	for _, r := range live {
+		if inBase[r] {
+			continue
+		}
+		if cands := removedByPeers[r.peers]; len(cands) > 0 {
+			old := cands[0]
+			removedByPeers[r.peers] = cands[1:]
+			paired[old] = true
+			*ports = append(*ports, model.NetPolPortChange{
+				Direction: direction,
+				Peers:     r.peers,
+				Baseline:  old.ports,
+				Live:      r.ports,
+			})
+			continue
+		}
+		added = append(added, r.String())
+	}
+	for _, r := range removedRules {
+		if !paired[r] {
+			removed = append(removed, r.String())
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	return added, removed
+}
+
+// selectorChanges describes how a pod selector's matchLabels and
+// matchExpressions changed.
+func selectorChanges(base, live metav1.LabelSelector) []model.NetPolSelectorChange {
+	var out []model.NetPolSelectorChange
+	keys := map[string]struct{}{}
+	for k := range base.MatchLabels {
+		keys[k] = struct{}{}
+	}
+	for k := range live.MatchLabels {
+		keys[k] = struct{}{}
+	}
+	sorted := make([]string, 0, len(keys))
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+	for _, k := range sorted {
+		if b, l := base.MatchLabels[k], live.MatchLabels[k]; b != l {
+			out = append(out, model.NetPolSelectorChange{Key: k, Baseline: b, Live: l})
+		}
+	}
+
+	exprs := func(s metav1.LabelSelector) string {
+		return selectorString(metav1.LabelSelector{MatchExpressions: s.MatchExpressions})
+	}
+	if b, l := exprs(base), exprs(live); b != l {
+		out = append(out, model.NetPolSelectorChange{Key: "matchExpressions", Baseline: b, Live: l})
+	}
+	return out
+}
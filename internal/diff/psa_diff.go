@@ -17,13 +17,23 @@ import (
 type PSADrift struct {
 	Extra   []model.PSADriftEntry
 	Missing []model.PSADriftEntry
+	// Unchanged lists namespaces present on both sides with the same
+	// enforce level, so summaries can report the full picture.
+	Unchanged []string
+	// ManagedBy lists live namespaces whose PSA labels lack the expected
+	// managed-by annotation (see CheckPSAManagedBy).
+	ManagedBy []model.PSAManagedByDrift
 }
 
 // DiffPSA compares baseline vs live NamespacePSA slices and buckets drift into Extra/Missing.
 // Semantics (direction):
 //   - Extra:   live is weaker / more permissive than baseline (security regression)
 //   - Missing: live is stronger / more restrictive than baseline (security tightening drift)
-func DiffPSA(baseline, live []model.NamespacePSA) PSADrift {
+//
+// exemptNamespaces are exempted from PSA by the live API server; they are
+// treated as privileged and reported with DriftType "exempted" when the
+// baseline expects a stricter level.
+func DiffPSA(baseline, live []model.NamespacePSA, exemptNamespaces []string) PSADrift {
 	bMap := make(map[string]model.NamespacePSA, len(baseline))
 	lMap := make(map[string]model.NamespacePSA, len(live))
 
@@ -33,9 +43,14 @@ func DiffPSA(baseline, live []model.NamespacePSA) PSADrift {
 	for _, l := range live {
 		lMap[l.Namespace] = l
 	}
+	exempt := make(map[string]bool, len(exemptNamespaces))
+	for _, ns := range exemptNamespaces {
+		exempt[ns] = true
+	}
 
 	var extra []model.PSADriftEntry
 	var missing []model.PSADriftEntry
+	var unchanged []string
 
 	// Baseline-driven: namespaces missing in live + posture changes.
 	for ns, b := range bMap {
@@ -46,30 +61,51 @@ func DiffPSA(baseline, live []model.NamespacePSA) PSADrift {
 				Namespace: ns,
 				Baseline:  b.Enforce,
 				DriftType: "missing",
+				DefinedIn: b.DefinedIn,
 			})
 			continue
 		}
 
-		if b.Enforce != l.Enforce {
-			dir, label := classifyPSADirection(b.Enforce, l.Enforce)
-
-			e := model.PSADriftEntry{
-				Namespace: ns,
-				Baseline:  b.Enforce,
-				Live:      l.Enforce,
-				DriftType: label, // "weaker" | "stronger" | "different"
+		if exempt[ns] {
+			if psaRank(b.Enforce) > psaRank(model.PSALevelPrivileged) {
+				extra = append(extra, model.PSADriftEntry{
+					Namespace: ns,
+					Baseline:  b.Enforce,
+					Live:      l.Enforce,
+					DriftType: "exempted",
+					Exempt:    true,
+					DefinedIn: b.DefinedIn,
+				})
+			} else {
+				unchanged = append(unchanged, ns)
 			}
+			continue
+		}
 
-			switch dir {
-			case "extra":
-				extra = append(extra, e)
-			case "missing":
-				missing = append(missing, e)
-			default:
-				// If direction can't be determined, bucket to Extra by default
-				// (conservative: treat as potential regression).
-				extra = append(extra, e)
-			}
+		if b.Enforce == l.Enforce {
+			unchanged = append(unchanged, ns)
+			continue
+		}
+
+		dir, label := classifyPSADirection(b.Enforce, l.Enforce)
+
+		e := model.PSADriftEntry{
+			Namespace: ns,
+			Baseline:  b.Enforce,
+			Live:      l.Enforce,
+			DriftType: label, // "weaker" | "stronger" | "different"
+			DefinedIn: b.DefinedIn,
+		}
+
+		switch dir {
+		case "extra":
+			extra = append(extra, e)
+		case "missing":
+			missing = append(missing, e)
+		default:
+			// If direction can't be determined, bucket to Extra by default
+			// (conservative: treat as potential regression).
+			extra = append(extra, e)
 		}
 	}
 
@@ -80,6 +116,7 @@ func DiffPSA(baseline, live []model.NamespacePSA) PSADrift {
 				Namespace: ns,
 				Live:      l.Enforce,
 				DriftType: "extra",
+				Exempt:    exempt[ns],
 			})
 		}
 	}
@@ -87,8 +124,51 @@ func DiffPSA(baseline, live []model.NamespacePSA) PSADrift {
 	// Deterministic ordering
 	sort.Slice(extra, func(i, j int) bool { return extra[i].Namespace < extra[j].Namespace })
 	sort.Slice(missing, func(i, j int) bool { return missing[i].Namespace < missing[j].Namespace })
+	sort.Strings(unchanged)
 
-	return PSADrift{Extra: extra, Missing: missing}
+	return PSADrift{Extra: extra, Missing: missing, Unchanged: unchanged}
+}
+
+// CheckPSAManagedBy flags live namespaces that carry PSA labels but whose
+// annotation is absent or differs from want. An empty want takes the
+// expected value from the baseline namespace's annotation; when the
+// baseline has none, only an absent annotation is flagged.
+func CheckPSAManagedBy(d *PSADrift, baseline, live []model.NamespacePSA, annotation, want string) {
+	expected := make(map[string]string, len(baseline))
+	definedIn := make(map[string]*model.SourceRef, len(baseline))
+	for _, b := range baseline {
+		expected[b.Namespace] = b.Annotations[annotation]
+		definedIn[b.Namespace] = b.DefinedIn
+	}
+
+	for _, l := range live {
+		if !l.HasPSALabels() {
+			continue
+		}
+		exp := want
+		if exp == "" {
+			exp = expected[l.Namespace]
+		}
+		got, ok := l.Annotations[annotation]
+		reason := ""
+		switch {
+		case !ok || got == "":
+			reason = "absent"
+		case exp != "" && got != exp:
+			reason = "wrong"
+		default:
+			continue
+		}
+		d.ManagedBy = append(d.ManagedBy, model.PSAManagedByDrift{
+			Namespace:  l.Namespace,
+			Annotation: annotation,
+			Expected:   exp,
+			Live:       got,
+			Reason:     reason,
+			DefinedIn:  definedIn[l.Namespace],
+		})
+	}
+	sort.Slice(d.ManagedBy, func(i, j int) bool { return d.ManagedBy[i].Namespace < d.ManagedBy[j].Namespace })
 }
 
 func classifyPSADirection(base, live model.PSALevel) (direction string, label string) {
@@ -109,6 +189,15 @@ func classifyPSADirection(base, live model.PSALevel) (direction string, label st
 	return "extra", "different"
 }
 
+// PSARankedLevels lists the enforce levels from weakest to strongest, in
+// the order DiffPSA ranks them; "" stands for an unlabeled namespace.
+var PSARankedLevels = []model.PSALevel{"", model.PSALevelPrivileged, model.PSALevelBaseline, model.PSALevelRestricted}
+
+// PSARank exposes the rank DiffPSA assigns to an enforce level.
+func PSARank(level model.PSALevel) int {
+	return psaRank(level)
+}
+
 func psaRank(level model.PSALevel) int {
 	switch level {
 	case model.PSALevelPrivileged:
@@ -0,0 +1,81 @@
+package diff
+
+import (
+	"sort"
+
+	"github.com/Hru-s/driftwatch/internal/model"
+)
+
+// ResourceQuotaDrift is the ResourceQuota comparison result.
+type ResourceQuotaDrift struct {
+	Missing []model.ResourceQuotaDigest `json:"missing"`
+	Extra   []model.ResourceQuotaDigest `json:"extra"`
+	Changed []model.ResourceQuotaChange `json:"changed"`
+}
+
+// DiffResourceQuotas compares ResourceQuotas by namespace/name. A quota on
+// both sides is changed when any hard limit is added, removed or set to a
+// different quantity.
+func DiffResourceQuotas(baseline, live []model.ResourceQuotaDigest) ResourceQuotaDrift {
+	bMap := make(map[string]model.ResourceQuotaDigest, len(baseline))
+	lMap := make(map[string]model.ResourceQuotaDigest, len(live))
+	for _, b := range baseline {
+		bMap[b.String()] = b
+	}
+	for _, l := range live {
+		lMap[l.String()] = l
+	}
+
+	var result ResourceQuotaDrift
+	for key, b := range bMap {
+		l, ok := lMap[key]
+		if !ok {
+			result.Missing = append(result.Missing, b)
+			continue
+		}
+		if limits := quotaLimitChanges(b.Hard, l.Hard); len(limits) > 0 {
+			result.Changed = append(result.Changed, model.ResourceQuotaChange{
+				Namespace: b.Namespace,
+				Name:      b.Name,
+				Limits:    limits,
+				DefinedIn: b.DefinedIn,
+			})
+		}
+	}
+	for key, l := range lMap {
+		if _, ok := bMap[key]; !ok {
+			result.Extra = append(result.Extra, l)
+		}
+	}
+
+	sortQuotas := func(s []model.ResourceQuotaDigest) {
+		sort.Slice(s, func(i, j int) bool { return s[i].String() < s[j].String() })
+	}
+	sortQuotas(result.Missing)
+	sortQuotas(result.Extra)
+	sort.Slice(result.Changed, func(i, j int) bool {
+		a, b := result.Changed[i], result.Changed[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+
+	return result
+}
+
+func quotaLimitChanges(base, live map[string]string) []model.QuotaLimitChange {
+	var out []model.QuotaLimitChange
+	for res, b := range base {
+		if l := live[res]; l != b {
+			out = append(out, model.QuotaLimitChange{Resource: res, Baseline: b, Live: l})
+		}
+	}
+	for res, l := range live {
+		if _, ok := base[res]; !ok {
+			out = append(out, model.QuotaLimitChange{Resource: res, Live: l})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Resource < out[j].Resource })
+	return out
+}
@@ -5,14 +5,24 @@ import "github.com/Hru-s/driftwatch/internal/model"
 type RBACDrift struct {
 	Extra   map[model.SubjectKey][]model.Permission
 	Missing map[model.SubjectKey][]model.Permission
+	// Renamed is filled by DetectSubjectRenames; renamed subjects are
+	// removed from Extra and Missing.
+	Renamed []model.SubjectRename
+	// ResourceNames is filled by GroupResourceNameChanges; the named
+	// permissions it describes are removed from Extra and Missing.
+	ResourceNames []model.ResourceNameChange
+	// DefinedIn is the baseline snapshot's DefinedIn, to locate Missing
+	// permissions in the baseline directory.
+	DefinedIn map[model.SubjectKey]map[model.Permission][]model.SourceRef
 }
 
 // DiffRBAC returns permissions that live has extra vs baseline, and ones
 // that are missing in live compared to baseline.
 func DiffRBAC(baseline, live *model.RBACSnapshot) RBACDrift {
 	result := RBACDrift{
-		Extra:   make(map[model.SubjectKey][]model.Permission),
-		Missing: make(map[model.SubjectKey][]model.Permission),
+		Extra:     make(map[model.SubjectKey][]model.Permission),
+		Missing:   make(map[model.SubjectKey][]model.Permission),
+		DefinedIn: baseline.DefinedIn,
 	}
 
 	// union of subjects
@@ -0,0 +1,55 @@
+package diff
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/Hru-s/driftwatch/internal/model"
+)
+
+// DetectSubjectRenames pairs subjects that exist only in baseline with
+// subjects that exist only in live when both are of the same kind and hold
+// identical permission sets, and reports them as renames instead of one
+// missing plus one extra subject. A pair is only formed when the match is
+// unambiguous (exactly one candidate on each side).
+func DetectSubjectRenames(d *RBACDrift, baseline, live *model.RBACSnapshot) {
+	gone := map[string][]model.SubjectKey{}
+	for subj, perms := range d.Missing {
+		if len(live.Subjects[subj]) == 0 {
+			sig := permissionSignature(subj.Kind, perms)
+			gone[sig] = append(gone[sig], subj)
+		}
+	}
+	added := map[string][]model.SubjectKey{}
+	for subj, perms := range d.Extra {
+		if len(baseline.Subjects[subj]) == 0 {
+			sig := permissionSignature(subj.Kind, perms)
+			added[sig] = append(added[sig], subj)
+		}
+	}
+
+	for sig, from := range gone {
+		to := added[sig]
+		if len(from) != 1 || len(to) != 1 {
+			continue
+		}
+		perms := append([]model.Permission(nil), d.Extra[to[0]]...)
+		sort.Slice(perms, func(i, j int) bool { return perms[i].String() < perms[j].String() })
+		d.Renamed = append(d.Renamed, model.SubjectRename{From: from[0], To: to[0], Permissions: perms})
+		delete(d.Missing, from[0])
+		delete(d.Extra, to[0])
+	}
+
+	sort.Slice(d.Renamed, func(i, j int) bool {
+		return d.Renamed[i].To.String() < d.Renamed[j].To.String()
+	})
+}
+
+func permissionSignature(kind string, perms []model.Permission) string {
+	keys := make([]string, 0, len(perms))
+	for _, p := range perms {
+		keys = append(keys, p.String())
+	}
+	sort.Strings(keys)
+	return kind + "\n" + strings.Join(keys, "\n")
+}
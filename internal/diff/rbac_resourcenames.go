@@ -0,0 +1,117 @@
+package diff
+
+import (
+	"sort"
+
+	"github.com/Hru-s/driftwatch/internal/model"
+)
+
+// GroupResourceNameChanges compares resourceName lists as sets. For every
+// grant (subject, scope, verb, resource) that names specific resources on
+// both sides, the named permissions that differ are moved out of Extra and
+// Missing into one ResourceNames entry listing the added, removed and
+// unchanged names. Grants that went from named to unconstrained (or back)
+// are left as ordinary extra/missing permissions.
+func GroupResourceNameChanges(d *RBACDrift, baseline, live *model.RBACSnapshot) {
+	subjects := map[model.SubjectKey]struct{}{}
+	for s := range d.Extra {
+		subjects[s] = struct{}{}
+	}
+	for s := range d.Missing {
+		subjects[s] = struct{}{}
+	}
+
+	for subj := range subjects {
+		baseNames := namedResources(baseline.Subjects[subj])
+		liveNames := namedResources(live.Subjects[subj])
+
+		changed := map[model.Permission]bool{}
+		for grant, base := range baseNames {
+			cur, ok := liveNames[grant]
+			if !ok {
+				continue
+			}
+			c := model.ResourceNameChange{
+				Subject:        subj,
+				ScopeNamespace: grant.ScopeNamespace,
+				APIGroup:       grant.APIGroup,
+				Resource:       grant.Resource,
+				Verb:           grant.Verb,
+			}
+			for name := range cur {
+				if _, ok := base[name]; ok {
+					c.Unchanged = append(c.Unchanged, name)
+				} else {
+					c.Added = append(c.Added, name)
+				}
+			}
+			for name := range base {
+				if _, ok := cur[name]; !ok {
+					c.Removed = append(c.Removed, name)
+				}
+			}
+			if len(c.Added) == 0 && len(c.Removed) == 0 {
+				continue
+			}
+			sort.Strings(c.Added)
+			sort.Strings(c.Removed)
+			sort.Strings(c.Unchanged)
+			d.ResourceNames = append(d.ResourceNames, c)
+			changed[grant] = true
+		}
+		if len(changed) == 0 {
+			continue
+		}
+
+		d.Extra[subj] = withoutGrants(d.Extra[subj], changed)
+		if len(d.Extra[subj]) == 0 {
+			delete(d.Extra, subj)
+		}
+		d.Missing[subj] = withoutGrants(d.Missing[subj], changed)
+		if len(d.Missing[subj]) == 0 {
+			delete(d.Missing, subj)
+		}
+	}
+
+	sort.Slice(d.ResourceNames, func(i, j int) bool {
+		a, b := d.ResourceNames[i], d.ResourceNames[j]
+		if a.Subject != b.Subject {
+			return a.Subject.String() < b.Subject.String()
+		}
+		return a.Grant().String() < b.Grant().String()
+	})
+}
+
+// namedResources groups permissions restricted to specific resourceNames
+// by their grant (the permission with ResourceName cleared).
+func namedResources(perms map[model.Permission]struct{}) map[model.Permission]map[string]struct{} {
+	out := map[model.Permission]map[string]struct{}{}
+	for p := range perms {
+		if p.NonResourceURL != "" || p.ResourceName == "" {
+			continue
+		}
+		grant := p
+		grant.ResourceName = ""
+		if out[grant] == nil {
+			out[grant] = map[string]struct{}{}
+		}
+		out[grant][p.ResourceName] = struct{}{}
+	}
+	return out
+}
+
+// withoutGrants drops the named permissions of grants from perms. The
+// unconstrained permission of a grant (no resourceName) is kept: it
+// is drift of its own, not part of the resourceName change.
+func withoutGrants(perms []model.Permission, grants map[model.Permission]bool) []model.Permission {
+	var out []model.Permission
+	for _, p := range perms {
+		grant := p
+		grant.ResourceName = ""
+		if p.ResourceName != "" && grants[grant] {
+			continue
+		}
+		out = append(out, p)
+	}
+	return out
+}
@@ -0,0 +1,47 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/Hru-s/driftwatch/internal/model"
+)
+
+func permSet(perms ...model.Permission) map[model.Permission]struct{} {
+	out := map[model.Permission]struct{}{}
+	for _, p := range perms {
+		out[p] = struct{}{}
+	}
+	return out
+}
+
+// A grant on all secrets added next to a changed resourceName list is an
+// escalation of its own and must stay in Extra.
+func TestGroupResourceNameChangesKeepsUnconstrainedGrant(t *testing.T) {
+	subj := model.SubjectKey{Kind: "User", Name: "alice"}
+	secret := func(name string) model.Permission {
+		return model.Permission{ScopeNamespace: "*", Resource: "secrets", Verb: "get", ResourceName: name}
+	}
+	baseline := &model.RBACSnapshot{Subjects: map[model.SubjectKey]map[model.Permission]struct{}{
+		subj: permSet(secret("a")),
+	}}
+	live := &model.RBACSnapshot{Subjects: map[model.SubjectKey]map[model.Permission]struct{}{
+		subj: permSet(secret("a"), secret("b"), secret("")),
+	}}
+
+	d := DiffRBAC(baseline, live)
+	GroupResourceNameChanges(&d, baseline, live)
+
+	if len(d.ResourceNames) != 1 {
+		t.Fatalf("ResourceNames = %v, want one change", d.ResourceNames)
+	}
+	if got := d.ResourceNames[0].Added; len(got) != 1 || got[0] != "b" {
+		t.Errorf("Added = %v, want [b]", got)
+	}
+	extra := d.Extra[subj]
+	if len(extra) != 1 || extra[0] != secret("") {
+		t.Errorf("Extra = %v, want only %v", extra, secret(""))
+	}
+	if len(d.Missing) != 0 {
+		t.Errorf("Missing = %v, want none", d.Missing)
+	}
+}
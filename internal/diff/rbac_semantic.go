@@ -0,0 +1,42 @@
+package diff
+
+import "github.com/Hru-s/driftwatch/internal/model"
+
+// DropCoveredPermissions removes from Extra the permissions some baseline
+// permission of the same subject covers (see model.Permission.Covers), and
+// from Missing the ones some live permission covers, so a wildcard grant on
+// one side and the concrete grants it includes on the other are not
+// reported as drift.
+func DropCoveredPermissions(d *RBACDrift, baseline, live *model.RBACSnapshot) {
+	dropCovered(d.Extra, baseline)
+	dropCovered(d.Missing, live)
+}
+
+func dropCovered(bucket map[model.SubjectKey][]model.Permission, other *model.RBACSnapshot) {
+	for subj, perms := range bucket {
+		held := other.Subjects[subj]
+		if len(held) == 0 {
+			continue
+		}
+		kept := perms[:0]
+		for _, p := range perms {
+			if !coveredByAny(held, p) {
+				kept = append(kept, p)
+			}
+		}
+		if len(kept) == 0 {
+			delete(bucket, subj)
+		} else {
+			bucket[subj] = kept
+		}
+	}
+}
+
+func coveredByAny(perms map[model.Permission]struct{}, p model.Permission) bool {
+	for q := range perms {
+		if q.Covers(p) {
+			return true
+		}
+	}
+	return false
+}
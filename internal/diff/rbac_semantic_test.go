@@ -0,0 +1,35 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/Hru-s/driftwatch/internal/model"
+)
+
+// Only an unconstrained baseline grant covers a named live one; a
+// resourceName of "*" is a literal name.
+func TestDropCoveredPermissionsResourceNames(t *testing.T) {
+	subj := model.SubjectKey{Kind: "User", Name: "alice"}
+	secret := func(name string) model.Permission {
+		return model.Permission{ScopeNamespace: "*", Resource: "secrets", Verb: "get", ResourceName: name}
+	}
+	for _, tt := range []struct {
+		baseline  string
+		wantExtra int
+	}{
+		{"", 0},
+		{"*", 1},
+	} {
+		baseline := &model.RBACSnapshot{Subjects: map[model.SubjectKey]map[model.Permission]struct{}{
+			subj: permSet(secret(tt.baseline)),
+		}}
+		live := &model.RBACSnapshot{Subjects: map[model.SubjectKey]map[model.Permission]struct{}{
+			subj: permSet(secret("foo")),
+		}}
+		d := DiffRBAC(baseline, live)
+		DropCoveredPermissions(&d, baseline, live)
+		if got := len(d.Extra[subj]); got != tt.wantExtra {
+			t.Errorf("baseline resourceName %q: %d extra permissions, want %d", tt.baseline, got, tt.wantExtra)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package diff
+
+import (
+	"sort"
+
+	"github.com/Hru-s/driftwatch/internal/model"
+)
+
+// ServiceAccountDrift is the ServiceAccount comparison result, bucketed
+// like PSADrift:
+//   - Extra:   live is weaker (token automount turned on), or only the
+//     attached secrets changed
+//   - Missing: live is stronger (token automount turned off), or the
+//     ServiceAccount is missing in live
+type ServiceAccountDrift struct {
+	Extra   []model.ServiceAccountDriftEntry `json:"extra"`
+	Missing []model.ServiceAccountDriftEntry `json:"missing"`
+}
+
+// DiffServiceAccounts compares the ServiceAccounts declared in the baseline
+// with live: the effective automountServiceAccountToken setting and the
+// attached imagePullSecrets and secrets. ServiceAccounts only in live are
+// not reported, since every namespace gets a "default" one.
+func DiffServiceAccounts(baseline, live []model.ServiceAccountDigest) ServiceAccountDrift {
+	lMap := make(map[string]model.ServiceAccountDigest, len(live))
+	for _, l := range live {
+		lMap[l.String()] = l
+	}
+
+	var result ServiceAccountDrift
+	for _, b := range baseline {
+		e := model.ServiceAccountDriftEntry{
+			Namespace:           b.Namespace,
+			Name:                b.Name,
+			BaselineMountsToken: b.MountsToken(),
+			DefinedIn:           b.DefinedIn,
+		}
+		l, ok := lMap[b.String()]
+		if !ok {
+			e.DriftType = "missing"
+			result.Missing = append(result.Missing, e)
+			continue
+		}
+		e.LiveMountsToken = l.MountsToken()
+		e.ImagePullSecretsAdded, e.ImagePullSecretsRemoved = stringSetDelta(b.ImagePullSecrets, l.ImagePullSecrets)
+		e.SecretsAdded, e.SecretsRemoved = stringSetDelta(b.Secrets, l.Secrets)
+
+		switch {
+		case !e.BaselineMountsToken && e.LiveMountsToken:
+			e.DriftType = "weaker"
+			result.Extra = append(result.Extra, e)
+		case e.BaselineMountsToken && !e.LiveMountsToken:
+			e.DriftType = "stronger"
+			result.Missing = append(result.Missing, e)
+		case len(e.ImagePullSecretsAdded)+len(e.ImagePullSecretsRemoved)+len(e.SecretsAdded)+len(e.SecretsRemoved) > 0:
+			e.DriftType = "changed"
+			result.Extra = append(result.Extra, e)
+		}
+	}
+
+	byName := func(s []model.ServiceAccountDriftEntry) {
+		sort.Slice(s, func(i, j int) bool { return s[i].String() < s[j].String() })
+	}
+	byName(result.Extra)
+	byName(result.Missing)
+	return result
+}
+
+// stringSetDelta returns the items of live not in base (added) and of base
+// not in live (removed), sorted.
+func stringSetDelta(base, live []string) (added, removed []string) {
+	inBase := make(map[string]bool, len(base))
+	for _, s := range base {
+		inBase[s] = true
+	}
+	inLive := make(map[string]bool, len(live))
+	for _, s := range live {
+		inLive[s] = true
+		if !inBase[s] {
+			added = append(added, s)
+		}
+	}
+	for _, s := range base {
+		if !inLive[s] {
+			removed = append(removed, s)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	return added, removed
+}
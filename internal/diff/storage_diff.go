@@ -0,0 +1,54 @@
+package diff
+
+import (
+	"sort"
+
+	"github.com/Hru-s/driftwatch/internal/model"
+)
+
+// StorageClassDrift is the StorageClass comparison result.
+type StorageClassDrift struct {
+	Missing []model.StorageClassDigest `json:"missing"`
+	Extra   []model.StorageClassDigest `json:"extra"`
+	Changed []model.StorageClassChange `json:"changed"`
+}
+
+// DiffStorageClasses compares StorageClasses by name.
+func DiffStorageClasses(baseline, live []model.StorageClassDigest) StorageClassDrift {
+	bMap := make(map[string]model.StorageClassDigest, len(baseline))
+	lMap := make(map[string]model.StorageClassDigest, len(live))
+	for _, b := range baseline {
+		bMap[b.Name] = b
+	}
+	for _, l := range live {
+		lMap[l.Name] = l
+	}
+
+	var result StorageClassDrift
+	for name, b := range bMap {
+		l, ok := lMap[name]
+		if !ok {
+			result.Missing = append(result.Missing, b)
+			continue
+		}
+		if b != l {
+			result.Changed = append(result.Changed, model.StorageClassChange{
+				Name:           name,
+				Baseline:       b,
+				Live:           l,
+				DefaultChanged: b.IsDefault != l.IsDefault,
+			})
+		}
+	}
+	for name, l := range lMap {
+		if _, ok := bMap[name]; !ok {
+			result.Extra = append(result.Extra, l)
+		}
+	}
+
+	sort.Slice(result.Missing, func(i, j int) bool { return result.Missing[i].Name < result.Missing[j].Name })
+	sort.Slice(result.Extra, func(i, j int) bool { return result.Extra[i].Name < result.Extra[j].Name })
+	sort.Slice(result.Changed, func(i, j int) bool { return result.Changed[i].Name < result.Changed[j].Name })
+
+	return result
+}
@@ -0,0 +1,94 @@
+package diff
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/Hru-s/driftwatch/internal/model"
+)
+
+// WebhookDrift is the admission webhook comparison result, one entry per
+// configuration with drift, sorted by kind and name.
+type WebhookDrift struct {
+	Configurations []model.WebhookConfigDrift `json:"configurations"`
+}
+
+type webhookKey struct {
+	kind, configuration, name string
+}
+
+// DiffWebhooks compares admission webhooks by configuration kind, name and
+// webhook name. A webhook on both sides is changed when its failurePolicy,
+// rules (by hash) or namespaceSelector differ.
+func DiffWebhooks(baseline, live []model.WebhookDigest) WebhookDrift {
+	bMap := make(map[webhookKey]model.WebhookDigest, len(baseline))
+	lMap := make(map[webhookKey]model.WebhookDigest, len(live))
+	for _, b := range baseline {
+		bMap[webhookKey{b.Kind, b.Configuration, b.Name}] = b
+	}
+	for _, l := range live {
+		lMap[webhookKey{l.Kind, l.Configuration, l.Name}] = l
+	}
+
+	configs := map[[2]string]*model.WebhookConfigDrift{}
+	group := func(kind, name string) *model.WebhookConfigDrift {
+		k := [2]string{kind, name}
+		if configs[k] == nil {
+			configs[k] = &model.WebhookConfigDrift{Kind: kind, Name: name}
+		}
+		return configs[k]
+	}
+
+	for key, b := range bMap {
+		l, ok := lMap[key]
+		if !ok {
+			g := group(b.Kind, b.Configuration)
+			g.Missing = append(g.Missing, b)
+			continue
+		}
+		if fields := webhookFieldChanges(b, l); len(fields) > 0 {
+			g := group(b.Kind, b.Configuration)
+			g.Changed = append(g.Changed, model.WebhookChange{Webhook: b.Name, Fields: fields, DefinedIn: b.DefinedIn})
+		}
+	}
+	for key, l := range lMap {
+		if _, ok := bMap[key]; !ok {
+			g := group(l.Kind, l.Configuration)
+			g.Extra = append(g.Extra, l)
+		}
+	}
+
+	var result WebhookDrift
+	for _, g := range configs {
+		sort.Slice(g.Missing, func(i, j int) bool { return g.Missing[i].Name < g.Missing[j].Name })
+		sort.Slice(g.Extra, func(i, j int) bool { return g.Extra[i].Name < g.Extra[j].Name })
+		sort.Slice(g.Changed, func(i, j int) bool { return g.Changed[i].Webhook < g.Changed[j].Webhook })
+		result.Configurations = append(result.Configurations, *g)
+	}
+	sort.Slice(result.Configurations, func(i, j int) bool {
+		a, b := result.Configurations[i], result.Configurations[j]
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Name < b.Name
+	})
+	return result
+}
+
+func webhookFieldChanges(b, l model.WebhookDigest) []model.WebhookFieldChange {
+	var out []model.WebhookFieldChange
+	if b.FailurePolicy != l.FailurePolicy {
+		out = append(out, model.WebhookFieldChange{Field: "failurePolicy", Baseline: b.FailurePolicy, Live: l.FailurePolicy})
+	}
+	if b.RulesHash != l.RulesHash {
+		out = append(out, model.WebhookFieldChange{
+			Field:    "rules",
+			Baseline: strings.Join(b.Rules, "; "),
+			Live:     strings.Join(l.Rules, "; "),
+		})
+	}
+	if b.NamespaceSelector != l.NamespaceSelector {
+		out = append(out, model.WebhookFieldChange{Field: "namespaceSelector", Baseline: b.NamespaceSelector, Live: l.NamespaceSelector})
+	}
+	return out
+}
@@ -0,0 +1,75 @@
+package kube
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// DirectConfig describes a kubeconfig-less connection to one API server.
+type DirectConfig struct {
+	Host string
+	// Token and TokenFile are mutually exclusive; TokenFile is re-read
+	// periodically so projected ServiceAccount tokens keep working.
+	Token     string
+	TokenFile string
+	// CAFile verifies the server certificate; empty uses the system roots.
+	CAFile string
+}
+
+// restConfig returns the REST config for kubeconfigPath and context (""
+// for the kubeconfig's current-context), or for direct when it is set. An
+// empty kubeconfigPath selects the in-cluster config of the pod's
+// ServiceAccount.
+func restConfig(kubeconfigPath, context string, direct DirectConfig) (*rest.Config, error) {
+	if direct.Host == "" && kubeconfigPath == "" {
+		config, err := rest.InClusterConfig()
+		if err != nil {
+			return nil, fmt.Errorf("no kubeconfig given and no in-cluster ServiceAccount available: %w", err)
+		}
+		return config, nil
+	}
+	if direct.Host == "" {
+		return kubeconfigRESTConfig(kubeconfigPath, context)
+	}
+
+	if (direct.Token == "") == (direct.TokenFile == "") {
+		return nil, fmt.Errorf("API server %s: exactly one of a token or a token file is required", direct.Host)
+	}
+	return &rest.Config{
+		Host:            direct.Host,
+		BearerToken:     direct.Token,
+		BearerTokenFile: direct.TokenFile,
+		TLSClientConfig: rest.TLSClientConfig{CAFile: direct.CAFile},
+	}, nil
+}
+
+func kubeconfigRESTConfig(kubeconfigPath, context string) (*rest.Config, error) {
+	cc := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		&clientcmd.ClientConfigLoadingRules{ExplicitPath: kubeconfigPath},
+		&clientcmd.ConfigOverrides{CurrentContext: context},
+	)
+	if context != "" {
+		raw, err := cc.RawConfig()
+		if err != nil {
+			return nil, fmt.Errorf("loading kubeconfig %s: %w", kubeconfigPath, err)
+		}
+		if _, ok := raw.Contexts[context]; !ok {
+			names := make([]string, 0, len(raw.Contexts))
+			for name := range raw.Contexts {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			return nil, fmt.Errorf("context %q not found in kubeconfig %s (available: %s)",
+				context, kubeconfigPath, strings.Join(names, ", "))
+		}
+	}
+	config, err := cc.ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("building REST config from %s: %w", kubeconfigPath, err)
+	}
+	return config, nil
+}
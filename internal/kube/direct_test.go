@@ -0,0 +1,80 @@
+package kube
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRestConfigDirect(t *testing.T) {
+	config, err := restConfig("", "", DirectConfig{Host: "https://10.0.0.1:6443", TokenFile: "/var/run/token", CAFile: "/etc/ca.crt"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.Host != "https://10.0.0.1:6443" || config.BearerTokenFile != "/var/run/token" || config.BearerToken != "" || config.CAFile != "/etc/ca.crt" {
+		t.Errorf("config = %+v", config)
+	}
+
+	for name, direct := range map[string]DirectConfig{
+		"neither": {Host: "https://10.0.0.1:6443"},
+		"both":    {Host: "https://10.0.0.1:6443", Token: "t", TokenFile: "/var/run/token"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := restConfig("", "", direct)
+			if err == nil || !strings.Contains(err.Error(), "exactly one of a token or a token file") {
+				t.Errorf("err = %v", err)
+			}
+		})
+	}
+}
+
+// A direct host takes precedence over a kubeconfig path.
+func TestRestConfigDirectIgnoresKubeconfig(t *testing.T) {
+	config, err := restConfig("/does/not/exist", "", DirectConfig{Host: "https://api", Token: "t"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.Host != "https://api" || config.BearerToken != "t" {
+		t.Errorf("config = %+v", config)
+	}
+}
+
+func TestRestConfigKubeconfigContext(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "kubeconfig")
+	kubeconfig := `apiVersion: v1
+kind: Config
+current-context: prod
+clusters:
+- name: prod
+  cluster: {server: "https://prod:6443"}
+- name: staging
+  cluster: {server: "https://staging:6443"}
+users:
+- name: u
+  user: {token: t}
+contexts:
+- name: prod
+  context: {cluster: prod, user: u}
+- name: staging
+  context: {cluster: staging, user: u}
+`
+	if err := os.WriteFile(path, []byte(kubeconfig), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	for ctx, host := range map[string]string{"": "https://prod:6443", "staging": "https://staging:6443"} {
+		config, err := restConfig(path, ctx, DirectConfig{})
+		if err != nil {
+			t.Fatalf("context %q: %v", ctx, err)
+		}
+		if config.Host != host {
+			t.Errorf("context %q: host = %s, want %s", ctx, config.Host, host)
+		}
+	}
+
+	_, err := restConfig(path, "dev", DirectConfig{})
+	if err == nil || !strings.Contains(err.Error(), `context "dev" not found`) || !strings.Contains(err.Error(), "available: prod, staging") {
+		t.Errorf("err = %v", err)
+	}
+}
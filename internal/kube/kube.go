@@ -2,16 +2,68 @@ package kube
 
 import (
 	"fmt"
+	"net/http"
+	"strings"
 
 	"k8s.io/client-go/kubernetes"
-	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/rest"
 )
 
-// BuildClient creates a Kubernetes clientset from the given kubeconfig path.
-func BuildClient(kubeconfigPath string) (*kubernetes.Clientset, error) {
-	config, err := clientcmd.BuildConfigFromFlags("", kubeconfigPath)
+// RecordedPrefix selects the recorded-fixture backend instead of a real
+// cluster, e.g. -kubeconfig recorded:./fixtures.
+const RecordedPrefix = "recorded:"
+
+// ClientConfig holds the settings of clients built by BuildClient and
+// BuildSchemaSource. The zero value connects through the kubeconfig with
+// client-go's defaults.
+type ClientConfig struct {
+	// QPS and Burst set the client-side rate limit. client-go's defaults
+	// (5 and 10) throttle collection on large clusters; 0 keeps them.
+	QPS   float32
+	Burst int
+	// Impersonate, when UserName is set, makes clients act as that user or
+	// ServiceAccount (-as, -as-group, -as-uid), so collection sees only
+	// what that identity may list.
+	Impersonate rest.ImpersonationConfig
+	// ListConcurrency bounds the number of in-flight List requests per
+	// client, so parallel collectors don't trip API Priority and Fairness
+	// limits on shared API servers. 0 means unbounded.
+	ListConcurrency int
+	// Direct, when Host is set, talks to that API server with a bearer
+	// token instead of loading a kubeconfig (-api-server with -token or
+	// -token-file).
+	Direct DirectConfig
+}
+
+// BuildClient creates a Kubernetes client from the given kubeconfig path
+// and context ("" for the current-context), configured by cfg.
+// A path of the form "recorded:<dir>" returns a client backed by recorded
+// List responses instead (see BuildRecordedClient). When cfg.Direct is
+// set, kubeconfigPath is ignored; an empty kubeconfigPath uses the
+// in-cluster config (running as a pod).
+func BuildClient(kubeconfigPath, context string, cfg ClientConfig) (kubernetes.Interface, error) {
+	if dir, ok := strings.CutPrefix(kubeconfigPath, RecordedPrefix); ok {
+		return BuildRecordedClient(dir)
+	}
+
+	config, err := restConfig(kubeconfigPath, context, cfg.Direct)
 	if err != nil {
-		return nil, fmt.Errorf("building REST config from %s: %w", kubeconfigPath, err)
+		return nil, err
+	}
+	if cfg.QPS > 0 {
+		config.QPS = cfg.QPS
+	}
+	if cfg.Burst > 0 {
+		config.Burst = cfg.Burst
+	}
+	if cfg.Impersonate.UserName != "" {
+		config.Impersonate = cfg.Impersonate
+	}
+	if cfg.ListConcurrency > 0 {
+		limit := cfg.ListConcurrency
+		config.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+			return newLimitTransport(rt, limit)
+		})
 	}
 
 	clientset, err := kubernetes.NewForConfig(config)
@@ -0,0 +1,49 @@
+package kube
+
+import (
+	"io"
+	"net/http"
+	"sync"
+)
+
+// limitTransport holds a semaphore slot from request start until the
+// response body is closed, since List payloads stream after RoundTrip.
+type limitTransport struct {
+	next http.RoundTripper
+	sem  chan struct{}
+}
+
+func newLimitTransport(next http.RoundTripper, n int) http.RoundTripper {
+	return &limitTransport{next: next, sem: make(chan struct{}, n)}
+}
+
+func (t *limitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		return t.next.RoundTrip(req)
+	}
+
+	select {
+	case t.sem <- struct{}{}:
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+	release := sync.OnceFunc(func() { <-t.sem })
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	resp.Body = &releasingBody{ReadCloser: resp.Body, release: release}
+	return resp, nil
+}
+
+type releasingBody struct {
+	io.ReadCloser
+	release func()
+}
+
+func (b *releasingBody) Close() error {
+	defer b.release()
+	return b.ReadCloser.Close()
+}
@@ -0,0 +1,101 @@
+package kube
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+func okResponse(*http.Request) (*http.Response, error) {
+	return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("{}"))}, nil
+}
+
+// A GET holds its slot until the response body is closed, not just until
+// RoundTrip returns.
+func TestLimitTransportHoldsSlotUntilBodyClosed(t *testing.T) {
+	rt := newLimitTransport(roundTripFunc(okResponse), 1)
+	get := func(ctx context.Context) (*http.Response, error) {
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "https://api/pods", nil)
+		return rt.RoundTrip(req)
+	}
+
+	first, err := get(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := get(ctx); err == nil {
+		t.Fatal("second GET ran while the first body was open")
+	}
+
+	first.Body.Close()
+	second, err := get(context.Background())
+	if err != nil {
+		t.Fatalf("GET after the first body was closed: %v", err)
+	}
+	second.Body.Close()
+	// closing twice must not release a slot it does not hold
+	second.Body.Close()
+	third, err := get(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	third.Body.Close()
+}
+
+func TestLimitTransportPassesWritesThrough(t *testing.T) {
+	rt := newLimitTransport(roundTripFunc(okResponse), 1)
+	req, _ := http.NewRequest(http.MethodGet, "https://api/pods", nil)
+	held, err := rt.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer held.Body.Close()
+
+	done := make(chan error, 1)
+	go func() {
+		req, _ := http.NewRequest(http.MethodPost, "https://api/events", nil)
+		_, err := rt.RoundTrip(req)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("POST waited for a GET slot")
+	}
+}
+
+// A failed request gives its slot back.
+func TestLimitTransportReleasesOnError(t *testing.T) {
+	calls := 0
+	rt := newLimitTransport(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+		if calls == 1 {
+			return nil, io.ErrUnexpectedEOF
+		}
+		return okResponse(req)
+	}), 1)
+	req, _ := http.NewRequest(http.MethodGet, "https://api/pods", nil)
+	if _, err := rt.RoundTrip(req); err == nil {
+		t.Fatal("want the first request to fail")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	resp, err := rt.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		t.Fatalf("slot not released after an error: %v", err)
+	}
+	resp.Body.Close()
+}
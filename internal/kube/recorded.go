@@ -0,0 +1,146 @@
+package kube
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	yamlutil "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+// BuildRecordedClient returns a client that serves List calls from recorded
+// API responses in dir instead of a live API server. Each .json/.yaml/.yml
+// file may hold a List (e.g. `kubectl get rolebindings -A -o json`) or
+// individual objects; every object must carry apiVersion and kind.
+func BuildRecordedClient(dir string) (kubernetes.Interface, error) {
+	var objects []runtime.Object
+
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != dir && d.Name() == recordedOpenAPIDir {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".json", ".yaml", ".yml":
+		default:
+			return nil
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("open %s: %w", path, err)
+		}
+		defer f.Close()
+
+		objs, err := decodeRecorded(f)
+		if err != nil {
+			return fmt.Errorf("decode %s: %w", path, err)
+		}
+		objects = append(objects, objs...)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("loading recorded cluster from %s: %w", dir, err)
+	}
+
+	return fake.NewSimpleClientset(dedupeObjects(objects)...), nil
+}
+
+// dedupeObjects keeps the last recording of each kind/namespace/name; the
+// fake tracker refuses duplicates and overlapping recordings are common.
+func dedupeObjects(objects []runtime.Object) []runtime.Object {
+	index := map[string]int{}
+	var out []runtime.Object
+	for _, obj := range objects {
+		accessor, err := meta.Accessor(obj)
+		if err != nil {
+			out = append(out, obj)
+			continue
+		}
+		key := obj.GetObjectKind().GroupVersionKind().Kind + "/" + accessor.GetNamespace() + "/" + accessor.GetName()
+		if i, ok := index[key]; ok {
+			out[i] = obj
+			continue
+		}
+		index[key] = len(out)
+		out = append(out, obj)
+	}
+	return out
+}
+
+func decodeRecorded(r io.Reader) ([]runtime.Object, error) {
+	deserializer := scheme.Codecs.UniversalDeserializer()
+	dec := yamlutil.NewYAMLOrJSONDecoder(r, 4096)
+
+	var out []runtime.Object
+	for {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+		if len(raw) == 0 || string(raw) == "null" {
+			continue
+		}
+
+		var list struct {
+			APIVersion string            `json:"apiVersion"`
+			Kind       string            `json:"kind"`
+			Items      []json.RawMessage `json:"items"`
+		}
+		if err := json.Unmarshal(raw, &list); err != nil {
+			return nil, err
+		}
+
+		docs := []json.RawMessage{raw}
+		if strings.HasSuffix(list.Kind, "List") {
+			docs = docs[:0]
+			for _, item := range list.Items {
+				// Raw API responses for typed lists (e.g. RoleBindingList)
+				// omit apiVersion/kind on items; derive them from the list.
+				typed, err := withTypeMeta(item, list.APIVersion, strings.TrimSuffix(list.Kind, "List"))
+				if err != nil {
+					return nil, err
+				}
+				docs = append(docs, typed)
+			}
+		}
+		for _, doc := range docs {
+			obj, _, err := deserializer.Decode(doc, nil, nil)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, obj)
+		}
+	}
+	return out, nil
+}
+
+func withTypeMeta(item json.RawMessage, apiVersion, kind string) (json.RawMessage, error) {
+	var obj map[string]interface{}
+	if err := json.Unmarshal(item, &obj); err != nil {
+		return nil, err
+	}
+	if _, ok := obj["kind"]; ok || kind == "" {
+		return item, nil
+	}
+	obj["apiVersion"] = apiVersion
+	obj["kind"] = kind
+	return json.Marshal(obj)
+}
@@ -0,0 +1,75 @@
+package kube
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func writeRecording(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// A typed List whose items omit apiVersion/kind, a multi-document YAML
+// file and an overlapping recording load into one client; the later
+// recording of an object wins.
+func TestBuildRecordedClient(t *testing.T) {
+	dir := t.TempDir()
+	writeRecording(t, filepath.Join(dir, "a-rolebindings.json"), `{
+  "apiVersion": "rbac.authorization.k8s.io/v1",
+  "kind": "RoleBindingList",
+  "items": [
+    {"metadata": {"name": "view", "namespace": "team-a"}, "roleRef": {"apiGroup": "rbac.authorization.k8s.io", "kind": "ClusterRole", "name": "view"}},
+    {"metadata": {"name": "edit", "namespace": "team-a"}, "roleRef": {"apiGroup": "rbac.authorization.k8s.io", "kind": "ClusterRole", "name": "edit"}}
+  ]
+}`)
+	writeRecording(t, filepath.Join(dir, "b-overlap.yaml"), `apiVersion: rbac.authorization.k8s.io/v1
+kind: RoleBinding
+metadata: {name: view, namespace: team-a}
+roleRef: {apiGroup: rbac.authorization.k8s.io, kind: ClusterRole, name: admin}
+---
+apiVersion: v1
+kind: Namespace
+metadata: {name: team-a}
+`)
+	writeRecording(t, filepath.Join(dir, "notes.txt"), "not a recording")
+	// the OpenAPI recording is not a cluster object
+	writeRecording(t, filepath.Join(dir, recordedOpenAPIDir, "apis__apps__v1.json"), `{"openapi": "3.0.0"}`)
+
+	client, err := BuildRecordedClient(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	rbs, err := client.RbacV1().RoleBindings("team-a").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	refs := map[string]string{}
+	for _, rb := range rbs.Items {
+		refs[rb.Name] = rb.RoleRef.Name
+	}
+	if len(refs) != 2 || refs["view"] != "admin" || refs["edit"] != "edit" {
+		t.Errorf("rolebindings = %v, want view->admin and edit->edit", refs)
+	}
+	if _, err := client.CoreV1().Namespaces().Get(ctx, "team-a", metav1.GetOptions{}); err != nil {
+		t.Errorf("namespace: %v", err)
+	}
+}
+
+func TestBuildRecordedClientDecodeError(t *testing.T) {
+	dir := t.TempDir()
+	writeRecording(t, filepath.Join(dir, "bad.json"), `{"apiVersion": "v1", "kind": "NoSuchKind", "metadata": {"name": "x"}}`)
+	if _, err := BuildRecordedClient(dir); err == nil {
+		t.Fatal("want an error for an unknown kind")
+	}
+}
@@ -0,0 +1,63 @@
+package kube
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/openapi"
+	"k8s.io/client-go/openapi3"
+)
+
+// recordedOpenAPIDir is the subdirectory of a recorded fixture holding
+// OpenAPI v3 documents, named like Kubernetes' api/openapi-spec/v3 tree
+// (e.g. apis__networking.k8s.io__v1_openapi.json).
+const recordedOpenAPIDir = "openapi"
+
+// BuildSchemaSource returns the OpenAPI v3 documents served by the cluster
+// behind kubeconfigPath and context (or direct, when its Host is set).
+// Recorded backends read them from the fixture's openapi/ directory
+// instead.
+func BuildSchemaSource(kubeconfigPath, context string, direct DirectConfig) (openapi3.Root, error) {
+	if dir, ok := strings.CutPrefix(kubeconfigPath, RecordedPrefix); ok {
+		return openapi3.NewRoot(recordedOpenAPI{dir: filepath.Join(dir, recordedOpenAPIDir)}), nil
+	}
+
+	config, err := restConfig(kubeconfigPath, context, direct)
+	if err != nil {
+		return nil, err
+	}
+	dc, err := discovery.NewDiscoveryClientForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("creating discovery client from %s: %w", kubeconfigPath, err)
+	}
+	return openapi3.NewRoot(dc.OpenAPIV3()), nil
+}
+
+type recordedOpenAPI struct {
+	dir string
+}
+
+func (r recordedOpenAPI) Paths() (map[string]openapi.GroupVersion, error) {
+	entries, err := os.ReadDir(r.dir)
+	if err != nil {
+		return nil, fmt.Errorf("reading recorded OpenAPI schemas: %w", err)
+	}
+	paths := map[string]openapi.GroupVersion{}
+	for _, e := range entries {
+		name, ok := strings.CutSuffix(e.Name(), "_openapi.json")
+		if e.IsDir() || !ok {
+			continue
+		}
+		paths[strings.ReplaceAll(name, "__", "/")] = recordedGroupVersion(filepath.Join(r.dir, e.Name()))
+	}
+	return paths, nil
+}
+
+type recordedGroupVersion string
+
+func (p recordedGroupVersion) Schema(contentType string) ([]byte, error) {
+	return os.ReadFile(string(p))
+}
@@ -0,0 +1,37 @@
+package model
+
+// RoleRiskFinding is a point-in-time posture finding about a ClusterRole,
+// reported independently of drift.
+type RoleRiskFinding struct {
+	Source   string   `json:"source"` // "baseline" or "live"
+	Role     string   `json:"role"`
+	Verbs    []string `json:"verbs"`
+	APIGroup string   `json:"apiGroup"`
+	Resource string   `json:"resource"`
+	Reason   string   `json:"reason"`
+	Severity Severity `json:"severity"`
+
+	ComplianceRefs []string `json:"complianceRefs,omitempty"`
+}
+
+// NetPolRiskFinding is a point-in-time posture finding about a
+// NetworkPolicy, reported independently of drift.
+type NetPolRiskFinding struct {
+	Source    string   `json:"source"` // "baseline" or "live"
+	Namespace string   `json:"namespace"`
+	Name      string   `json:"name"`
+	Direction string   `json:"direction"` // "ingress" or "egress"
+	Detail    string   `json:"detail"`
+	Reason    string   `json:"reason"`
+	Severity  Severity `json:"severity"`
+}
+
+// RegoViolation is one result of the -rego policy's deny rule evaluated
+// against a collected snapshot.
+type RegoViolation struct {
+	Source    string   `json:"source"` // "live" (cluster B in cluster-compare mode)
+	Message   string   `json:"message"`
+	Namespace string   `json:"namespace,omitempty"`
+	Name      string   `json:"name,omitempty"`
+	Severity  Severity `json:"severity"`
+}
@@ -0,0 +1,15 @@
+package model
+
+import "fmt"
+
+// SourceRef locates an object in the baseline directory: the file,
+// relative to the directory, and the 1-based position of the object's
+// document within the file.
+type SourceRef struct {
+	File     string `json:"file"`
+	Document int    `json:"document"`
+}
+
+func (r SourceRef) String() string {
+	return fmt.Sprintf("%s, document %d", r.File, r.Document)
+}
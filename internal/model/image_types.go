@@ -0,0 +1,56 @@
+package model
+
+import "strings"
+
+// AllowedRegistriesConfigMap names the baseline ConfigMap that lists the
+// allowed image registries, one per line in data[AllowedRegistriesKey]. An
+// entry may include a path ("ghcr.io/acme") to allow only that prefix.
+const (
+	AllowedRegistriesConfigMap = "driftwatch-allowed-registries"
+	AllowedRegistriesKey       = "registries"
+)
+
+// ImageUsageDigest lists the distinct container images (including init and
+// ephemeral containers) run by Pods in one namespace.
+type ImageUsageDigest struct {
+	Namespace  string   `json:"namespace"`
+	Images     []string `json:"images"`
+	Registries []string `json:"registries"`
+}
+
+// ImageViolation is an image in use whose registry is not allowlisted.
+type ImageViolation struct {
+	Namespace string `json:"namespace"`
+	Image     string `json:"image"`
+	Registry  string `json:"registry"`
+}
+
+// ImageRegistry returns the registry host of an image reference, applying
+// the container runtime's defaulting: a reference without a host
+// ("nginx", "library/nginx") comes from docker.io.
+func ImageRegistry(image string) string {
+	first, _, ok := strings.Cut(image, "/")
+	if !ok || (!strings.ContainsAny(first, ".:") && first != "localhost") {
+		return "docker.io"
+	}
+	return first
+}
+
+// ImageRepository returns the fully qualified repository of an image
+// reference (registry plus path, without tag or digest), e.g.
+// "docker.io/library/nginx" for "nginx:1.27".
+func ImageRepository(image string) string {
+	repo, _, _ := strings.Cut(image, "@")
+	if i := strings.LastIndex(repo, ":"); i > strings.LastIndex(repo, "/") {
+		repo = repo[:i]
+	}
+
+	registry := ImageRegistry(repo)
+	if !strings.HasPrefix(repo, registry+"/") {
+		if registry == "docker.io" && !strings.Contains(repo, "/") {
+			repo = "library/" + repo
+		}
+		repo = registry + "/" + repo
+	}
+	return repo
+}
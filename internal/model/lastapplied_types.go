@@ -0,0 +1,34 @@
+package model
+
+import "fmt"
+
+// LastAppliedAnnotation is where `kubectl apply` records the configuration
+// it last applied to an object.
+const LastAppliedAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// LastAppliedObject pairs a live object with the configuration recorded in
+// its last-applied annotation, both as generic JSON.
+type LastAppliedObject struct {
+	Kind      string
+	Namespace string // "" for cluster-scoped kinds
+	Name      string
+	Applied   map[string]any
+	Live      map[string]any
+}
+
+// LastAppliedDrift is an object whose live state no longer matches its last
+// `kubectl apply`, e.g. after a `kubectl edit`.
+type LastAppliedDrift struct {
+	Source    string   `json:"source"` // "live", "cluster A", "cluster B"
+	Kind      string   `json:"kind"`
+	Namespace string   `json:"namespace,omitempty"`
+	Name      string   `json:"name"`
+	Fields    []string `json:"fields"` // JSON paths that differ, e.g. spec.ingress[0].ports
+}
+
+func (d LastAppliedDrift) String() string {
+	if d.Namespace == "" {
+		return fmt.Sprintf("%s %s", d.Kind, d.Name)
+	}
+	return fmt.Sprintf("%s %s/%s", d.Kind, d.Namespace, d.Name)
+}
@@ -0,0 +1,46 @@
+package model
+
+// LimitRangeDigest captures the per-type constraints of one LimitRange.
+type LimitRangeDigest struct {
+	Namespace string           `json:"namespace"`
+	Name      string           `json:"name"`
+	Limits    []LimitRangeItem `json:"limits"`
+	DefinedIn *SourceRef       `json:"definedIn,omitempty"`
+}
+
+func (d LimitRangeDigest) String() string {
+	return d.Namespace + "/" + d.Name
+}
+
+// LimitRangeItem is one entry of a LimitRange's spec.limits. The maps are
+// keyed by resource name ("cpu", "memory", ...) and hold canonical
+// quantities.
+type LimitRangeItem struct {
+	Type           string            `json:"type"`
+	Default        map[string]string `json:"default,omitempty"`
+	DefaultRequest map[string]string `json:"defaultRequest,omitempty"`
+	Max            map[string]string `json:"max,omitempty"`
+	Min            map[string]string `json:"min,omitempty"`
+}
+
+// LimitRangeChange is a LimitRange present on both sides whose constraints
+// differ. DefinedIn locates the baseline side.
+type LimitRangeChange struct {
+	Namespace string                  `json:"namespace"`
+	Name      string                  `json:"name"`
+	Fields    []LimitRangeFieldChange `json:"fields"`
+	DefinedIn *SourceRef              `json:"definedIn,omitempty"`
+}
+
+// LimitRangeFieldChange is one constraint that differs, e.g. Type
+// "Container", Field "default.cpu". An empty side means it is not set there.
+type LimitRangeFieldChange struct {
+	Type     string `json:"type"`
+	Field    string `json:"field"`
+	Baseline string `json:"baseline"`
+	Live     string `json:"live"`
+}
+
+func (c LimitRangeFieldChange) String() string {
+	return c.Type + " " + c.Field
+}
@@ -5,8 +5,12 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"slices"
+	"sort"
+	"strings"
 
 	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // NetPolDigest is a light-weight normalized representation of a NetworkPolicy.
@@ -17,28 +21,181 @@ type NetPolDigest struct {
 	PolicyTypes  []networkingv1.PolicyType `json:"policyTypes"`
 	IngressCount int                       `json:"ingressCount"`
 	EgressCount  int                       `json:"egressCount"`
+	// Labels holds the policy's metadata labels; they are not part of
+	// SpecHash and are only compared when explicitly requested.
+	Labels map[string]string `json:"labels,omitempty"`
+	// IngressCIDRs / EgressCIDRs are the ipBlock CIDRs allowed by the rules.
+	IngressCIDRs []string `json:"ingressCIDRs,omitempty"`
+	EgressCIDRs  []string `json:"egressCIDRs,omitempty"`
+	// IngressNamespaceSelectors / EgressNamespaceSelectors are the peer
+	// namespaceSelectors used by the rules, kept to join against namespaces.
+	IngressNamespaceSelectors []metav1.LabelSelector `json:"ingressNamespaceSelectors,omitempty"`
+	EgressNamespaceSelectors  []metav1.LabelSelector `json:"egressNamespaceSelectors,omitempty"`
+	// Unstable marks a live policy whose spec changed between the two
+	// reads of -ignore-transient-netpol-changes; its changes are not reported.
+	Unstable bool `json:"unstable,omitempty"`
+	// Spec is the decoded spec with normalized selectors, kept to describe
+	// rule-level changes when the hashes differ. It is not set by
+	// NewNetPolHashDigest (-fast).
+	Spec *networkingv1.NetworkPolicySpec `json:"-"`
+	// DefinedIn locates a baseline policy in the baseline directory; nil
+	// for live policies.
+	DefinedIn *SourceRef `json:"definedIn,omitempty"`
 }
 
-func NewNetPolDigest(np *networkingv1.NetworkPolicy) (NetPolDigest, error) {
-	specBytes, err := json.Marshal(np.Spec)
+// NewNetPolHashDigest builds a digest holding only the identity, labels and
+// spec hash of np; the per-field detail used to describe changes is left
+// empty. It is the lightweight path behind -fast.
+func NewNetPolHashDigest(np *networkingv1.NetworkPolicy) (NetPolDigest, error) {
+	hash, err := NetPolSpecHash(np.Spec)
 	if err != nil {
-		return NetPolDigest{}, fmt.Errorf("marshal NetworkPolicy spec: %w", err)
+		return NetPolDigest{}, err
 	}
-	hash := sha256.Sum256(specBytes)
 
 	return NetPolDigest{
-		Namespace:    np.Namespace,
-		Name:         np.Name,
-		SpecHash:     hex.EncodeToString(hash[:]),
-		PolicyTypes:  np.Spec.PolicyTypes,
-		IngressCount: len(np.Spec.Ingress),
-		EgressCount:  len(np.Spec.Egress),
+		Namespace: np.Namespace,
+		Name:      np.Name,
+		SpecHash:  hash,
+		Labels:    np.Labels,
 	}, nil
 }
 
+// NetPolSpecHash hashes spec after NormalizeNetPolSpec, so specs that differ
+// only in how their selectors are written hash the same.
+func NetPolSpecHash(spec networkingv1.NetworkPolicySpec) (string, error) {
+	specBytes, err := json.Marshal(NormalizeNetPolSpec(spec))
+	if err != nil {
+		return "", fmt.Errorf("marshal NetworkPolicy spec: %w", err)
+	}
+	hash := sha256.Sum256(specBytes)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// NormalizeNetPolSpec returns a copy of spec with every pod and namespace
+// selector in canonical form: matchExpressions values sorted and
+// deduplicated, single-value In expressions folded into matchLabels, the
+// remaining expressions sorted, and empty matchLabels/matchExpressions
+// dropped. The copy selects exactly what spec selects.
+func NormalizeNetPolSpec(spec networkingv1.NetworkPolicySpec) networkingv1.NetworkPolicySpec {
+	out := *spec.DeepCopy()
+	normalizeSelector(&out.PodSelector)
+	peers := func(peers []networkingv1.NetworkPolicyPeer) {
+		for i := range peers {
+			if peers[i].PodSelector != nil {
+				normalizeSelector(peers[i].PodSelector)
+			}
+			if peers[i].NamespaceSelector != nil {
+				normalizeSelector(peers[i].NamespaceSelector)
+			}
+		}
+	}
+	for i := range out.Ingress {
+		peers(out.Ingress[i].From)
+	}
+	for i := range out.Egress {
+		peers(out.Egress[i].To)
+	}
+	return out
+}
+
+func normalizeSelector(s *metav1.LabelSelector) {
+	exprs := make([]metav1.LabelSelectorRequirement, 0, len(s.MatchExpressions))
+	for _, e := range s.MatchExpressions {
+		e.Values = append([]string(nil), e.Values...)
+		sort.Strings(e.Values)
+		e.Values = slices.Compact(e.Values)
+		exprs = append(exprs, e)
+	}
+	// sorted before folding, so which of two single-value In expressions
+	// on one key becomes the label does not depend on their order
+	sort.Slice(exprs, func(i, j int) bool {
+		a, b := exprs[i], exprs[j]
+		if a.Key != b.Key {
+			return a.Key < b.Key
+		}
+		if a.Operator != b.Operator {
+			return a.Operator < b.Operator
+		}
+		return strings.Join(a.Values, ",") < strings.Join(b.Values, ",")
+	})
+
+	var kept []metav1.LabelSelectorRequirement
+	seen := map[string]bool{}
+	for _, e := range exprs {
+		if e.Operator == metav1.LabelSelectorOpIn && len(e.Values) == 1 {
+			v, ok := s.MatchLabels[e.Key]
+			if !ok {
+				if s.MatchLabels == nil {
+					s.MatchLabels = map[string]string{}
+				}
+				s.MatchLabels[e.Key] = e.Values[0]
+				continue
+			}
+			if v == e.Values[0] {
+				continue
+			}
+		}
+
+		id := e.Key + "\x00" + string(e.Operator) + "\x00" + strings.Join(e.Values, "\x00")
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		kept = append(kept, e)
+	}
+	s.MatchExpressions = kept
+	if len(s.MatchLabels) == 0 {
+		s.MatchLabels = nil
+	}
+}
+
+func NewNetPolDigest(np *networkingv1.NetworkPolicy) (NetPolDigest, error) {
+	d, err := NewNetPolHashDigest(np)
+	if err != nil {
+		return NetPolDigest{}, err
+	}
+
+	spec := NormalizeNetPolSpec(np.Spec)
+	var ingressCIDRs, egressCIDRs []string
+	var ingressSelectors, egressSelectors []metav1.LabelSelector
+	for _, rule := range spec.Ingress {
+		for _, peer := range rule.From {
+			if peer.IPBlock != nil {
+				ingressCIDRs = append(ingressCIDRs, peer.IPBlock.CIDR)
+			}
+			if peer.NamespaceSelector != nil {
+				ingressSelectors = append(ingressSelectors, *peer.NamespaceSelector)
+			}
+		}
+	}
+	for _, rule := range spec.Egress {
+		for _, peer := range rule.To {
+			if peer.IPBlock != nil {
+				egressCIDRs = append(egressCIDRs, peer.IPBlock.CIDR)
+			}
+			if peer.NamespaceSelector != nil {
+				egressSelectors = append(egressSelectors, *peer.NamespaceSelector)
+			}
+		}
+	}
+
+	d.PolicyTypes = spec.PolicyTypes
+	d.IngressCount = len(spec.Ingress)
+	d.EgressCount = len(spec.Egress)
+	d.IngressCIDRs = ingressCIDRs
+	d.EgressCIDRs = egressCIDRs
+	d.IngressNamespaceSelectors = ingressSelectors
+	d.EgressNamespaceSelectors = egressSelectors
+	d.Spec = &spec
+	return d, nil
+}
+
 type NetPolRef struct {
-	Namespace string `json:"namespace"`
-	Name      string `json:"name"`
+	Namespace      string   `json:"namespace"`
+	Name           string   `json:"name"`
+	ComplianceRefs []string `json:"complianceRefs,omitempty"`
+	// DefinedIn locates a missing policy in the baseline directory.
+	DefinedIn *SourceRef `json:"definedIn,omitempty"`
 }
 
 func (r NetPolRef) String() string {
@@ -50,8 +207,128 @@ type NetPolChange struct {
 	Name      string       `json:"name"`
 	Baseline  NetPolDigest `json:"baseline"`
 	Live      NetPolDigest `json:"live"`
+	// Rules describes what changed; nil under -fast, where only the spec
+	// hashes are compared.
+	Rules *NetPolRuleDiff `json:"rules,omitempty"`
+}
+
+// NetPolRuleDiff is the structured difference between two versions of a
+// NetworkPolicy spec. Rules are rendered as "from|to [peers] on [ports]"
+// and compared as sets, so reordering rules is not a change.
+type NetPolRuleDiff struct {
+	PodSelector    []NetPolSelectorChange `json:"podSelector,omitempty"`
+	IngressAdded   []string               `json:"ingressAdded,omitempty"`
+	IngressRemoved []string               `json:"ingressRemoved,omitempty"`
+	EgressAdded    []string               `json:"egressAdded,omitempty"`
+	EgressRemoved  []string               `json:"egressRemoved,omitempty"`
+	PortChanges    []NetPolPortChange     `json:"portChanges,omitempty"`
+}
+
+// NetPolSelectorChange is one podSelector matchLabels key whose value
+// differs (an empty value means the key is absent), or Key
+// "matchExpressions" when the expressions differ.
+type NetPolSelectorChange struct {
+	Key      string `json:"key"`
+	Baseline string `json:"baseline"`
+	Live     string `json:"live"`
+}
+
+// NetPolPortChange is a rule whose peers are unchanged but whose ports
+// differ.
+type NetPolPortChange struct {
+	Direction string `json:"direction"` // "ingress" or "egress"
+	Peers     string `json:"peers"`
+	Baseline  string `json:"baseline"`
+	Live      string `json:"live"`
+}
+
+// Empty reports whether the diff found nothing to describe (e.g. only
+// field order or defaulting differed in the marshaled spec).
+func (d NetPolRuleDiff) Empty() bool {
+	return len(d.PodSelector) == 0 &&
+		len(d.IngressAdded) == 0 && len(d.IngressRemoved) == 0 &&
+		len(d.EgressAdded) == 0 && len(d.EgressRemoved) == 0 &&
+		len(d.PortChanges) == 0
 }
 
 type NetPolSnapshot struct {
 	Items map[string]NetPolDigest `json:"-"`
+
+	// Warnings are non-fatal problems found while loading the snapshot
+	// (e.g. a baseline policy declared twice).
+	Warnings []string `json:"-"`
+}
+
+// LabelChange records one compared metadata label whose value differs
+// between baseline and live. An empty value means the label is absent.
+type LabelChange struct {
+	Kind      string `json:"kind"` // "NetworkPolicy" or "Namespace"
+	Namespace string `json:"namespace"`
+	Name      string `json:"name,omitempty"`
+	Key       string `json:"key"`
+	Baseline  string `json:"baseline"`
+	Live      string `json:"live"`
+}
+
+// NetPolOpenness classifies how much traffic one direction (ingress or
+// egress) of a NetworkPolicy admits, from least to most open.
+type NetPolOpenness string
+
+const (
+	// NetPolRestricted admits only the listed peers, or nothing at all (a
+	// policy type without rules is a default deny).
+	NetPolRestricted NetPolOpenness = "restricted"
+	// NetPolAllPeers admits every peer, but only on the listed ports.
+	NetPolAllPeers NetPolOpenness = "all-peers"
+	// NetPolOpen admits every peer on every port.
+	NetPolOpen NetPolOpenness = "open"
+)
+
+// Rank orders openness levels; a higher rank admits more traffic.
+func (o NetPolOpenness) Rank() int {
+	switch o {
+	case NetPolRestricted:
+		return 1
+	case NetPolAllPeers:
+		return 2
+	case NetPolOpen:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// NetPolOpennessChange is one direction of a live NetworkPolicy that admits
+// more than the baseline. DriftType follows the PSA direction semantics:
+//   - "weaker": the baseline policy is more restrictive
+//   - "new":    the policy only exists in live and is not restricted
+type NetPolOpennessChange struct {
+	Namespace string         `json:"namespace"`
+	Name      string         `json:"name"`
+	Direction string         `json:"direction"` // "ingress" or "egress"
+	DriftType string         `json:"driftType"`
+	Baseline  NetPolOpenness `json:"baseline,omitempty"`
+	Live      NetPolOpenness `json:"live"`
+	// DefinedIn locates the baseline policy in the baseline directory.
+	DefinedIn *SourceRef `json:"definedIn,omitempty"`
+}
+
+func (c NetPolOpennessChange) String() string {
+	return fmt.Sprintf("%s/%s", c.Namespace, c.Name)
+}
+
+// DefaultDenyGap is a live namespace without a default-deny NetworkPolicy
+// (empty podSelector, policy type set, no rules) for one direction.
+// DriftType is:
+//   - "lost":   the baseline has a default-deny for the namespace
+//   - "absent": neither the baseline nor live has one
+type DefaultDenyGap struct {
+	Namespace string `json:"namespace"`
+	Direction string `json:"direction"` // "ingress" or "egress"
+	DriftType string `json:"driftType"`
+	// BaselinePolicies names the baseline default-deny policies of a
+	// "lost" gap.
+	BaselinePolicies []string `json:"baselinePolicies,omitempty"`
+	// DefinedIn locates the first of them in the baseline directory.
+	DefinedIn *SourceRef `json:"definedIn,omitempty"`
 }
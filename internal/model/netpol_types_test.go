@@ -0,0 +1,116 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNetPolSpecHashSelectorForms(t *testing.T) {
+	in := func(key string, values ...string) metav1.LabelSelectorRequirement {
+		return metav1.LabelSelectorRequirement{Key: key, Operator: metav1.LabelSelectorOpIn, Values: values}
+	}
+	notIn := func(key string, values ...string) metav1.LabelSelectorRequirement {
+		return metav1.LabelSelectorRequirement{Key: key, Operator: metav1.LabelSelectorOpNotIn, Values: values}
+	}
+	spec := func(sel metav1.LabelSelector) networkingv1.NetworkPolicySpec {
+		return networkingv1.NetworkPolicySpec{PodSelector: sel}
+	}
+
+	tests := []struct {
+		name string
+		a, b metav1.LabelSelector
+		same bool
+	}{
+		{
+			name: "single-value In is a label",
+			a:    metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+			b:    metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{in("app", "web")}},
+			same: true,
+		},
+		{
+			name: "In repeating a label",
+			a:    metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+			b:    metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}, MatchExpressions: []metav1.LabelSelectorRequirement{in("app", "web")}},
+			same: true,
+		},
+		{
+			name: "values order and duplicates",
+			a:    metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{notIn("tier", "b", "a")}},
+			b:    metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{notIn("tier", "a", "b", "a")}},
+			same: true,
+		},
+		{
+			name: "expression order",
+			a:    metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{notIn("tier", "x"), in("app", "a", "b")}},
+			b:    metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{in("app", "b", "a"), notIn("tier", "x")}},
+			same: true,
+		},
+		{
+			name: "conflicting single-value In order",
+			a:    metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{in("app", "a"), in("app", "b")}},
+			b:    metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{in("app", "b"), in("app", "a")}},
+			same: true,
+		},
+		{
+			name: "empty matchLabels",
+			a:    metav1.LabelSelector{MatchLabels: map[string]string{}},
+			b:    metav1.LabelSelector{},
+			same: true,
+		},
+		{
+			name: "In conflicting with a label is kept",
+			a:    metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+			b:    metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}, MatchExpressions: []metav1.LabelSelectorRequirement{in("app", "api")}},
+			same: false,
+		},
+		{
+			name: "different values",
+			a:    metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{in("app", "a", "b")}},
+			b:    metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{in("app", "a", "c")}},
+			same: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ha, err := NetPolSpecHash(spec(tt.a))
+			if err != nil {
+				t.Fatal(err)
+			}
+			hb, err := NetPolSpecHash(spec(tt.b))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if (ha == hb) != tt.same {
+				t.Errorf("same hash = %v, want %v\na: %+v\nb: %+v", ha == hb, tt.same,
+					NormalizeNetPolSpec(spec(tt.a)).PodSelector, NormalizeNetPolSpec(spec(tt.b)).PodSelector)
+			}
+		})
+	}
+}
+
+// Peer selectors are normalized too, and the input spec is left as is.
+func TestNormalizeNetPolSpecPeers(t *testing.T) {
+	sel := &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+		{Key: "team", Operator: metav1.LabelSelectorOpIn, Values: []string{"a"}},
+	}}
+	spec := networkingv1.NetworkPolicySpec{
+		Ingress: []networkingv1.NetworkPolicyIngressRule{{From: []networkingv1.NetworkPolicyPeer{{NamespaceSelector: sel}}}},
+		Egress:  []networkingv1.NetworkPolicyEgressRule{{To: []networkingv1.NetworkPolicyPeer{{PodSelector: sel}}}},
+	}
+	before := *spec.DeepCopy()
+
+	out := NormalizeNetPolSpec(spec)
+	want := &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}}
+	if got := out.Ingress[0].From[0].NamespaceSelector; !reflect.DeepEqual(got, want) {
+		t.Errorf("ingress namespaceSelector = %+v, want %+v", got, want)
+	}
+	if got := out.Egress[0].To[0].PodSelector; !reflect.DeepEqual(got, want) {
+		t.Errorf("egress podSelector = %+v, want %+v", got, want)
+	}
+	if !reflect.DeepEqual(spec, before) {
+		t.Errorf("input spec modified: %+v", spec)
+	}
+}
@@ -17,6 +17,19 @@ type NamespacePSA struct {
 	Enforce   PSALevel `json:"enforce,omitempty"`
 	Audit     PSALevel `json:"audit,omitempty"`
 	Warn      PSALevel `json:"warn,omitempty"`
+	// Labels holds all namespace labels for optional label drift checks.
+	Labels map[string]string `json:"labels,omitempty"`
+	// Annotations holds all namespace annotations for the optional
+	// managed-by check.
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// DefinedIn locates a baseline namespace in the baseline directory;
+	// nil for live namespaces.
+	DefinedIn *SourceRef `json:"definedIn,omitempty"`
+}
+
+// HasPSALabels reports whether any PSA mode label is set.
+func (n NamespacePSA) HasPSALabels() bool {
+	return n.Enforce != "" || n.Audit != "" || n.Warn != ""
 }
 
 func (n NamespacePSA) String() string {
@@ -29,6 +42,35 @@ type PSADriftEntry struct {
 	Namespace string   `json:"namespace"`
 	Baseline  PSALevel `json:"baseline,omitempty"`
 	Live      PSALevel `json:"live,omitempty"`
-	// DriftType: "extra", "missing", "weaker", "stronger", "different"
+	// DriftType: "extra", "missing", "weaker", "stronger", "different",
+	// "exempted"
 	DriftType string `json:"driftType"`
+	// Exempt is set when the live namespace is listed in the API server's
+	// PSA exemptions, i.e. it is effectively privileged whatever its labels.
+	Exempt bool `json:"exempt,omitempty"`
+
+	ComplianceRefs []string `json:"complianceRefs,omitempty"`
+	// DefinedIn locates the baseline namespace, when there is one.
+	DefinedIn *SourceRef `json:"definedIn,omitempty"`
+}
+
+// PSAManagedByDrift flags a live namespace carrying PSA labels whose
+// managed-by annotation is absent or not the expected value, i.e. the
+// labels were likely set by hand rather than by the policy controller.
+type PSAManagedByDrift struct {
+	Namespace  string `json:"namespace"`
+	Annotation string `json:"annotation"`
+	Expected   string `json:"expected,omitempty"`
+	Live       string `json:"live,omitempty"`
+	Reason     string `json:"reason"` // "absent" or "wrong"
+	// DefinedIn locates the baseline namespace, when there is one.
+	DefinedIn *SourceRef `json:"definedIn,omitempty"`
+}
+
+// PSAExemptions mirrors the exemptions block of the API server's
+// PodSecurity admission configuration.
+type PSAExemptions struct {
+	Usernames      []string `json:"usernames,omitempty"`
+	RuntimeClasses []string `json:"runtimeClasses,omitempty"`
+	Namespaces     []string `json:"namespaces,omitempty"`
 }
@@ -0,0 +1,32 @@
+package model
+
+// ResourceQuotaDigest captures the hard limits of one ResourceQuota, keyed
+// by resource name ("requests.cpu", "pods", ...). Quantities are in
+// canonical form, so "1000m" and "1" compare equal.
+type ResourceQuotaDigest struct {
+	Namespace string            `json:"namespace"`
+	Name      string            `json:"name"`
+	Hard      map[string]string `json:"hard"`
+	DefinedIn *SourceRef        `json:"definedIn,omitempty"`
+}
+
+func (d ResourceQuotaDigest) String() string {
+	return d.Namespace + "/" + d.Name
+}
+
+// ResourceQuotaChange is a ResourceQuota present on both sides whose hard
+// limits differ. DefinedIn locates the baseline side.
+type ResourceQuotaChange struct {
+	Namespace string             `json:"namespace"`
+	Name      string             `json:"name"`
+	Limits    []QuotaLimitChange `json:"limits"`
+	DefinedIn *SourceRef         `json:"definedIn,omitempty"`
+}
+
+// QuotaLimitChange is one hard limit that differs; an empty side means the
+// limit is not set there.
+type QuotaLimitChange struct {
+	Resource string `json:"resource"`
+	Baseline string `json:"baseline"`
+	Live     string `json:"live"`
+}
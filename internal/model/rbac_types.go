@@ -2,6 +2,9 @@ package model
 
 import (
 	"fmt"
+	"slices"
+	"sort"
+	"strings"
 
 	rbacv1 "k8s.io/api/rbac/v1"
 )
@@ -23,12 +26,114 @@ func (s SubjectKey) String() string {
 	return fmt.Sprintf("%s %s", s.Kind, s.Name)
 }
 
+// RoleRef identifies a bound role. Namespace is the binding's namespace for
+// RoleBindings and "" for ClusterRoleBindings.
+type RoleRef struct {
+	Kind      string `json:"kind"` // "Role" or "ClusterRole"
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+func (r RoleRef) String() string {
+	switch {
+	case r.Kind == "Role":
+		return fmt.Sprintf("Role %s/%s", r.Namespace, r.Name)
+	case r.Namespace != "":
+		return fmt.Sprintf("ClusterRole %s [ns=%s]", r.Name, r.Namespace)
+	default:
+		return fmt.Sprintf("ClusterRole %s [cluster-wide]", r.Name)
+	}
+}
+
+// BindingChange lists subjects added to / removed from a role reference
+// between baseline and live.
+type BindingChange struct {
+	Role    RoleRef      `json:"role"`
+	Added   []SubjectKey `json:"added,omitempty"`
+	Removed []SubjectKey `json:"removed,omitempty"`
+}
+
+// DanglingBinding is a RoleBinding or ClusterRoleBinding whose roleRef
+// names a Role or ClusterRole that does not exist. It grants nothing
+// today, but creating a role of that name silently grants it to the
+// subjects.
+type DanglingBinding struct {
+	// Source is "baseline" or "live" ("cluster A"/"cluster B" are reported
+	// as baseline/live, like the audits).
+	Source    string       `json:"source"`
+	Kind      string       `json:"kind"` // "RoleBinding" or "ClusterRoleBinding"
+	Namespace string       `json:"namespace,omitempty"`
+	Name      string       `json:"name"`
+	Role      RoleRef      `json:"role"`
+	Subjects  []SubjectKey `json:"subjects"`
+	// DefinedIn locates a baseline binding in the baseline directory.
+	DefinedIn []SourceRef `json:"definedIn,omitempty"`
+}
+
+// ClusterRoleGrant is one subject bound to a ClusterRole, either
+// cluster-wide (Namespace "") or through a RoleBinding in Namespace.
+type ClusterRoleGrant struct {
+	Subject   SubjectKey `json:"subject"`
+	Namespace string     `json:"namespace,omitempty"`
+}
+
+func (g ClusterRoleGrant) String() string {
+	if g.Namespace == "" {
+		return g.Subject.String() + " [cluster-wide]"
+	}
+	return fmt.Sprintf("%s [ns=%s]", g.Subject, g.Namespace)
+}
+
+// ClusterRoleSubjects lists, for one ClusterRole, every subject granted it
+// in baseline and live, and which grants were added or removed.
+type ClusterRoleSubjects struct {
+	Role     string             `json:"role"`
+	Baseline []ClusterRoleGrant `json:"baseline"`
+	Live     []ClusterRoleGrant `json:"live"`
+	Added    []ClusterRoleGrant `json:"added,omitempty"`
+	Removed  []ClusterRoleGrant `json:"removed,omitempty"`
+	Drifted  bool               `json:"drifted"`
+}
+
+// SubjectRename pairs a subject that disappeared from live with a new
+// subject of the same kind holding exactly the same permissions, e.g. a
+// ServiceAccount recreated under a new name during rotation.
+type SubjectRename struct {
+	From        SubjectKey   `json:"from"`
+	To          SubjectKey   `json:"to"`
+	Permissions []Permission `json:"permissions"`
+}
+
+// ResourceNameChange describes a grant (subject, scope, verb, resource)
+// that names specific resources in both baseline and live but with a
+// different set of names.
+type ResourceNameChange struct {
+	Subject        SubjectKey `json:"subject"`
+	ScopeNamespace string     `json:"scopeNamespace"`
+	APIGroup       string     `json:"apiGroup"`
+	Resource       string     `json:"resource"`
+	Verb           string     `json:"verb"`
+	Added          []string   `json:"added,omitempty"`
+	Removed        []string   `json:"removed,omitempty"`
+	Unchanged      []string   `json:"unchanged,omitempty"`
+}
+
+// Grant returns the changed grant as a Permission without a resourceName.
+func (c ResourceNameChange) Grant() Permission {
+	return Permission{
+		ScopeNamespace: c.ScopeNamespace,
+		APIGroup:       c.APIGroup,
+		Resource:       c.Resource,
+		Verb:           c.Verb,
+	}
+}
+
 // Permission represents one effective permission a subject has.
 type Permission struct {
 	ScopeNamespace string `json:"scopeNamespace"`           // "*" for cluster-wide, or specific namespace
 	APIGroup       string `json:"apiGroup"`                 // e.g. "", "apps"
 	Resource       string `json:"resource"`                 // e.g. "pods", "deployments"
-	ResourceName   string `json:"resourceName"`             // name or "*" if not constrained
+	ResourceName   string `json:"resourceName"`             // name, or "" if not constrained
 	Verb           string `json:"verb"`                     // e.g. "get", "list", "watch"
 	NonResourceURL string `json:"nonResourceUrl,omitempty"` // if NonResourceURL rule
 }
@@ -50,17 +155,84 @@ func (p Permission) String() string {
 	}
 
 	rn := p.ResourceName
-	if rn == "" {
+	switch rn {
+	case "":
 		rn = "*"
+	case "*":
+		// a literal name, not the unconstrained "*" above
+		rn = `"*"`
 	}
 
 	return fmt.Sprintf("%s verb=%s resource=%s.%s resourceName=%s",
 		scope, p.Verb, p.Resource, group, rn)
 }
 
+// Covers reports whether p grants everything other grants, honoring the
+// RBAC wildcards: "*" as verb, apiGroup or resource, "*/sub" for a
+// subresource of any resource, a trailing "*" on a nonResourceURL, and a
+// cluster-wide scope covering every namespace. An unconstrained p (no
+// ResourceName) covers every name; resourceNames has no wildcard, so a
+// ResourceName of "*" covers only the object literally named "*".
+func (p Permission) Covers(other Permission) bool {
+	if p.ScopeNamespace != "*" && p.ScopeNamespace != other.ScopeNamespace {
+		return false
+	}
+	if p.Verb != "*" && p.Verb != other.Verb {
+		return false
+	}
+	if p.NonResourceURL != "" || other.NonResourceURL != "" {
+		if p.NonResourceURL == "" || other.NonResourceURL == "" {
+			return false
+		}
+		if prefix, ok := strings.CutSuffix(p.NonResourceURL, "*"); ok {
+			return strings.HasPrefix(other.NonResourceURL, prefix)
+		}
+		return p.NonResourceURL == other.NonResourceURL
+	}
+	if p.APIGroup != "*" && p.APIGroup != other.APIGroup {
+		return false
+	}
+	if !resourceCovers(p.Resource, other.Resource) {
+		return false
+	}
+	return p.ResourceName == "" || p.ResourceName == other.ResourceName
+}
+
+func resourceCovers(rule, resource string) bool {
+	if rule == "*" || rule == resource {
+		return true
+	}
+	sub, ok := strings.CutPrefix(rule, "*/")
+	if !ok {
+		return false
+	}
+	_, resourceSub, hasSub := strings.Cut(resource, "/")
+	return hasSub && (sub == "*" || sub == resourceSub)
+}
+
 // RBACSnapshot is a normalized view of effective permissions per subject.
 type RBACSnapshot struct {
 	Subjects map[SubjectKey]map[Permission]struct{}
+
+	// ClusterRoles retains the raw rules per ClusterRole name for posture
+	// checks that look at roles regardless of who is bound to them.
+	ClusterRoles map[string][]rbacv1.PolicyRule
+
+	// Bindings records which subjects are bound to each role reference,
+	// independent of what the role grants.
+	Bindings map[RoleRef]map[SubjectKey]struct{}
+
+	// Warnings are non-fatal problems found while building the snapshot
+	// (e.g. circular ClusterRole aggregation).
+	Warnings []string
+
+	// DefinedIn records, for snapshots read from a baseline directory,
+	// where the role and binding granting each permission are declared.
+	DefinedIn map[SubjectKey]map[Permission][]SourceRef
+
+	// Dangling lists the bindings whose role does not exist, and which
+	// therefore contribute no permissions.
+	Dangling []DanglingBinding
 }
 
 // AddPermissions merges the given permissions into the snapshot for the subject.
@@ -81,7 +253,101 @@ func (s *RBACSnapshot) AddPermissions(subj SubjectKey, perms []Permission) {
 	}
 }
 
-// SubjectKeyFromRBACSubject converts an RBAC Subject to our SubjectKey.
+// AddDefinedIn records refs as declaring each of subj's perms.
+func (s *RBACSnapshot) AddDefinedIn(subj SubjectKey, perms []Permission, refs []SourceRef) {
+	if len(refs) == 0 {
+		return
+	}
+	if s.DefinedIn == nil {
+		s.DefinedIn = make(map[SubjectKey]map[Permission][]SourceRef)
+	}
+	if s.DefinedIn[subj] == nil {
+		s.DefinedIn[subj] = make(map[Permission][]SourceRef)
+	}
+	for _, p := range perms {
+		for _, ref := range refs {
+			if !slices.Contains(s.DefinedIn[subj][p], ref) {
+				s.DefinedIn[subj][p] = append(s.DefinedIn[subj][p], ref)
+			}
+		}
+	}
+}
+
+// AddBinding records that subj is bound to role.
+func (s *RBACSnapshot) AddBinding(role RoleRef, subj SubjectKey) {
+	if s.Bindings == nil {
+		s.Bindings = make(map[RoleRef]map[SubjectKey]struct{})
+	}
+	if s.Bindings[role] == nil {
+		s.Bindings[role] = make(map[SubjectKey]struct{})
+	}
+	s.Bindings[role][subj] = struct{}{}
+}
+
+// ExpandGroups grants every User in userGroups the permissions held by the
+// Group subjects they belong to, so drift shows up on concrete users and not
+// only on the group. Group subjects are kept as-is.
+func (s *RBACSnapshot) ExpandGroups(userGroups map[string][]string) {
+	for user, groups := range userGroups {
+		userKey := SubjectKey{Kind: "User", Name: user}
+		for _, g := range groups {
+			permSet := s.Subjects[SubjectKey{Kind: "Group", Name: g}]
+			if len(permSet) == 0 {
+				continue
+			}
+			perms := make([]Permission, 0, len(permSet))
+			for p := range permSet {
+				perms = append(perms, p)
+			}
+			s.AddPermissions(userKey, perms)
+		}
+	}
+}
+
+// knownVerbs is the set of verbs understood by the Kubernetes authorizer for
+// built-in resources (plus "*").
+var knownVerbs = map[string]struct{}{
+	"*": {}, "get": {}, "list": {}, "watch": {}, "create": {}, "update": {},
+	"patch": {}, "delete": {}, "deletecollection": {}, "use": {}, "bind": {},
+	"escalate": {}, "impersonate": {}, "approve": {}, "sign": {}, "proxy": {},
+	// non-resource URL verbs
+	"head": {}, "post": {}, "put": {}, "options": {},
+}
+
+// NormalizeVerbs lowercases every permission verb in the snapshot and records
+// a warning for verbs outside the well-known set, which in hand-written
+// baselines are usually typos that would otherwise show up as phantom drift.
+func (s *RBACSnapshot) NormalizeVerbs() {
+	unknown := map[string]struct{}{}
+	for subj, permSet := range s.Subjects {
+		normalized := make(map[Permission]struct{}, len(permSet))
+		for p := range permSet {
+			p.Verb = strings.ToLower(p.Verb)
+			if _, ok := knownVerbs[p.Verb]; !ok {
+				unknown[p.Verb] = struct{}{}
+			}
+			normalized[p] = struct{}{}
+		}
+		s.Subjects[subj] = normalized
+	}
+
+	verbs := make([]string, 0, len(unknown))
+	for v := range unknown {
+		verbs = append(verbs, v)
+	}
+	sort.Strings(verbs)
+	for _, v := range verbs {
+		s.Warnings = append(s.Warnings, fmt.Sprintf("unknown RBAC verb %q (possible typo)", v))
+	}
+}
+
+// SubjectKeyFromRBACSubject converts an RBAC Subject to our SubjectKey. A
+// ServiceAccount subject without a namespace takes defaultNamespace, the
+// namespace of its RoleBinding. A ClusterRoleBinding has no namespace to
+// default to (and the API server rejects such subjects), so the RBAC
+// collectors drop namespace-less ServiceAccount subjects of
+// ClusterRoleBindings with a snapshot warning instead of keying them here;
+// a ServiceAccount SubjectKey therefore always has a namespace.
 func SubjectKeyFromRBACSubject(subj rbacv1.Subject, defaultNamespace string) SubjectKey {
 	ns := subj.Namespace
 	if ns == "" && subj.Kind == "ServiceAccount" {
@@ -97,6 +363,8 @@ func SubjectKeyFromRBACSubject(subj rbacv1.Subject, defaultNamespace string) Sub
 // ExpandPolicyRulesToPermissions converts PolicyRule objects into flat Permission entries.
 // bindingNamespace is the namespace of the RoleBinding (if any).
 // clusterScope should be true when rules are bound via a ClusterRoleBinding.
+// The rules are normalized first (see NormalizePolicyRules), so duplicate
+// rules are expanded once.
 func ExpandPolicyRulesToPermissions(
 	rules []rbacv1.PolicyRule,
 	bindingNamespace string,
@@ -104,7 +372,7 @@ func ExpandPolicyRulesToPermissions(
 ) []Permission {
 	var out []Permission
 
-	for _, rule := range rules {
+	for _, rule := range NormalizePolicyRules(rules) {
 		// Non-resource URLs
 		if len(rule.NonResourceURLs) > 0 {
 			scope := "*"
@@ -132,7 +400,7 @@ func ExpandPolicyRulesToPermissions(
 
 		resourceNames := rule.ResourceNames
 		if len(resourceNames) == 0 {
-			resourceNames = []string{"*"}
+			resourceNames = []string{""}
 		}
 
 		scope := "*"
@@ -159,3 +427,49 @@ func ExpandPolicyRulesToPermissions(
 
 	return out
 }
+
+// NormalizePolicyRules returns rules without duplicate entries: every list
+// of a rule is sorted and deduplicated, and rules on the same API groups,
+// resources, resourceNames and non-resource URLs are merged into one with
+// the union of their verbs. Nothing else is dropped; a rule another rule
+// covers is kept, so each permission it grants is still compared on its
+// own.
+func NormalizePolicyRules(rules []rbacv1.PolicyRule) []rbacv1.PolicyRule {
+	var out []rbacv1.PolicyRule
+	byTarget := map[string]int{}
+	for _, rule := range rules {
+		r := rbacv1.PolicyRule{
+			Verbs:           normalizeRuleList(rule.Verbs),
+			APIGroups:       normalizeRuleList(rule.APIGroups),
+			Resources:       normalizeRuleList(rule.Resources),
+			ResourceNames:   normalizeRuleList(rule.ResourceNames),
+			NonResourceURLs: normalizeRuleList(rule.NonResourceURLs),
+		}
+		if len(r.Verbs) == 0 {
+			continue
+		}
+		target := strings.Join([]string{
+			strings.Join(r.APIGroups, ","),
+			strings.Join(r.Resources, ","),
+			strings.Join(r.ResourceNames, ","),
+			strings.Join(r.NonResourceURLs, ","),
+		}, "\x00")
+		if i, ok := byTarget[target]; ok {
+			out[i].Verbs = normalizeRuleList(append(out[i].Verbs, r.Verbs...))
+			continue
+		}
+		byTarget[target] = len(out)
+		out = append(out, r)
+	}
+	return out
+}
+
+// normalizeRuleList sorts and deduplicates a PolicyRule list.
+func normalizeRuleList(list []string) []string {
+	if len(list) == 0 {
+		return nil
+	}
+	out := slices.Clone(list)
+	sort.Strings(out)
+	return slices.Compact(out)
+}
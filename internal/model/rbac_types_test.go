@@ -0,0 +1,136 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+func TestNormalizePolicyRules(t *testing.T) {
+	secrets := func(verbs []string, names ...string) rbacv1.PolicyRule {
+		return rbacv1.PolicyRule{Verbs: verbs, APIGroups: []string{""}, Resources: []string{"secrets"}, ResourceNames: names}
+	}
+	get := []string{"get"}
+
+	tests := []struct {
+		name  string
+		rules []rbacv1.PolicyRule
+		want  []rbacv1.PolicyRule
+	}{
+		{
+			// resourceNames has no wildcard: "*" is a literal name
+			name:  "literal star name keeps named rule",
+			rules: []rbacv1.PolicyRule{secrets(get, "*"), secrets(get, "db-password")},
+			want:  []rbacv1.PolicyRule{secrets(get, "*"), secrets(get, "db-password")},
+		},
+		{
+			name:  "names that contain each other keep both",
+			rules: []rbacv1.PolicyRule{secrets(get, "*"), secrets(get, "x", "*")},
+			want:  []rbacv1.PolicyRule{secrets(get, "*"), secrets(get, "*", "x")},
+		},
+		{
+			name:  "exact duplicates keep one",
+			rules: []rbacv1.PolicyRule{secrets(get, "a"), secrets(get, "a", "a")},
+			want:  []rbacv1.PolicyRule{secrets(get, "a")},
+		},
+		{
+			name:  "verbs merged on the same target",
+			rules: []rbacv1.PolicyRule{secrets([]string{"list", "get"}), secrets([]string{"watch", "get"})},
+			want:  []rbacv1.PolicyRule{secrets([]string{"get", "list", "watch"})},
+		},
+		{
+			name:  "verb wildcard merged with enumerated verbs keeps one rule",
+			rules: []rbacv1.PolicyRule{secrets([]string{"*"}), secrets([]string{"get", "*"})},
+			want:  []rbacv1.PolicyRule{secrets([]string{"*", "get"})},
+		},
+		{
+			name: "broader rule does not drop narrower one",
+			rules: []rbacv1.PolicyRule{
+				{Verbs: get, APIGroups: []string{""}, Resources: []string{"*"}},
+				secrets(get),
+			},
+			want: []rbacv1.PolicyRule{
+				{Verbs: get, APIGroups: []string{""}, Resources: []string{"*"}},
+				secrets(get),
+			},
+		},
+		{
+			name:  "rule without verbs dropped",
+			rules: []rbacv1.PolicyRule{secrets(nil), secrets(get)},
+			want:  []rbacv1.PolicyRule{secrets(get)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizePolicyRules(tt.rules); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NormalizePolicyRules = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// Every permission a role grants is expanded, including those a broader
+// rule of the same role also grants.
+func TestExpandPolicyRulesKeepsCoveredPermissions(t *testing.T) {
+	rules := []rbacv1.PolicyRule{
+		{Verbs: []string{"get"}, APIGroups: []string{""}, Resources: []string{"*"}},
+		{Verbs: []string{"get"}, APIGroups: []string{""}, Resources: []string{"secrets"}, ResourceNames: []string{"db-password"}},
+	}
+	got := map[Permission]bool{}
+	for _, p := range ExpandPolicyRulesToPermissions(rules, "team-a", false) {
+		got[p] = true
+	}
+	named := Permission{ScopeNamespace: "team-a", Resource: "secrets", ResourceName: "db-password", Verb: "get"}
+	if len(got) != 2 || !got[named] {
+		t.Errorf("permissions = %v, want the resources=* grant and %v", got, named)
+	}
+}
+
+func TestPermissionCovers(t *testing.T) {
+	secret := func(name string) Permission {
+		return Permission{ScopeNamespace: "team-a", Resource: "secrets", Verb: "get", ResourceName: name}
+	}
+	tests := []struct {
+		name string
+		p, q Permission
+		want bool
+	}{
+		{"unconstrained covers a name", secret(""), secret("foo"), true},
+		{"literal star does not cover a name", secret("*"), secret("foo"), false},
+		{"literal star covers itself", secret("*"), secret("*"), true},
+		{"name does not cover unconstrained", secret("foo"), secret(""), false},
+		{"verb wildcard", Permission{ScopeNamespace: "team-a", Resource: "secrets", Verb: "*"}, secret("foo"), true},
+		{"resource wildcard", Permission{ScopeNamespace: "team-a", Resource: "*", Verb: "get"}, secret(""), true},
+		{"subresource wildcard", Permission{ScopeNamespace: "*", Resource: "*/status", Verb: "get"},
+			Permission{ScopeNamespace: "team-a", Resource: "pods/status", Verb: "get"}, true},
+		{"cluster-wide covers a namespace", Permission{ScopeNamespace: "*", Resource: "secrets", Verb: "get"}, secret(""), true},
+		{"namespace does not cover another", secret(""), Permission{ScopeNamespace: "team-b", Resource: "secrets", Verb: "get"}, false},
+		{"nonResourceURL prefix", Permission{ScopeNamespace: "*", Verb: "get", NonResourceURL: "/healthz*"},
+			Permission{ScopeNamespace: "*", Verb: "get", NonResourceURL: "/healthz/ready"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.p.Covers(tt.q); got != tt.want {
+				t.Errorf("%v covers %v = %v, want %v", tt.p, tt.q, got, tt.want)
+			}
+		})
+	}
+}
+
+// A rule without resourceNames expands to an unconstrained permission; a
+// literal "*" name stays a name.
+func TestExpandPolicyRulesResourceNames(t *testing.T) {
+	rules := []rbacv1.PolicyRule{
+		{Verbs: []string{"get"}, APIGroups: []string{""}, Resources: []string{"secrets"}},
+		{Verbs: []string{"list"}, APIGroups: []string{""}, Resources: []string{"secrets"}, ResourceNames: []string{"*"}},
+	}
+	perms := ExpandPolicyRulesToPermissions(rules, "team-a", false)
+	want := []Permission{
+		{ScopeNamespace: "team-a", Resource: "secrets", Verb: "get"},
+		{ScopeNamespace: "team-a", Resource: "secrets", ResourceName: "*", Verb: "list"},
+	}
+	if !reflect.DeepEqual(perms, want) {
+		t.Errorf("permissions = %v, want %v", perms, want)
+	}
+}
@@ -0,0 +1,61 @@
+package model
+
+import "fmt"
+
+// ServiceAccountDigest captures the ServiceAccount fields driftwatch uses.
+type ServiceAccountDigest struct {
+	Namespace   string            `json:"namespace"`
+	Name        string            `json:"name"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// AutomountToken is automountServiceAccountToken; nil means the
+	// default, which mounts the token.
+	AutomountToken   *bool    `json:"automountServiceAccountToken,omitempty"`
+	ImagePullSecrets []string `json:"imagePullSecrets,omitempty"`
+	Secrets          []string `json:"secrets,omitempty"`
+	// DefinedIn locates a baseline ServiceAccount in the baseline
+	// directory; nil for live ones.
+	DefinedIn *SourceRef `json:"definedIn,omitempty"`
+}
+
+func (s ServiceAccountDigest) String() string {
+	return fmt.Sprintf("%s/%s", s.Namespace, s.Name)
+}
+
+// SubjectKey returns the RBAC subject key for this ServiceAccount.
+func (s ServiceAccountDigest) SubjectKey() SubjectKey {
+	return SubjectKey{Kind: "ServiceAccount", Name: s.Name, Namespace: s.Namespace}
+}
+
+// MountsToken reports whether pods using the ServiceAccount get its API
+// token mounted unless they opt out.
+func (s ServiceAccountDigest) MountsToken() bool {
+	return s.AutomountToken == nil || *s.AutomountToken
+}
+
+// ServiceAccountDriftEntry is one baseline ServiceAccount that differs in
+// live. DriftType follows the PSA direction semantics:
+//   - "weaker":   live mounts the API token where the baseline does not
+//   - "stronger": live no longer mounts a token the baseline mounts
+//   - "changed":  only the attached secrets differ
+//   - "missing":  the ServiceAccount does not exist in live
+//
+// The secret deltas are filled in for every type but "missing".
+type ServiceAccountDriftEntry struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	DriftType string `json:"driftType"`
+
+	BaselineMountsToken bool `json:"baselineMountsToken"`
+	LiveMountsToken     bool `json:"liveMountsToken"`
+
+	ImagePullSecretsAdded   []string `json:"imagePullSecretsAdded,omitempty"`
+	ImagePullSecretsRemoved []string `json:"imagePullSecretsRemoved,omitempty"`
+	SecretsAdded            []string `json:"secretsAdded,omitempty"`
+	SecretsRemoved          []string `json:"secretsRemoved,omitempty"`
+
+	DefinedIn *SourceRef `json:"definedIn,omitempty"`
+}
+
+func (e ServiceAccountDriftEntry) String() string {
+	return fmt.Sprintf("%s/%s", e.Namespace, e.Name)
+}
@@ -0,0 +1,121 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Severity ranks how concerning a finding is.
+type Severity string
+
+const (
+	SeverityCritical Severity = "critical"
+	SeverityHigh     Severity = "high"
+	SeverityMedium   Severity = "medium"
+	SeverityLow      Severity = "low"
+)
+
+// Rank returns a comparable weight (higher = more severe). Unknown values rank 0.
+func (s Severity) Rank() int {
+	switch s {
+	case SeverityCritical:
+		return 4
+	case SeverityHigh:
+		return 3
+	case SeverityMedium:
+		return 2
+	case SeverityLow:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// severityLevels lists the severities from least to most severe.
+var severityLevels = []Severity{SeverityLow, SeverityMedium, SeverityHigh, SeverityCritical}
+
+// Shift moves s up (or, when negative, down) by levels, clamped to
+// low..critical. Unknown severities are returned unchanged.
+func (s Severity) Shift(levels int) Severity {
+	r := s.Rank()
+	if r == 0 || levels == 0 {
+		return s
+	}
+	r += levels
+	r = max(1, min(r, len(severityLevels)))
+	return severityLevels[r-1]
+}
+
+// SeverityConfig is the -severity-config file.
+type SeverityConfig struct {
+	// SubjectKindBoost shifts the severity of RBAC findings by subject
+	// kind, e.g. {User: 1, Group: 1} ranks a User gaining a permission one
+	// level above a ServiceAccount gaining the same one.
+	SubjectKindBoost map[string]int `json:"subjectKindBoost"`
+}
+
+// ClassifySubjectPermission is ClassifyPermission shifted by the
+// SubjectKindBoost configured for the subject's kind.
+func (c SeverityConfig) ClassifySubjectPermission(subj SubjectKey, p Permission) Severity {
+	return ClassifyPermission(p).Shift(c.SubjectKindBoost[subj.Kind])
+}
+
+// ParseSeverity converts a user-supplied string into a Severity.
+func ParseSeverity(s string) (Severity, error) {
+	sev := Severity(strings.ToLower(strings.TrimSpace(s)))
+	if sev.Rank() == 0 {
+		return "", fmt.Errorf("unknown severity %q (supported: critical, high, medium, low)", s)
+	}
+	return sev, nil
+}
+
+// ClassifyPermission assigns a severity to a single RBAC permission based on
+// how much blast radius it carries.
+func ClassifyPermission(p Permission) Severity {
+	clusterWide := p.ScopeNamespace == "*" || p.ScopeNamespace == ""
+
+	if p.NonResourceURL != "" {
+		if p.Verb == "*" || p.NonResourceURL == "*" {
+			return SeverityMedium
+		}
+		return SeverityLow
+	}
+
+	wildcard := p.Verb == "*" || p.Resource == "*" || p.APIGroup == "*"
+	switch {
+	case wildcard && clusterWide:
+		return SeverityCritical
+	case p.Resource == "secrets" && clusterWide:
+		return SeverityCritical
+	case isEscalationVerb(p.Verb) && clusterWide:
+		return SeverityCritical
+	case wildcard, p.Resource == "secrets", isEscalationVerb(p.Verb):
+		return SeverityHigh
+	case clusterWide && isWriteVerb(p.Verb):
+		return SeverityHigh
+	case isWriteVerb(p.Verb):
+		return SeverityMedium
+	default:
+		return SeverityLow
+	}
+}
+
+// isEscalationVerb reports whether verb lets a subject gain permissions it
+// does not hold: binding or escalating roles, or impersonating others.
+func isEscalationVerb(verb string) bool {
+	switch verb {
+	case "escalate", "bind", "impersonate":
+		return true
+	default:
+		return false
+	}
+}
+
+func isWriteVerb(verb string) bool {
+	switch verb {
+	case "create", "update", "patch", "delete", "deletecollection":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,27 @@
+package model
+
+// DefaultStorageClassAnnotation marks the cluster's default StorageClass.
+// The beta key is still honored by the API server.
+const (
+	DefaultStorageClassAnnotation     = "storageclass.kubernetes.io/is-default-class"
+	DefaultStorageClassAnnotationBeta = "storageclass.beta.kubernetes.io/is-default-class"
+)
+
+// StorageClassDigest captures the StorageClass fields that change how new
+// PVCs are provisioned.
+type StorageClassDigest struct {
+	Name          string `json:"name"`
+	Provisioner   string `json:"provisioner"`
+	ReclaimPolicy string `json:"reclaimPolicy"`
+	IsDefault     bool   `json:"isDefault"`
+}
+
+// StorageClassChange is a StorageClass present on both sides with differing
+// fields. DefaultChanged flags an is-default-class flip specifically, since
+// it silently affects every PVC without an explicit storageClassName.
+type StorageClassChange struct {
+	Name           string             `json:"name"`
+	Baseline       StorageClassDigest `json:"baseline"`
+	Live           StorageClassDigest `json:"live"`
+	DefaultChanged bool               `json:"defaultChanged"`
+}
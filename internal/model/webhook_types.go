@@ -0,0 +1,116 @@
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Kinds of admission webhook configuration.
+const (
+	ValidatingWebhookConfigurationKind = "ValidatingWebhookConfiguration"
+	MutatingWebhookConfigurationKind   = "MutatingWebhookConfiguration"
+)
+
+// WebhookDigest is one webhook of a Validating- or
+// MutatingWebhookConfiguration, reduced to the fields that decide which
+// requests it sees and what happens when it is unreachable.
+type WebhookDigest struct {
+	Kind          string `json:"kind"`
+	Configuration string `json:"configuration"`
+	Name          string `json:"name"`
+	FailurePolicy string `json:"failurePolicy"`
+	// Rules are the rendered rules ("CREATE,UPDATE groups=apps
+	// versions=v1 resources=deployments scope=*"); RulesHash is their
+	// hash, so any rule change is detected.
+	Rules     []string `json:"rules,omitempty"`
+	RulesHash string   `json:"rulesHash"`
+	// NamespaceSelector is the selector in label-selector syntax; empty
+	// means every namespace.
+	NamespaceSelector string `json:"namespaceSelector,omitempty"`
+	// DefinedIn locates a baseline webhook in the baseline directory; nil
+	// for live webhooks.
+	DefinedIn *SourceRef `json:"definedIn,omitempty"`
+}
+
+// NewWebhookDigest builds the digest of one webhook. Fields the API server
+// defaults (failurePolicy Fail, rule scope "*") are filled in, so a
+// baseline that leaves them out matches the live object.
+func NewWebhookDigest(kind, configuration, name string, rules []admissionregistrationv1.RuleWithOperations,
+	failurePolicy *admissionregistrationv1.FailurePolicyType, namespaceSelector *metav1.LabelSelector) WebhookDigest {
+	d := WebhookDigest{
+		Kind:          kind,
+		Configuration: configuration,
+		Name:          name,
+		FailurePolicy: string(admissionregistrationv1.Fail),
+	}
+	if failurePolicy != nil {
+		d.FailurePolicy = string(*failurePolicy)
+	}
+	for _, r := range rules {
+		d.Rules = append(d.Rules, webhookRuleString(r))
+	}
+	hash := sha256.Sum256([]byte(strings.Join(d.Rules, "\n")))
+	d.RulesHash = hex.EncodeToString(hash[:])
+	if namespaceSelector != nil {
+		if s := metav1.FormatLabelSelector(namespaceSelector); s != "<none>" {
+			d.NamespaceSelector = s
+		}
+	}
+	return d
+}
+
+func webhookRuleString(r admissionregistrationv1.RuleWithOperations) string {
+	list := func(items []string) string {
+		s := append([]string(nil), items...)
+		sort.Strings(s)
+		return strings.Join(s, ",")
+	}
+	ops := make([]string, 0, len(r.Operations))
+	for _, op := range r.Operations {
+		ops = append(ops, string(op))
+	}
+	scope := "*"
+	if r.Scope != nil {
+		scope = string(*r.Scope)
+	}
+	return fmt.Sprintf("%s groups=%s versions=%s resources=%s scope=%s",
+		list(ops), list(r.APIGroups), list(r.APIVersions), list(r.Resources), scope)
+}
+
+// WebhookConfigDrift groups the webhook drift of one configuration.
+// Missing and Extra name webhooks present on only one side; a
+// configuration missing as a whole lists all its webhooks as missing.
+type WebhookConfigDrift struct {
+	Kind    string          `json:"kind"`
+	Name    string          `json:"name"`
+	Missing []WebhookDigest `json:"missing,omitempty"`
+	Extra   []WebhookDigest `json:"extra,omitempty"`
+	Changed []WebhookChange `json:"changed,omitempty"`
+}
+
+// Empty reports whether the configuration has no drift left.
+func (d WebhookConfigDrift) Empty() bool {
+	return len(d.Missing) == 0 && len(d.Extra) == 0 && len(d.Changed) == 0
+}
+
+// WebhookChange is a webhook present on both sides whose failurePolicy,
+// rules or namespaceSelector differ. DefinedIn locates the baseline side.
+type WebhookChange struct {
+	Webhook   string               `json:"webhook"`
+	Fields    []WebhookFieldChange `json:"fields"`
+	DefinedIn *SourceRef           `json:"definedIn,omitempty"`
+}
+
+// WebhookFieldChange is one differing field. For "rules" the values are
+// the rendered rules joined with "; ".
+type WebhookFieldChange struct {
+	Field    string `json:"field"`
+	Baseline string `json:"baseline"`
+	Live     string `json:"live"`
+}
@@ -0,0 +1,317 @@
+package source
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// gitPrefix marks a -baseline value as a Git repository to clone
+// ("git::https://host/org/repo.git?ref=v1.2").
+const gitPrefix = "git::"
+
+// IsRemote reports whether a -baseline value points at a remote location
+// rather than a local path.
+func IsRemote(baseline string) bool {
+	return strings.HasPrefix(baseline, "https://") || strings.HasPrefix(baseline, "http://") ||
+		strings.HasPrefix(baseline, gitPrefix)
+}
+
+// Stdin is the -baseline value that reads the baseline as one
+// multi-document YAML stream from standard input.
+const Stdin = "-"
+
+// StdinFile is the file name stdin is saved as, and so the file findings
+// from a stdin baseline are defined in.
+const StdinFile = "stdin.yaml"
+
+// Resolve turns a -baseline value into a local directory or YAML file the
+// collectors can walk. Local paths are returned unchanged. Stdin ("-") is
+// saved into a temp directory, as the collectors each read the baseline.
+// Remote baselines are fetched into a temp directory: "git::<url>" is
+// cloned (at the revision of an optional ?ref=), a URL to a .yaml/.yml
+// file is saved as that file, and any other URL is taken to be a
+// (gzipped) tarball and extracted. Downloads are optionally verified
+// against wantSHA256 (hex). maxFileBytes, when positive, bounds stdin, the
+// download and each file extracted from a tarball, like the collectors
+// bound each baseline file. The returned cleanup func removes the temp
+// directory.
+func Resolve(ctx context.Context, baseline, wantSHA256 string, maxFileBytes int64) (string, func(), error) {
+	noop := func() {}
+	if !IsRemote(baseline) {
+		if wantSHA256 != "" {
+			return "", noop, fmt.Errorf("-baseline-sha256 is only supported for remote baselines")
+		}
+		if baseline == Stdin {
+			return saveStdin(maxFileBytes)
+		}
+		if info, err := os.Stat(baseline); err == nil && !info.IsDir() && !isYAMLName(baseline) && !isJSONName(baseline) {
+			return "", noop, fmt.Errorf("baseline file %s must have a .yaml or .yml extension (or .json for a snapshot)", baseline)
+		}
+		return baseline, noop, nil
+	}
+
+	if strings.HasPrefix(baseline, gitPrefix) && wantSHA256 != "" {
+		return "", noop, fmt.Errorf("-baseline-sha256 is not supported for git:: baselines; pin a commit with ?ref= instead")
+	}
+
+	dir, err := os.MkdirTemp("", "driftwatch-baseline-")
+	if err != nil {
+		return "", noop, fmt.Errorf("creating temp dir: %w", err)
+	}
+	cleanup := func() { os.RemoveAll(dir) }
+
+	if repo, ok := strings.CutPrefix(baseline, gitPrefix); ok {
+		if err := gitClone(ctx, repo, dir); err != nil {
+			cleanup()
+			return "", noop, err
+		}
+		return dir, cleanup, nil
+	}
+
+	archive, err := download(ctx, baseline, wantSHA256, maxFileBytes)
+	if err != nil {
+		cleanup()
+		return "", noop, err
+	}
+	defer os.Remove(archive)
+
+	if name := urlFileName(baseline); isYAMLName(name) {
+		if err := os.Rename(archive, filepath.Join(dir, name)); err != nil {
+			cleanup()
+			return "", noop, fmt.Errorf("saving %s: %w", baseline, err)
+		}
+		return dir, cleanup, nil
+	}
+	if err := extractTarball(archive, dir, maxFileBytes); err != nil {
+		cleanup()
+		return "", noop, fmt.Errorf("extracting %s: %w", baseline, err)
+	}
+	return dir, cleanup, nil
+}
+
+// urlFileName returns the last path element of rawURL, ignoring any query.
+func urlFileName(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return path.Base(u.Path)
+}
+
+// gitClone checks out repo ("https://host/org/repo.git", optionally with
+// ?ref=<branch, tag or commit>) into dir with the git CLI, fetching only
+// that revision. The .git directory is removed afterwards.
+func gitClone(ctx context.Context, repo, dir string) error {
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+	defer cancel()
+
+	u, err := url.Parse(repo)
+	if err != nil {
+		return fmt.Errorf("parsing git URL %s: %w", repo, err)
+	}
+	q := u.Query()
+	ref := q.Get("ref")
+	q.Del("ref")
+	u.RawQuery = q.Encode()
+
+	git := func(args ...string) error {
+		cmd := exec.CommandContext(ctx, "git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("git %s %s: %w: %s", args[0], u.Redacted(), err, strings.TrimSpace(string(out)))
+		}
+		return nil
+	}
+	if ref == "" {
+		err = git("clone", "--quiet", "--depth", "1", "--", u.String(), ".")
+	} else {
+		// fetch by ref rather than clone --branch, which rejects commit SHAs
+		err = git("init", "--quiet")
+		if err == nil {
+			err = git("fetch", "--quiet", "--depth", "1", "--", u.String(), ref)
+		}
+		if err == nil {
+			err = git("checkout", "--quiet", "FETCH_HEAD")
+		}
+	}
+	if err != nil {
+		return err
+	}
+	return os.RemoveAll(filepath.Join(dir, ".git"))
+}
+
+// saveStdin copies standard input into StdinFile in a new temp directory.
+func saveStdin(maxFileBytes int64) (string, func(), error) {
+	noop := func() {}
+	dir, err := os.MkdirTemp("", "driftwatch-baseline-")
+	if err != nil {
+		return "", noop, fmt.Errorf("creating temp dir: %w", err)
+	}
+	cleanup := func() { os.RemoveAll(dir) }
+
+	f, err := os.Create(filepath.Join(dir, StdinFile))
+	if err != nil {
+		cleanup()
+		return "", noop, fmt.Errorf("creating temp file: %w", err)
+	}
+	err = copyLimited(f, os.Stdin, maxFileBytes)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		cleanup()
+		return "", noop, fmt.Errorf("reading baseline from stdin: %w", err)
+	}
+	return dir, cleanup, nil
+}
+
+func isYAMLName(path string) bool {
+	name := strings.ToLower(filepath.Base(path))
+	return strings.HasSuffix(name, ".yaml") || strings.HasSuffix(name, ".yml")
+}
+
+func isJSONName(path string) bool {
+	return strings.HasSuffix(strings.ToLower(path), ".json")
+}
+
+// download fetches url into a temp file, verifying its SHA-256 if requested.
+// A response larger than maxFileBytes (when positive) fails.
+func download(ctx context.Context, url, wantSHA256 string, maxFileBytes int64) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", fmt.Errorf("building request for %s: %w", url, err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("fetching %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("fetching %s: unexpected status %s", url, resp.Status)
+	}
+
+	f, err := os.CreateTemp("", "driftwatch-baseline-*.tar")
+	if err != nil {
+		return "", fmt.Errorf("creating temp file: %w", err)
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if err := copyLimited(io.MultiWriter(f, h), resp.Body, maxFileBytes); err != nil {
+		os.Remove(f.Name())
+		return "", fmt.Errorf("downloading %s: %w", url, err)
+	}
+
+	if wantSHA256 != "" {
+		got := hex.EncodeToString(h.Sum(nil))
+		if !strings.EqualFold(got, strings.TrimSpace(wantSHA256)) {
+			os.Remove(f.Name())
+			return "", fmt.Errorf("checksum mismatch for %s: got sha256 %s, want %s", url, got, wantSHA256)
+		}
+	}
+
+	return f.Name(), nil
+}
+
+// extractTarball unpacks a (optionally gzip-compressed) tar archive into dst,
+// refusing entries that would escape dst or, when maxFileBytes is positive,
+// are larger than it.
+func extractTarball(path, dst string, maxFileBytes int64) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if gz, err := gzip.NewReader(f); err == nil {
+		defer gz.Close()
+		r = gz
+	} else {
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+	}
+
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		root := filepath.Clean(dst)
+		target := filepath.Join(dst, filepath.Clean("/"+hdr.Name))
+		if target == root {
+			// the "./" entry of `tar czf b.tgz .`; dst already exists
+			continue
+		}
+		if !strings.HasPrefix(target, root+string(os.PathSeparator)) {
+			return fmt.Errorf("archive entry %q escapes destination", hdr.Name)
+		}
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0o755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if maxFileBytes > 0 && hdr.Size > maxFileBytes {
+				return fmt.Errorf("archive entry %q is %d bytes, exceeds baseline size limit of %d bytes", hdr.Name, hdr.Size, maxFileBytes)
+			}
+			if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+				return err
+			}
+			out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+			if err != nil {
+				return err
+			}
+			if err := copyLimited(out, tr, maxFileBytes); err != nil {
+				out.Close()
+				return fmt.Errorf("archive entry %q: %w", hdr.Name, err)
+			}
+			if err := out.Close(); err != nil {
+				return err
+			}
+		default:
+			// skip symlinks and other special entries
+		}
+	}
+}
+
+// copyLimited copies src to dst, failing once src has more than limit
+// bytes when limit is positive.
+func copyLimited(dst io.Writer, src io.Reader, limit int64) error {
+	if limit <= 0 {
+		_, err := io.Copy(dst, src)
+		return err
+	}
+	n, err := io.Copy(dst, io.LimitReader(src, limit+1))
+	if err != nil {
+		return err
+	}
+	if n > limit {
+		return fmt.Errorf("baseline file exceeds %d bytes", limit)
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package source
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeTarball writes a gzipped tar of entries (name to content; a name
+// ending in "/" is a directory) to a temp file and returns its path.
+func writeTarball(t *testing.T, entries [][2]string) string {
+	t.Helper()
+	f, err := os.Create(filepath.Join(t.TempDir(), "baseline.tgz"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	for _, e := range entries {
+		hdr := &tar.Header{Name: e[0], Mode: 0o644, Typeflag: tar.TypeReg, Size: int64(len(e[1]))}
+		if e[0][len(e[0])-1] == '/' {
+			hdr.Typeflag, hdr.Mode, hdr.Size = tar.TypeDir, 0o755, 0
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(e[1])); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return f.Name()
+}
+
+// `tar czf b.tgz .` names its entries "./", "./rbac/", "./rbac/roles.yaml".
+func TestExtractTarballDotPrefixed(t *testing.T) {
+	archive := writeTarball(t, [][2]string{
+		{"./", ""},
+		{"./rbac/", ""},
+		{"./rbac/roles.yaml", "kind: Role\n"},
+	})
+	dst := t.TempDir()
+	if err := extractTarball(archive, dst, 0); err != nil {
+		t.Fatalf("extractTarball: %v", err)
+	}
+	got, err := os.ReadFile(filepath.Join(dst, "rbac", "roles.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "kind: Role\n" {
+		t.Errorf("roles.yaml = %q", got)
+	}
+}
+
+func TestExtractTarballStaysInDestination(t *testing.T) {
+	archive := writeTarball(t, [][2]string{{"../../escape.yaml", "kind: Role\n"}})
+	dst := t.TempDir()
+	if err := extractTarball(archive, dst, 0); err != nil {
+		t.Fatalf("extractTarball: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dst, "escape.yaml")); err != nil {
+		t.Errorf("entry not confined to destination: %v", err)
+	}
+}
+
+func TestExtractTarballSizeLimit(t *testing.T) {
+	archive := writeTarball(t, [][2]string{{"roles.yaml", strings.Repeat("#", 11)}})
+	err := extractTarball(archive, t.TempDir(), 10)
+	if err == nil || !strings.Contains(err.Error(), "exceeds baseline size limit of 10 bytes") {
+		t.Errorf("err = %v, want size limit error", err)
+	}
+	if err := extractTarball(archive, t.TempDir(), 11); err != nil {
+		t.Errorf("entry at the limit: %v", err)
+	}
+}
+
+func TestResolveRemoteSizeLimit(t *testing.T) {
+	body := strings.Repeat("#", 11)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, body)
+	}))
+	defer srv.Close()
+
+	_, cleanup, err := Resolve(context.Background(), srv.URL+"/baseline.yaml", "", 10)
+	cleanup()
+	if err == nil || !strings.Contains(err.Error(), "baseline file exceeds 10 bytes") {
+		t.Errorf("err = %v, want size limit error", err)
+	}
+
+	dir, cleanup, err := Resolve(context.Background(), srv.URL+"/baseline.yaml", "", 11)
+	defer cleanup()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := os.ReadFile(filepath.Join(dir, "baseline.yaml")); err != nil || string(got) != body {
+		t.Errorf("baseline.yaml = %q, %v", got, err)
+	}
+}
+
+func TestResolveStdinSizeLimit(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdin := os.Stdin
+	os.Stdin = r
+	defer func() { os.Stdin = stdin; r.Close() }()
+	go func() {
+		w.WriteString(strings.Repeat("#", 11))
+		w.Close()
+	}()
+
+	_, cleanup, err := Resolve(context.Background(), Stdin, "", 10)
+	cleanup()
+	if err == nil || !strings.Contains(err.Error(), "baseline file exceeds 10 bytes") {
+		t.Errorf("err = %v, want size limit error", err)
+	}
+}